@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// httpClient sends the requests to the APIs and the servers, which mustn't hang a CI job forever.
+var httpClient = &http.Client{Timeout: time.Minute}
+
+// apiClient sends JSON requests to a REST API.
+type apiClient struct {
+	// base is the URL the request paths are relative to.
+	base string
+	// header is added to every request, e.g. for authentication.
+	header http.Header
+}
+
+// do sends a request with an optional JSON body to the endpoint at path,
+// and decodes the JSON response into out, if not nil.
+// An absolute URL can be used as path, e.g. to follow pagination links.
+func (c *apiClient) do(method, path string, body, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(bs)
+	}
+	target := path
+	if !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
+		target = strings.TrimSuffix(c.base, "/") + path
+	}
+	req, err := http.NewRequest(method, target, reqBody)
+	if err != nil {
+		return err
+	}
+	for name, values := range c.header {
+		req.Header[name] = values
+	}
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.send(req, out)
+}
+
+// postForm posts a form to the endpoint at path and decodes the JSON response into out, if not nil.
+func (c *apiClient) postForm(path string, form url.Values, out any) error {
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(c.base, "/")+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	for name, values := range c.header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.send(req, out)
+}
+
+// send sends req and decodes the JSON response into out, if not nil.
+func (c *apiClient) send(req *http.Request, out any) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("%v %v: %v: %s", req.Method, req.URL, resp.Status, bytes.TrimSpace(msg))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
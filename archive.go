@@ -0,0 +1,185 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+)
+
+// archiveExts are the extensions of the archives read in place of a translation root directory.
+var archiveExts = []string{".zip", ".tar.gz", ".tgz"}
+
+// isArchivePath reports whether p is an archive of translation files.
+func isArchivePath(p string) bool {
+	lower := strings.ToLower(p)
+	return slices.ContainsFunc(archiveExts, func(ext string) bool { return strings.HasSuffix(lower, ext) })
+}
+
+// isArchiveData reports whether bs is a zip or a gzip compressed archive, from its magic number.
+func isArchiveData(bs []byte) bool {
+	return bytes.HasPrefix(bs, []byte("PK\x03\x04")) || bytes.HasPrefix(bs, []byte{0x1f, 0x8b})
+}
+
+// maxArchiveContents limits the total size of the files read from an archive, which can
+// decompress to much more than its own size.
+const maxArchiveContents = 1 << 30
+
+// errArchiveTooBig is returned for the archives whose files are bigger than maxArchiveContents.
+var errArchiveTooBig = fmt.Errorf("the files are bigger than %v MiB in total", maxArchiveContents>>20)
+
+// readArchiveFile reads a file of an archive from r, of at most remaining bytes, and decreases remaining
+// by its size.
+func readArchiveFile(r io.Reader, remaining *int64) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, *remaining+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > *remaining {
+		return nil, errArchiveTooBig
+	}
+	*remaining -= int64(len(content))
+	return content, nil
+}
+
+// readArchive returns the contents of the regular files of the zip or tar.gz archive bs whose names
+// wants keeps, by their slash separated name inside the archive. name is the archive in the errors.
+// The files may only be maxArchiveContents big in total.
+func readArchive(name string, bs []byte, wants func(name string) bool) (map[string][]byte, error) {
+	contents := make(map[string][]byte)
+	remaining := int64(maxArchiveContents)
+	if bytes.HasPrefix(bs, []byte("PK\x03\x04")) {
+		r, err := zip.NewReader(bytes.NewReader(bs), int64(len(bs)))
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", name, err)
+		}
+		for _, f := range r.File {
+			if !f.Mode().IsRegular() || !wants(f.Name) {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("%v: %v: %w", name, f.Name, err)
+			}
+			content, err := readArchiveFile(rc, &remaining)
+			rc.Close()
+			if err != nil {
+				return nil, fmt.Errorf("%v: %v: %w", name, f.Name, err)
+			}
+			contents[f.Name] = content
+		}
+		return contents, nil
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(bs))
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", name, err)
+	}
+	r := tar.NewReader(gz)
+	for {
+		header, err := r.Next()
+		if err == io.EOF {
+			return contents, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", name, err)
+		}
+		file := strings.TrimPrefix(header.Name, "./")
+		if header.Typeflag != tar.TypeReg || !wants(file) {
+			continue
+		}
+		content, err := readArchiveFile(r, &remaining)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v: %w", name, file, err)
+		}
+		contents[file] = content
+	}
+}
+
+// parseTranslationFile parses the contents of a translation file, a PO one or a JSON one depending on name.
+// It also returns the english source strings of a PO file, or the raw values of a JSON one, whose arrays
+// are read as arrays says, see readTranslation.
+func parseTranslationFile(name string, bs []byte, arrays string) (translation, source Translation, raw rawValues, err error) {
+	if !isPOPath(name) {
+		translation, raw, err = decodeTranslation(bs, arrays)
+		return translation, nil, raw, err
+	}
+	entries, err := parsePO(bs)
+	if err != nil {
+		return nil, nil, rawValues{}, err
+	}
+	translation, source = poTranslation(entries)
+	return translation, source, rawValues{}, nil
+}
+
+// loadArchive loads the translation files inside the archive at p, without extracting it, as if it
+// were the translation root directory. When a language has several files, the last one by name is read.
+// The files which can't be loaded are left out and reported as findings instead.
+func loadArchive(p string, files FilesConfig) (map[string]Translation, []Finding) {
+	bs, err := os.ReadFile(p)
+	if err != nil {
+		return nil, []Finding{{Check: "files", Message: err.Error()}}
+	}
+	return loadArchiveData(p, bs, files)
+}
+
+// loadArchiveData loads the translation files inside the archive bs, named name in the findings, like loadArchive.
+func loadArchiveData(name string, bs []byte, files FilesConfig) (map[string]Translation, []Finding) {
+	match, wants, err := translationFileFilter(files)
+	if err != nil {
+		return nil, []Finding{{Check: "files", Message: err.Error()}}
+	}
+	contents, err := readArchive(name, bs, wants)
+	if err != nil {
+		return nil, []Finding{{Check: "files", Message: err.Error()}}
+	}
+	return loadFileContents(name, contents, match, files.Arrays)
+}
+
+// translationFileFilter returns the matcher of the translation files of files, and whether a slash
+// separated path relative to the translation root is one of the translation files to load.
+func translationFileFilter(files FilesConfig) (fileMatcher, func(file string) bool, error) {
+	match, err := newFileMatcher(files.Pattern)
+	if err != nil {
+		return nil, nil, err
+	}
+	wants := func(file string) bool {
+		lang, ok := match(path.Base(file))
+		depth := strings.Count(file, "/") + 1
+		return ok && files.wantsLanguage(lang) && (files.MaxDepth == 0 || depth <= files.MaxDepth)
+	}
+	return match, wants, nil
+}
+
+// loadFileContents loads the translation files of contents, by their slash separated path in the
+// root named name in the findings, like loadTranslationFiles does. When a language has several files,
+// the last one by path is read.
+func loadFileContents(name string, contents map[string][]byte, match fileMatcher, arrays string) (map[string]Translation, []Finding) {
+	paths := make(map[string]string)
+	for _, file := range sortedKeys(contents) {
+		lang, _ := match(path.Base(file))
+		paths[lang] = file
+	}
+
+	translations := make(map[string]Translation)
+	var findings []Finding
+	for lang, file := range paths {
+		translation, source, raw, err := parseTranslationFile(file, contents[file], arrays)
+		if err != nil {
+			findings = append(findings, Finding{Lang: lang, Check: "load", Message: fmt.Sprintf("%v: %v: %v", name, file, err)})
+			continue
+		}
+		translations[lang] = translation
+		findings = append(findings, skippedFindings(lang, raw)...)
+		if _, ok := paths["en"]; !ok && source != nil {
+			addPOSource(translations, source)
+		}
+	}
+	return translations, findings
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeArchive writes the files, by name, to a .zip or .tar.gz archive at path.
+func writeArchive(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if filepath.Ext(path) == ".zip" {
+		w := zip.NewWriter(f)
+		for name, content := range files {
+			part, _ := w.Create(name)
+			part.Write([]byte(content))
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	gz := gzip.NewWriter(f)
+	w := tar.NewWriter(gz)
+	for name, content := range files {
+		w.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		w.Write([]byte(content))
+	}
+	w.Close()
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadArchive(t *testing.T) {
+	files := map[string]string{
+		"export/en.json":   `{"save": "Save"}`,
+		"export/sv.json":   `{"save": "Spara"}`,
+		"export/de.json":   `{"save": `,
+		"export/fi/fi.po":  "msgid \"Save\"\nmsgstr \"Tallenna\"\n",
+		"export/notes.txt": "delivered on monday",
+	}
+	for _, name := range []string{"delivery.zip", "delivery.tar.gz"} {
+		path := filepath.Join(t.TempDir(), name)
+		writeArchive(t, path, files)
+		translations, findings := loadArchive(path, FilesConfig{})
+		want := map[string]Translation{
+			"en": {"save": "Save"},
+			"sv": {"save": "Spara"},
+			"fi": {"Save": "Tallenna"},
+		}
+		if !reflect.DeepEqual(translations, want) {
+			t.Errorf("%v: want %v, got %v", name, want, translations)
+		}
+		if len(findings) != 1 || findings[0].Lang != "de" || findings[0].Check != "load" {
+			t.Errorf("%v: unexpected findings: %v", name, findings)
+		}
+
+		translations, _ = loadArchive(path, FilesConfig{MaxDepth: 2, ExcludeLanguages: []string{"sv"}})
+		if _, ok := translations["fi"]; ok {
+			t.Errorf("%v: want fi deeper than the max depth left out", name)
+		}
+		if _, ok := translations["sv"]; ok {
+			t.Errorf("%v: want sv excluded", name)
+		}
+	}
+}
+
+func TestReadArchiveFile(t *testing.T) {
+	remaining := int64(10)
+	if content, err := readArchiveFile(strings.NewReader("123456"), &remaining); err != nil || string(content) != "123456" || remaining != 4 {
+		t.Errorf("want the content and 4 bytes remaining, got %q, %v, %v", content, remaining, err)
+	}
+	if _, err := readArchiveFile(strings.NewReader("12345"), &remaining); err != errArchiveTooBig {
+		t.Errorf("want errArchiveTooBig, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// azurePropertyEscaper escapes the values of the properties of the logging commands of Azure Pipelines,
+// and azureMessageEscaper their messages.
+// See https://learn.microsoft.com/en-us/azure/devops/pipelines/scripts/logging-commands
+var (
+	azurePropertyEscaper = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A", ";", "%3B", "]", "%5D")
+	azureMessageEscaper  = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A")
+)
+
+// reportAzure writes findings as Azure Pipelines logging commands, which show as the errors and
+// warnings of the build, on the line of their key in the translation file of their language in paths.
+// At most limits findings are written.
+func reportAzure(w io.Writer, findings []Finding, rules ruleSeverities, paths map[string]string, limits reportLimits) {
+	findings = slices.Clone(findings)
+	slices.SortStableFunc(findings, compareFindings)
+	perLang := make(map[string]int)
+	var limited []Finding
+	for _, finding := range findings {
+		if limits.total > 0 && len(limited) == limits.total {
+			break
+		}
+		if limits.perLang > 0 && perLang[finding.Lang] == limits.perLang {
+			continue
+		}
+		perLang[finding.Lang]++
+		limited = append(limited, finding)
+	}
+
+	located, outside := locateFindings(limited, paths)
+	write := func(finding Finding, location string) {
+		kind := "error"
+		if rules.isWarning(finding) {
+			kind = "warning"
+		}
+		message := reportLocale.message(finding.Message)
+		if finding.Key != "" {
+			message = finding.Lang + " " + finding.Key + ": " + message
+		}
+		fmt.Fprintf(w, "##vso[task.logissue type=%v;%vcode=%v;]%v\n",
+			kind, location, azurePropertyEscaper.Replace(rules.id(finding.Check)), azureMessageEscaper.Replace(message))
+	}
+	for _, finding := range outside {
+		write(finding, "")
+	}
+	for _, finding := range located {
+		write(finding.Finding, fmt.Sprintf("sourcepath=%v;linenumber=%v;", azurePropertyEscaper.Replace(finding.path), finding.start))
+	}
+	if len(limited) < len(findings) {
+		fmt.Fprintf(w, "##vso[task.logissue type=warning;]%v\n",
+			azureMessageEscaper.Replace(reportLocale.sprintf("…and %v more findings", len(findings)-len(limited))))
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReportAzure(t *testing.T) {
+	dir := t.TempDir()
+	svPath := filepath.Join(dir, "sv.json")
+	os.WriteFile(svPath, []byte("{\n  \"greeting\": \"Hej $namn$\",\n  \"rate\": \"100%\"\n}\n"), 0644)
+	findings := []Finding{
+		{Lang: "sv", Key: "greeting", Check: "variables", Message: "mismatch in variables: Hello $name$ ⇒ Hej $namn$"},
+		{Lang: "sv", Key: "rate", Check: "profanity", Message: "100%\nof it"},
+		{Lang: "de", Check: "languages", Message: "missing translation file"},
+	}
+	var buf bytes.Buffer
+	reportAzure(&buf, findings, ruleSeverities{}, map[string]string{"sv": svPath}, reportLimits{})
+	want := "##vso[task.logissue type=error;code=CAT005;]missing translation file\n" +
+		"##vso[task.logissue type=error;sourcepath=" + svPath + ";linenumber=2;code=VAR001;]sv greeting: mismatch in variables: Hello $name$ ⇒ Hej $namn$\n" +
+		"##vso[task.logissue type=warning;sourcepath=" + svPath + ";linenumber=3;code=TXT004;]sv rate: 100%AZP25%0Aof it\n"
+	if buf.String() != want {
+		t.Errorf("want:\n%v\ngot:\n%v", want, buf.String())
+	}
+
+	buf.Reset()
+	reportAzure(&buf, findings, ruleSeverities{}, map[string]string{"sv": svPath}, reportLimits{total: 1})
+	want = "##vso[task.logissue type=error;code=CAT005;]missing translation file\n" +
+		"##vso[task.logissue type=warning;]…and 2 more findings\n"
+	if buf.String() != want {
+		t.Errorf("limited: want:\n%v\ngot:\n%v", want, buf.String())
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+// badgeEndpoint is the JSON of a shields.io endpoint badge.
+// See https://shields.io/badges/endpoint-badge
+type badgeEndpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// badgeThresholds are the lowest coverages, in percent, of the colors of a badge.
+type badgeThresholds struct {
+	good, fair float64
+}
+
+// translationBadge returns the badge of a run: the mean coverage of the languages, and the number of
+// errors if there are any. A failed run is red, and a passing one green, yellow or orange as its coverage
+// reaches the good or the fair threshold or not.
+func translationBadge(label string, coverage map[string]float64, findings []Finding, rules ruleSeverities, failed bool, thresholds badgeThresholds) badgeEndpoint {
+	total := 100.0
+	if len(coverage) > 0 {
+		total = 0
+		for _, percent := range coverage {
+			total += percent
+		}
+		total /= float64(len(coverage))
+	}
+	errors := 0
+	for _, finding := range findings {
+		if !rules.isWarning(finding) {
+			errors++
+		}
+	}
+	// Rounding down never shows 100% before all is translated.
+	message := fmt.Sprintf("%v%%", int(total))
+	switch errors {
+	case 0:
+	case 1:
+		message += " · 1 error"
+	default:
+		message += fmt.Sprintf(" · %v errors", errors)
+	}
+	color := "orange"
+	switch {
+	case failed:
+		color = "red"
+	case total >= thresholds.good:
+		color = "brightgreen"
+	case total >= thresholds.fair:
+		color = "yellow"
+	}
+	return badgeEndpoint{SchemaVersion: 1, Label: label, Message: message, Color: color}
+}
+
+// badge runs the checks and writes a shields.io endpoint badge of the coverage and errors of the
+// translations, to be published where the badge of a README can fetch it.
+func badge(args []string) {
+	flags := flag.NewFlagSet("badge", flag.ExitOnError)
+	out := flags.String("out", "", "file the badge is written to (default standard output)")
+	label := flags.String("label", "translations", "label of the badge")
+	good := flags.Float64("good", 95, "lowest coverage percentage of a green badge")
+	fair := flags.Float64("fair", 80, "lowest coverage percentage of a yellow badge, orange below")
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v badge [flags] <translation-root-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	rootDir := flags.Arg(0)
+	if err := checkRootDir(rootDir); err != nil {
+		log.Fatal(err)
+	}
+
+	config := loadConfig(*configPath)
+	_, translations, findings := checkTranslationRoot(rootDir, config)
+	failed := failsBudgets(findings, config.Rules, languageBudgets(config))
+	endpoint := translationBadge(*label, translationCoverage(translations, config.Fallbacks), findings, config.Rules, failed, badgeThresholds{*good, *fair})
+
+	bs, err := json.MarshalIndent(endpoint, "", "  ")
+	if err != nil {
+		log.Fatalf("badge: %v", err)
+	}
+	bs = append(bs, '\n')
+	if *out == "" {
+		os.Stdout.Write(bs)
+		return
+	}
+	if err := os.WriteFile(*out, bs, 0644); err != nil {
+		log.Fatalf("badge: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "%v: %v\n", *out, endpoint.Message)
+}
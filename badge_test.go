@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestTranslationBadge(t *testing.T) {
+	thresholds := badgeThresholds{good: 95, fair: 80}
+	errors := []Finding{
+		{Lang: "sv", Key: "a", Check: "variables", Message: "mismatch"},
+		{Lang: "sv", Key: "b", Check: "variables", Message: "mismatch"},
+		{Lang: "sv", Key: "c", Check: "profanity", Message: "profanity"},
+	}
+	tests := []struct {
+		name     string
+		coverage map[string]float64
+		findings []Finding
+		failed   bool
+		message  string
+		color    string
+	}{
+		{"complete", map[string]float64{"sv": 100, "fi": 100}, nil, false, "100%", "brightgreen"},
+		{"no languages", nil, nil, false, "100%", "brightgreen"},
+		{"rounded down", map[string]float64{"sv": 100, "fi": 99.9}, nil, false, "99%", "brightgreen"},
+		{"fair", map[string]float64{"sv": 100, "fi": 70}, errors[2:], false, "85%", "yellow"},
+		{"poor", map[string]float64{"sv": 50}, errors[:1], false, "50% · 1 error", "orange"},
+		{"failed", map[string]float64{"sv": 100}, errors, true, "100% · 2 errors", "red"},
+	}
+	for _, test := range tests {
+		got := translationBadge("translations", test.coverage, test.findings, ruleSeverities{}, test.failed, thresholds)
+		want := badgeEndpoint{SchemaVersion: 1, Label: "translations", Message: test.message, Color: test.color}
+		if got != want {
+			t.Errorf("%v: want %+v, got %+v", test.name, want, got)
+		}
+	}
+}
+
+func TestTranslationBadgeWarnings(t *testing.T) {
+	findings := []Finding{{Lang: "sv", Key: "greeting", Check: "variables", Message: "mismatch in variables"}}
+	rules := newRuleSeverities(map[string]string{"variables": severityWarning})
+	got := translationBadge("translations", map[string]float64{"sv": 100}, findings, rules, failsBudgets(findings, rules, nil),
+		badgeThresholds{good: 95, fair: 80})
+	if want := (badgeEndpoint{SchemaVersion: 1, Label: "translations", Message: "100%", Color: "brightgreen"}); got != want {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
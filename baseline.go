@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"log"
+	"os"
+	"slices"
+)
+
+// defaultBaselineFile holds the accepted findings when neither the -baseline flag
+// nor the configuration file name one.
+const defaultBaselineFile = ".check-translations-baseline.json"
+
+// baselinePath returns the baseline file to use, given the -baseline flag and the configuration.
+func baselinePath(flagPath string, config Config) string {
+	switch {
+	case flagPath != "":
+		return flagPath
+	case config.Baseline != "":
+		return config.Baseline
+	}
+	return defaultBaselineFile
+}
+
+// loadBaseline loads the accepted findings from the baseline file at path.
+// A missing file is an empty baseline.
+func loadBaseline(path string) []Finding {
+	bs, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		log.Fatalf("loadBaseline: %v: %v", path, err)
+	}
+	var baseline []Finding
+	if err := json.Unmarshal(bs, &baseline); err != nil {
+		log.Fatalf("loadBaseline: %v: %v", path, err)
+	}
+	return baseline
+}
+
+// writeBaseline writes the accepted findings to the baseline file at path,
+// sorted so that the file diffs well.
+func writeBaseline(path string, baseline []Finding) error {
+	baseline = slices.Clone(baseline)
+	slices.SortFunc(baseline, compareFindings)
+	baseline = slices.Compact(baseline)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(baseline); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// compareFindings orders findings by language, key, check and message.
+func compareFindings(a, b Finding) int {
+	if c := cmp.Compare(a.Lang, b.Lang); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Key, b.Key); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Check, b.Check); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Message, b.Message)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Limits of the Code Insights API of Bitbucket Cloud.
+const (
+	bitbucketAnnotationsPerRequest = 100
+	bitbucketMaxAnnotations        = 1000
+	bitbucketMaxSummary            = 450
+)
+
+// bitbucketAnnotation is an annotation of a Code Insights report on a line of a file.
+// See https://developer.atlassian.com/cloud/bitbucket/rest/api-group-reports/
+type bitbucketAnnotation struct {
+	ExternalID     string `json:"external_id"`
+	AnnotationType string `json:"annotation_type"`
+	Summary        string `json:"summary"`
+	Details        string `json:"details,omitempty"`
+	Path           string `json:"path"`
+	Line           int    `json:"line"`
+	Severity       string `json:"severity"`
+}
+
+// bitbucketAnnotations turns findings into annotations of the translation files of their language,
+// located by locateFindings, at most bitbucketMaxAnnotations of them. The translation files are read
+// from paths, while repoPaths holds their paths relative to the root of the repository.
+func bitbucketAnnotations(findings []Finding, rules ruleSeverities, paths, repoPaths map[string]string) []bitbucketAnnotation {
+	located, _ := locateFindings(findings, paths)
+	var annotations []bitbucketAnnotation
+	for _, finding := range located[:min(len(located), bitbucketMaxAnnotations)] {
+		annotation := bitbucketAnnotation{
+			// Identical findings are the same annotation.
+			ExternalID:     hashString(strings.Join([]string{finding.Lang, finding.Key, finding.Check, finding.Message}, "\x00")),
+			AnnotationType: "BUG",
+			Summary:        fmt.Sprintf("%v %v: %v", rules.id(finding.Check), finding.Check, finding.Message),
+			Path:           repoPaths[finding.Lang],
+			Line:           finding.start,
+			Severity:       "HIGH",
+		}
+		if rules.isWarning(finding.Finding) {
+			annotation.AnnotationType = "CODE_SMELL"
+			annotation.Severity = "MEDIUM"
+		}
+		if len(annotation.Summary) > bitbucketMaxSummary {
+			annotation.Details = annotation.Summary
+			annotation.Summary = strings.ToValidUTF8(annotation.Summary[:bitbucketMaxSummary-len("…")], "") + "…"
+		}
+		annotations = append(annotations, annotation)
+	}
+	return annotations
+}
+
+// bitbucketReport returns the Code Insights report of findings.
+func bitbucketReport(findings []Finding, rules ruleSeverities, failed bool) map[string]any {
+	result := "PASSED"
+	if failed {
+		result = "FAILED"
+	}
+	warnings := 0
+	for _, finding := range findings {
+		if rules.isWarning(finding) {
+			warnings++
+		}
+	}
+	return map[string]any{
+		"title":       "check-translations",
+		"details":     fmt.Sprintf("check-translations found %v problems.", len(findings)),
+		"report_type": "BUG",
+		"reporter":    "check-translations",
+		"result":      result,
+		"data": []map[string]any{
+			{"title": "Errors", "type": "NUMBER", "value": len(findings) - warnings},
+			{"title": "Warnings", "type": "NUMBER", "value": warnings},
+			{"title": "Languages", "type": "NUMBER", "value": len(findingsByLang(findings))},
+		},
+	}
+}
+
+// bitbucketReportCommand runs the checks and reports the findings as a Code Insights report of a commit,
+// with annotations on the translation files, shown on the pull requests of Bitbucket Cloud.
+func bitbucketReportCommand(args []string) {
+	flags := flag.NewFlagSet("bitbucket-report", flag.ExitOnError)
+	repo := flags.String("repo", os.Getenv("BITBUCKET_REPO_FULL_NAME"), "repository in the workspace/slug form")
+	commit := flags.String("commit", os.Getenv("BITBUCKET_COMMIT"), "commit the report is on (default the HEAD of the repository)")
+	reportID := flags.String("report-id", "check-translations", "identifier of the report, replaced on every run")
+	var token, user, password string
+	envFlag(flags, &token, "token", "BITBUCKET_ACCESS_TOKEN", "repository or workspace access token")
+	envFlag(flags, &user, "user", "BITBUCKET_USERNAME", "user of the app password, instead of a token")
+	envFlag(flags, &password, "app-password", "BITBUCKET_APP_PASSWORD", "app password of the user")
+	api := flags.String("api", "https://api.bitbucket.org/2.0", "base URL of the Bitbucket API")
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v bitbucket-report [flags] <translation-root-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 || *repo == "" {
+		flags.Usage()
+		os.Exit(1)
+	}
+	rootDir := flags.Arg(0)
+	if err := checkRootDir(rootDir); err != nil {
+		log.Fatal(err)
+	}
+	if *commit == "" {
+		out, err := gitOutput("rev-parse", "HEAD")
+		if err != nil {
+			log.Fatalf("bitbucketReportCommand: %v", err)
+		}
+		*commit = strings.TrimSpace(string(out))
+	}
+
+	config := loadConfig(*configPath)
+	paths, _, findings := checkTranslationRoot(rootDir, config)
+	reportText(os.Stderr, findings, config.Rules)
+	repoPaths, err := repoRelativePaths(paths)
+	if err != nil {
+		log.Fatalf("bitbucketReportCommand: %v", err)
+	}
+	failed := failsBudgets(findings, config.Rules, languageBudgets(config))
+	annotations := bitbucketAnnotations(findings, config.Rules, paths, repoPaths)
+
+	// Without credentials, the requests go through the authenticating proxy of Bitbucket Pipelines.
+	header := http.Header{}
+	switch {
+	case token != "":
+		header.Set("Authorization", "Bearer "+token)
+	case user != "":
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+password)))
+	}
+	client := &apiClient{
+		base:   fmt.Sprintf("%v/repositories/%v/commit/%v/reports/%v", strings.TrimSuffix(*api, "/"), *repo, *commit, *reportID),
+		header: header,
+	}
+	if err := client.do(http.MethodPut, "", bitbucketReport(findings, config.Rules, failed), nil); err != nil {
+		log.Fatalf("bitbucketReportCommand: %v", err)
+	}
+	for rest := annotations; len(rest) > 0; {
+		batch := rest[:min(len(rest), bitbucketAnnotationsPerRequest)]
+		rest = rest[len(batch):]
+		if err := client.do(http.MethodPost, "/annotations", batch, nil); err != nil {
+			log.Fatalf("bitbucketReportCommand: %v", err)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "report %v of %v: %v annotations\n", *reportID, *commit, len(annotations))
+	if failed {
+		os.Exit(1)
+	}
+}
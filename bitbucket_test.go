@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBitbucketAnnotations(t *testing.T) {
+	dir := t.TempDir()
+	svPath := filepath.Join(dir, "sv.json")
+	os.WriteFile(svPath, []byte("{\n  \"greeting\": \"Hej $namn$\",\n  \"bad\": \"shit\"\n}\n"), 0644)
+	findings := []Finding{
+		{Lang: "sv", Key: "greeting", Check: "variables", Message: "mismatch in variables: Hello $name$ ⇒ Hej $namn$"},
+		{Lang: "sv", Key: "bad", Check: "profanity", Message: strings.Repeat("offensive ", 50)},
+		{Lang: "de", Check: "languages", Message: "missing translation file"},
+	}
+	annotations := bitbucketAnnotations(findings, ruleSeverities{}, map[string]string{"sv": svPath}, map[string]string{"sv": "locales/sv.json"})
+	if len(annotations) != 2 {
+		t.Fatalf("want 2 annotations, got %v", annotations)
+	}
+	a := annotations[0]
+	if a.Path != "locales/sv.json" || a.Line != 2 || a.Severity != "HIGH" || a.AnnotationType != "BUG" ||
+		a.Summary != "VAR001 variables: mismatch in variables: Hello $name$ ⇒ Hej $namn$" || a.Details != "" {
+		t.Errorf("unexpected annotation %+v", a)
+	}
+	a = annotations[1]
+	if a.Line != 3 || a.Severity != "MEDIUM" || len(a.Summary) > bitbucketMaxSummary || !strings.HasPrefix(a.Details, "TXT004 profanity: offensive") {
+		t.Errorf("unexpected annotation %+v", a)
+	}
+	if annotations[0].ExternalID == annotations[1].ExternalID {
+		t.Errorf("want distinct identifiers, got %v", annotations[0].ExternalID)
+	}
+
+	report := bitbucketReport(findings, ruleSeverities{}, true)
+	data := report["data"].([]map[string]any)
+	if report["result"] != "FAILED" || data[0]["value"] != 2 || data[1]["value"] != 1 || data[2]["value"] != 2 {
+		t.Errorf("unexpected report %v", report)
+	}
+}
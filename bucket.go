@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// bucket is a cloud storage bucket the translation files are read from.
+type bucket interface {
+	// list returns the names of the objects whose name starts with prefix.
+	list(prefix string) ([]string, error)
+	// get returns the contents of the object named name.
+	get(name string) ([]byte, error)
+}
+
+// metadataClient queries the metadata servers of the cloud instances, which don't exist elsewhere.
+var metadataClient = &http.Client{Timeout: time.Second}
+
+// isBucketPath reports whether p is an s3:// or gs:// URL, read in place of a translation root directory.
+func isBucketPath(p string) bool {
+	return strings.HasPrefix(p, "s3://") || strings.HasPrefix(p, "gs://")
+}
+
+// parseBucketPath returns the bucket and the prefix of the s3://bucket/prefix or gs://bucket/prefix URL p.
+// The prefix is a directory: it ends with a / unless empty.
+func parseBucketPath(p string) (b bucket, prefix string, err error) {
+	u, err := url.Parse(p)
+	if err != nil {
+		return nil, "", err
+	}
+	if u.Host == "" {
+		return nil, "", fmt.Errorf("%v: no bucket", p)
+	}
+	prefix = strings.Trim(u.Path, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	if u.Scheme == "s3" {
+		return newS3Bucket(u.Host), prefix, nil
+	}
+	return newGCSBucket(u.Host), prefix, nil
+}
+
+// loadBucket loads the translation files under the prefix of the bucket URL p, as if it were the
+// translation root directory, like loadArchive. The bucket is listed and read with the credentials
+// found as the cloud providers' own tools do, or anonymously if there are none.
+// The files which can't be loaded are left out and reported as findings instead.
+func loadBucket(p string, files FilesConfig) (map[string]Translation, []Finding) {
+	b, prefix, err := parseBucketPath(p)
+	if err != nil {
+		return nil, []Finding{{Check: "files", Message: err.Error()}}
+	}
+	match, wants, err := translationFileFilter(files)
+	if err != nil {
+		return nil, []Finding{{Check: "files", Message: err.Error()}}
+	}
+	names, err := b.list(prefix)
+	if err != nil {
+		return nil, []Finding{{Check: "files", Message: fmt.Sprintf("%v: %v", p, err)}}
+	}
+
+	contents := make(map[string][]byte)
+	var findings []Finding
+	for _, name := range names {
+		file := strings.TrimPrefix(name, prefix)
+		if strings.HasSuffix(file, "/") || !wants(file) {
+			continue
+		}
+		bs, err := b.get(name)
+		if err != nil {
+			lang, _ := match(filepath.Base(file))
+			findings = append(findings, Finding{Lang: lang, Check: "load", Message: fmt.Sprintf("%v: %v", p, err)})
+			continue
+		}
+		contents[file] = bs
+	}
+	translations, loadFindings := loadFileContents(p, contents, match, files.Arrays)
+	return translations, append(findings, loadFindings...)
+}
+
+// homeFile returns the path of a file relative to the home directory, or "" if there is none.
+func homeFile(elem ...string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(append([]string{home}, elem...)...)
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// gcsReadScope is the OAuth scope of the access tokens, which only need to read the objects.
+const gcsReadScope = "https://www.googleapis.com/auth/devstorage.read_only"
+
+// gcsBucket reads a Google Cloud Storage bucket with the JSON API, or the emulator at $STORAGE_EMULATOR_HOST.
+// See https://cloud.google.com/storage/docs/json_api/v1/objects/list
+type gcsBucket struct {
+	name     string
+	endpoint string
+	// token returns the access token on the first request, or "" to read anonymously.
+	token func() (string, error)
+}
+
+func newGCSBucket(name string) *gcsBucket {
+	b := &gcsBucket{name: name, endpoint: "https://storage.googleapis.com", token: sync.OnceValues(gcsToken)}
+	// As the Google client libraries, nothing authenticates to an emulator.
+	if host := os.Getenv("STORAGE_EMULATOR_HOST"); host != "" {
+		b.endpoint = strings.TrimSuffix(host, "/")
+		if !isURLPath(host) {
+			b.endpoint = "http://" + b.endpoint
+		}
+		b.token = func() (string, error) { return "", nil }
+	}
+	return b
+}
+
+// list returns the object names starting with prefix, following the pagination.
+func (b *gcsBucket) list(prefix string) ([]string, error) {
+	var names []string
+	query := url.Values{"prefix": {prefix}, "fields": {"items(name),nextPageToken"}}
+	for {
+		bs, err := b.request("/storage/v1/b/" + url.PathEscape(b.name) + "/o?" + query.Encode())
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Items []struct {
+				Name string `json:"name"`
+			} `json:"items"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := json.Unmarshal(bs, &page); err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			names = append(names, item.Name)
+		}
+		if page.NextPageToken == "" {
+			return names, nil
+		}
+		query.Set("pageToken", page.NextPageToken)
+	}
+}
+
+func (b *gcsBucket) get(name string) ([]byte, error) {
+	return b.request("/storage/v1/b/" + url.PathEscape(b.name) + "/o/" + url.PathEscape(name) + "?alt=media")
+}
+
+// request sends a GET of path, relative to the endpoint.
+func (b *gcsBucket) request(path string) ([]byte, error) {
+	token, err := b.token()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodGet, b.endpoint+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return fetch(httpClient, req)
+}
+
+// gcsCredentials are the fields of the service account keys, of the user credentials of gcloud and of the
+// configurations of workload identity federation which are used to get an access token.
+type gcsCredentials struct {
+	Type string `json:"type"`
+	// A service account key signs its token requests.
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+	// The user credentials are refreshed.
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token"`
+	// An external account exchanges the token of another identity provider, like the OIDC token of a CI job.
+	Audience                       string              `json:"audience"`
+	SubjectTokenType               string              `json:"subject_token_type"`
+	TokenURL                       string              `json:"token_url"`
+	ServiceAccountImpersonationURL string              `json:"service_account_impersonation_url"`
+	CredentialSource               gcsCredentialSource `json:"credential_source"`
+}
+
+// gcsCredentialSource tells where the token of an external account is read from: a file, or a URL
+// requested with headers. Its format is the token as text, or a field of a JSON object.
+type gcsCredentialSource struct {
+	File    string            `json:"file"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Format  struct {
+		Type                  string `json:"type"`
+		SubjectTokenFieldName string `json:"subject_token_field_name"`
+	} `json:"format"`
+}
+
+// gcsToken returns an access token for the application default credentials: the service account key, user
+// credentials or external account configuration at $GOOGLE_APPLICATION_CREDENTIALS, the ones of gcloud auth application-default login, then the service
+// account of the Google Cloud instance. It returns "" if there are none.
+// See https://cloud.google.com/docs/authentication/application-default-credentials
+func gcsToken() (string, error) {
+	file := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	if file == "" {
+		file = gcloudCredentialsFile()
+		if _, err := os.Stat(file); err != nil {
+			return gcsInstanceToken(), nil
+		}
+	}
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	var creds gcsCredentials
+	if err := json.Unmarshal(bs, &creds); err != nil {
+		return "", fmt.Errorf("%v: %w", file, err)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	switch creds.Type {
+	case "service_account":
+		assertion, err := gcsAssertion(creds, time.Now())
+		if err != nil {
+			return "", fmt.Errorf("%v: %w", file, err)
+		}
+		form := url.Values{"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"}, "assertion": {assertion}}
+		err = (&apiClient{base: creds.TokenURI}).postForm("", form, &token)
+		return token.AccessToken, err
+	case "authorized_user":
+		form := url.Values{
+			"grant_type":    {"refresh_token"},
+			"client_id":     {creds.ClientID},
+			"client_secret": {creds.ClientSecret},
+			"refresh_token": {creds.RefreshToken},
+		}
+		err := (&apiClient{base: "https://oauth2.googleapis.com/token"}).postForm("", form, &token)
+		return token.AccessToken, err
+	case "external_account":
+		accessToken, err := gcsExternalAccountToken(creds)
+		if err != nil {
+			return "", fmt.Errorf("%v: %w", file, err)
+		}
+		return accessToken, nil
+	}
+	return "", fmt.Errorf("%v: unsupported credentials type %q", file, creds.Type)
+}
+
+// gcsExternalAccountToken returns an access token for an external account of workload identity federation:
+// the token of its credential source is exchanged with the security token service, and then for the one of
+// the service account it impersonates, if any.
+// See https://cloud.google.com/iam/docs/workload-identity-federation-with-other-providers
+func gcsExternalAccountToken(creds gcsCredentials) (string, error) {
+	subjectToken, err := gcsSubjectToken(creds.CredentialSource)
+	if err != nil {
+		return "", err
+	}
+	// The federated token needs the cloud-platform scope to impersonate a service account.
+	scope := gcsReadScope
+	if creds.ServiceAccountImpersonationURL != "" {
+		scope = "https://www.googleapis.com/auth/cloud-platform"
+	}
+	form := url.Values{
+		"grant_type":           {"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"audience":             {creds.Audience},
+		"scope":                {scope},
+		"requested_token_type": {"urn:ietf:params:oauth:token-type:access_token"},
+		"subject_token_type":   {creds.SubjectTokenType},
+		"subject_token":        {subjectToken},
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := (&apiClient{base: creds.TokenURL}).postForm("", form, &token); err != nil {
+		return "", err
+	}
+	if creds.ServiceAccountImpersonationURL == "" {
+		return token.AccessToken, nil
+	}
+	var impersonated struct {
+		AccessToken string `json:"accessToken"`
+	}
+	client := &apiClient{header: http.Header{"Authorization": {"Bearer " + token.AccessToken}}}
+	err = client.do(http.MethodPost, creds.ServiceAccountImpersonationURL, map[string]any{"scope": []string{gcsReadScope}}, &impersonated)
+	return impersonated.AccessToken, err
+}
+
+// gcsSubjectToken reads the token of the identity provider of an external account from source.
+func gcsSubjectToken(source gcsCredentialSource) (string, error) {
+	var bs []byte
+	var err error
+	switch {
+	case source.File != "":
+		bs, err = os.ReadFile(source.File)
+	case source.URL != "":
+		var req *http.Request
+		if req, err = http.NewRequest(http.MethodGet, source.URL, nil); err == nil {
+			for name, value := range source.Headers {
+				req.Header.Set(name, value)
+			}
+			bs, err = fetch(httpClient, req)
+		}
+	default:
+		return "", fmt.Errorf("unsupported credential source, only files and URLs are")
+	}
+	if err != nil {
+		return "", err
+	}
+	if source.Format.Type != "json" {
+		return strings.TrimSpace(string(bs)), nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(bs, &fields); err != nil {
+		return "", fmt.Errorf("the subject token: %w", err)
+	}
+	token, ok := fields[source.Format.SubjectTokenFieldName].(string)
+	if !ok {
+		return "", fmt.Errorf("the subject token has no field %v", source.Format.SubjectTokenFieldName)
+	}
+	return token, nil
+}
+
+// gcloudCredentialsFile returns the path of the application default credentials of gcloud.
+func gcloudCredentialsFile() string {
+	if dir := os.Getenv("CLOUDSDK_CONFIG"); dir != "" {
+		return filepath.Join(dir, "application_default_credentials.json")
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "gcloud", "application_default_credentials.json")
+	}
+	return homeFile(".config", "gcloud", "application_default_credentials.json")
+}
+
+// gcsAssertion returns the JWT a service account exchanges for an access token, signed with its private key.
+func gcsAssertion(creds gcsCredentials, now time.Time) (string, error) {
+	return signJWT([]byte(creds.PrivateKey), map[string]any{
+		"iss":   creds.ClientEmail,
+		"scope": gcsReadScope,
+		"aud":   creds.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+}
+
+// gcsInstanceToken returns an access token of the service account of the Google Cloud instance,
+// or "" outside of Google Cloud.
+func gcsInstanceToken() string {
+	host := os.Getenv("GCE_METADATA_HOST")
+	if host == "" {
+		host = "metadata.google.internal"
+	}
+	req, err := http.NewRequest(http.MethodGet, "http://"+host+"/computeMetadata/v1/instance/service-accounts/default/token", nil)
+	if err != nil {
+		return ""
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	bs, err := fetch(metadataClient, req)
+	if err != nil {
+		return ""
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	json.Unmarshal(bs, &token)
+	return token.AccessToken
+}
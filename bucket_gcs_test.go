@@ -0,0 +1,158 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadGCSBucket(t *testing.T) {
+	objects := map[string]string{
+		"app/locales/en.json": `{"save": "Save"}`,
+		"app/locales/sv.po":   "msgid \"save\"\nmsgstr \"Spara\"\n",
+		"app/other/fi.json":   `{"save": "Tallenna"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if name, ok := strings.CutPrefix(r.URL.EscapedPath(), "/storage/v1/b/translations/o/"); ok {
+			name = strings.ReplaceAll(name, "%2F", "/")
+			if object, ok := objects[name]; ok && r.URL.Query().Get("alt") == "media" {
+				fmt.Fprint(w, object)
+			} else {
+				http.NotFound(w, r)
+			}
+			return
+		}
+		// The first page lists a single object.
+		var names []string
+		for _, name := range sortedKeys(objects) {
+			if strings.HasPrefix(name, r.URL.Query().Get("prefix")) {
+				names = append(names, fmt.Sprintf(`{"name": %q}`, name))
+			}
+		}
+		if r.URL.Query().Get("pageToken") == "" {
+			fmt.Fprintf(w, `{"items": [%v], "nextPageToken": "next"}`, names[0])
+		} else {
+			fmt.Fprintf(w, `{"items": [%v]}`, strings.Join(names[1:], ","))
+		}
+	}))
+	defer server.Close()
+	t.Setenv("STORAGE_EMULATOR_HOST", strings.TrimPrefix(server.URL, "http://"))
+
+	translations, findings := loadBucket("gs://translations/app/locales/", FilesConfig{})
+	want := map[string]Translation{"en": {"save": "Save"}, "sv": {"save": "Spara"}}
+	if !reflect.DeepEqual(translations, want) || len(findings) > 0 {
+		t.Errorf("want %v, got %v, %v", want, translations, findings)
+	}
+}
+
+func TestGCSServiceAccountToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.FormValue("assertion"), ".")
+		if len(parts) != 3 {
+			http.Error(w, "no assertion", http.StatusBadRequest)
+			return
+		}
+		hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		if rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature) != nil ||
+			!strings.Contains(string(claims), `"iss":"checker@example.iam.gserviceaccount.com"`) {
+			http.Error(w, "invalid assertion", http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"access_token": "token"}`)
+	}))
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "key.json")
+	bs, _ := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "checker@example.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":    server.URL,
+	})
+	if err := os.WriteFile(file, bs, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", file)
+	if token, err := gcsToken(); token != "token" || err != nil {
+		t.Errorf("want the token, got %q, %v", token, err)
+	}
+}
+
+func TestGCSExternalAccountToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oidc":
+			if r.Header.Get("Authorization") != "bearer ci" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"value": "oidc-token"}`)
+		case "/sts":
+			if r.FormValue("subject_token") != "oidc-token" || r.FormValue("audience") != "//iam.googleapis.com/pool" {
+				http.Error(w, "invalid exchange", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"access_token": "federated"}`)
+		case "/impersonate":
+			if r.Header.Get("Authorization") != "Bearer federated" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"accessToken": "impersonated"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	os.WriteFile(tokenFile, []byte("oidc-token"), 0o600)
+	for _, test := range []struct {
+		name   string
+		source map[string]any
+		url    string
+		want   string
+	}{
+		{"file", map[string]any{"file": tokenFile}, "", "federated"},
+		{"url", map[string]any{
+			"url": server.URL + "/oidc", "headers": map[string]string{"Authorization": "bearer ci"},
+			"format": map[string]string{"type": "json", "subject_token_field_name": "value"},
+		}, server.URL + "/impersonate", "impersonated"},
+	} {
+		file := filepath.Join(dir, test.name+".json")
+		bs, _ := json.Marshal(map[string]any{
+			"type":                              "external_account",
+			"audience":                          "//iam.googleapis.com/pool",
+			"subject_token_type":                "urn:ietf:params:oauth:token-type:jwt",
+			"token_url":                         server.URL + "/sts",
+			"service_account_impersonation_url": test.url,
+			"credential_source":                 test.source,
+		})
+		os.WriteFile(file, bs, 0o600)
+		t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", file)
+		if token, err := gcsToken(); token != test.want || err != nil {
+			t.Errorf("%v: want %q, got %q, %v", test.name, test.want, token, err)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+package main
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// s3Bucket reads an Amazon S3 bucket, or one of a compatible storage with $AWS_ENDPOINT_URL_S3.
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectsV2.html
+type s3Bucket struct {
+	name   string
+	region string
+	// endpoint is the URL of the service, with the bucket in the path, or "" for the one of AWS.
+	endpoint string
+	// credentials are looked up on the first request.
+	credentials func() (awsCredentials, error)
+}
+
+// awsCredentials are the credentials requests are signed with. They are anonymous without an access key.
+type awsCredentials struct {
+	accessKey    string
+	secretKey    string
+	sessionToken string
+}
+
+func newS3Bucket(name string) *s3Bucket {
+	profile := os.Getenv("AWS_PROFILE")
+	if profile == "" {
+		profile = "default"
+	}
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		region = awsConfigValue(profile, "region")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+	endpoint := os.Getenv("AWS_ENDPOINT_URL_S3")
+	if endpoint == "" {
+		endpoint = os.Getenv("AWS_ENDPOINT_URL")
+	}
+	return &s3Bucket{
+		name:        name,
+		region:      region,
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		credentials: sync.OnceValues(func() (awsCredentials, error) { return awsCredentialChain(profile, region) }),
+	}
+}
+
+// list returns the keys starting with prefix, following the pagination of ListObjectsV2.
+func (b *s3Bucket) list(prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		query := map[string]string{"list-type": "2", "prefix": prefix}
+		if token != "" {
+			query["continuation-token"] = token
+		}
+		bs, err := b.request("", query)
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			Contents []struct {
+				Key string `xml:"Key"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		if err := xml.Unmarshal(bs, &result); err != nil {
+			return nil, err
+		}
+		for _, object := range result.Contents {
+			keys = append(keys, object.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+func (b *s3Bucket) get(key string) ([]byte, error) {
+	return b.request(key, nil)
+}
+
+// request sends a GET of the object key, or of the bucket if key is "", with the query parameters query.
+func (b *s3Bucket) request(key string, query map[string]string) ([]byte, error) {
+	creds, err := b.credentials()
+	if err != nil {
+		return nil, err
+	}
+	// The bucket is in the host name for AWS, and in the path for the other endpoints.
+	base := fmt.Sprintf("https://%v.s3.%v.amazonaws.com", b.name, b.region)
+	if b.endpoint != "" {
+		base = b.endpoint + "/" + awsEscape(b.name, false)
+	}
+	target := base + "/" + awsEscape(key, true)
+	var params []string
+	for _, name := range sortedKeys(query) {
+		params = append(params, awsEscape(name, false)+"="+awsEscape(query[name], false))
+	}
+	if len(params) > 0 {
+		target += "?" + strings.Join(params, "&")
+	}
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if creds.accessKey != "" {
+		signAWSRequest(req, creds, b.region, "s3", time.Now())
+	}
+	return fetch(httpClient, req)
+}
+
+// emptyPayloadHash is the SHA-256 of the empty body of the GET requests.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// signAWSRequest signs the body-less req with Signature Version 4, including all its headers.
+// See https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv.html
+func signAWSRequest(req *http.Request, creds awsCredentials, region, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+	if creds.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := sortedKeys(headers)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%v:%v\n", name, headers[name])
+	}
+	signedHeaders := strings.Join(names, ";")
+	// The query parameters are sorted by name, then by value.
+	params := strings.Split(req.URL.RawQuery, "&")
+	if req.URL.RawQuery == "" {
+		params = nil
+	}
+	for i, param := range params {
+		if !strings.Contains(param, "=") {
+			params[i] += "="
+		}
+	}
+	sort.Strings(params)
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method, path, strings.Join(params, "&"), canonicalHeaders.String(), signedHeaders, emptyPayloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%v/%v/%v/aws4_request", now.Format("20060102"), region, service)
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+	key := []byte("AWS4" + creds.secretKey)
+	for _, part := range []string{now.Format("20060102"), region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%v/%v, SignedHeaders=%v, Signature=%v",
+		creds.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// awsEscape percent-encodes s as the signatures expect: everything but the unreserved characters,
+// and the slashes as well unless path.
+func awsEscape(s string, path bool) string {
+	var escaped strings.Builder
+	for _, b := range []byte(s) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', strings.IndexByte("-._~", b) >= 0, path && b == '/':
+			escaped.WriteByte(b)
+		default:
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+	return escaped.String()
+}
+
+// awsCredentialChain looks up the credentials like the AWS CLI does: in the environment, in the shared
+// credentials file for profile, by assuming a role with a web identity token, like the ones of EKS and of
+// the OIDC providers of CI services, then from the container and the EC2 instance metadata endpoints.
+// The credentials are anonymous if there are none.
+func awsCredentialChain(profile, region string) (awsCredentials, error) {
+	if key := os.Getenv("AWS_ACCESS_KEY_ID"); key != "" {
+		return awsCredentials{key, os.Getenv("AWS_SECRET_ACCESS_KEY"), os.Getenv("AWS_SESSION_TOKEN")}, nil
+	}
+	if key := awsCredentialsValue(profile, "aws_access_key_id"); key != "" {
+		return awsCredentials{
+			key, awsCredentialsValue(profile, "aws_secret_access_key"), awsCredentialsValue(profile, "aws_session_token"),
+		}, nil
+	}
+	if creds, ok, err := awsWebIdentityCredentials(profile, region); ok || err != nil {
+		return creds, err
+	}
+	if creds, ok, err := awsContainerCredentials(); ok || err != nil {
+		return creds, err
+	}
+	if os.Getenv("AWS_EC2_METADATA_DISABLED") != "true" {
+		if creds, err := awsInstanceCredentials(); err == nil {
+			return creds, nil
+		}
+	}
+	return awsCredentials{}, nil
+}
+
+// awsMetadataCredentials are the temporary credentials of the container and instance metadata endpoints.
+type awsMetadataCredentials struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	Token           string `json:"Token"`
+}
+
+// awsWebIdentityCredentials returns the credentials of the role assumed with the web identity token of
+// $AWS_WEB_IDENTITY_TOKEN_FILE and $AWS_ROLE_ARN, or of the web_identity_token_file and role_arn of profile,
+// if there is one. The token is exchanged with STS in region, or at $AWS_ENDPOINT_URL_STS.
+// See https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRoleWithWebIdentity.html
+func awsWebIdentityCredentials(profile, region string) (awsCredentials, bool, error) {
+	tokenFile, role := os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"), os.Getenv("AWS_ROLE_ARN")
+	if tokenFile == "" {
+		tokenFile, role = awsConfigValue(profile, "web_identity_token_file"), awsConfigValue(profile, "role_arn")
+	}
+	if tokenFile == "" {
+		return awsCredentials{}, false, nil
+	}
+	if role == "" {
+		return awsCredentials{}, true, fmt.Errorf("web identity: no role to assume with %v", tokenFile)
+	}
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return awsCredentials{}, true, fmt.Errorf("web identity: %w", err)
+	}
+	session := os.Getenv("AWS_ROLE_SESSION_NAME")
+	if session == "" {
+		session = "check-translations"
+	}
+	endpoint := os.Getenv("AWS_ENDPOINT_URL_STS")
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://sts.%v.amazonaws.com", region)
+	}
+	form := url.Values{
+		"Action":           {"AssumeRoleWithWebIdentity"},
+		"Version":          {"2011-06-15"},
+		"RoleArn":          {role},
+		"RoleSessionName":  {session},
+		"WebIdentityToken": {strings.TrimSpace(string(token))},
+	}
+	// The request is authenticated by the token only, and isn't signed.
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(endpoint, "/")+"/", strings.NewReader(form.Encode()))
+	if err != nil {
+		return awsCredentials{}, true, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	bs, err := fetch(httpClient, req)
+	if err != nil {
+		return awsCredentials{}, true, fmt.Errorf("web identity: %w", err)
+	}
+	var result struct {
+		Credentials struct {
+			AccessKeyID     string `xml:"AccessKeyId"`
+			SecretAccessKey string `xml:"SecretAccessKey"`
+			SessionToken    string `xml:"SessionToken"`
+		} `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+	}
+	if err := xml.Unmarshal(bs, &result); err != nil {
+		return awsCredentials{}, true, fmt.Errorf("web identity: %w", err)
+	}
+	creds := result.Credentials
+	return awsCredentials{creds.AccessKeyID, creds.SecretAccessKey, creds.SessionToken}, true, nil
+}
+
+// awsContainerCredentials returns the credentials of the ECS task role, if there is one.
+func awsContainerCredentials() (awsCredentials, bool, error) {
+	endpoint := os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI")
+	if relative := os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"); relative != "" {
+		endpoint = "http://169.254.170.2" + relative
+	}
+	if endpoint == "" {
+		return awsCredentials{}, false, nil
+	}
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return awsCredentials{}, true, err
+	}
+	if token := os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN"); token != "" {
+		req.Header.Set("Authorization", token)
+	}
+	bs, err := fetch(metadataClient, req)
+	if err != nil {
+		return awsCredentials{}, true, fmt.Errorf("container credentials: %w", err)
+	}
+	var creds awsMetadataCredentials
+	if err := json.Unmarshal(bs, &creds); err != nil {
+		return awsCredentials{}, true, fmt.Errorf("container credentials: %w", err)
+	}
+	return awsCredentials{creds.AccessKeyID, creds.SecretAccessKey, creds.Token}, true, nil
+}
+
+// awsInstanceCredentials returns the credentials of the role of the EC2 instance, with IMDSv2.
+func awsInstanceCredentials() (awsCredentials, error) {
+	const imds = "http://169.254.169.254/latest"
+	req, err := http.NewRequest(http.MethodPut, imds+"/api/token", nil)
+	if err != nil {
+		return awsCredentials{}, err
+	}
+	req.Header.Set("X-Aws-Ec2-Metadata-Token-Ttl-Seconds", "60")
+	token, err := fetch(metadataClient, req)
+	if err != nil {
+		return awsCredentials{}, err
+	}
+	get := func(path string) ([]byte, error) {
+		req, err := http.NewRequest(http.MethodGet, imds+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-Aws-Ec2-Metadata-Token", string(token))
+		return fetch(metadataClient, req)
+	}
+	role, err := get("/meta-data/iam/security-credentials/")
+	if err != nil {
+		return awsCredentials{}, err
+	}
+	bs, err := get("/meta-data/iam/security-credentials/" + strings.TrimSpace(string(role)))
+	if err != nil {
+		return awsCredentials{}, err
+	}
+	var creds awsMetadataCredentials
+	if err := json.Unmarshal(bs, &creds); err != nil {
+		return awsCredentials{}, err
+	}
+	return awsCredentials{creds.AccessKeyID, creds.SecretAccessKey, creds.Token}, nil
+}
+
+// awsCredentialsValue returns a value of profile in the shared credentials file, or "".
+func awsCredentialsValue(profile, name string) string {
+	file := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if file == "" {
+		file = homeFile(".aws", "credentials")
+	}
+	return iniValue(file, profile, name)
+}
+
+// awsConfigValue returns a value of profile in the shared config file, or "".
+func awsConfigValue(profile, name string) string {
+	file := os.Getenv("AWS_CONFIG_FILE")
+	if file == "" {
+		file = homeFile(".aws", "config")
+	}
+	// The sections of the profiles other than the default one are named "profile <name>" in the config file.
+	if profile != "default" {
+		profile = "profile " + profile
+	}
+	return iniValue(file, profile, name)
+}
+
+// iniValue returns the value of name in the section of the INI file, or "" if the file doesn't have it.
+func iniValue(file, section, name string) string {
+	f, err := os.Open(file)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	current := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if ok && current == section && strings.TrimSpace(key) == name {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
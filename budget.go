@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Budget is the number of findings a language is allowed to have before failing the run.
+type Budget struct {
+	// Errors is the number of errors allowed, 0 by default.
+	Errors int `json:"errors"`
+	// Warnings is the number of warnings allowed, unlimited by default.
+	Warnings *int `json:"warnings"`
+}
+
+// budgetExcess is a count of findings of a language over its budget.
+type budgetExcess struct {
+	lang string
+	// warnings is set for the warnings, and unset for the errors.
+	warnings       bool
+	count, allowed int
+}
+
+// validateBudgets returns an error unless the budgets of config allow positive counts.
+func validateBudgets(budgets map[string]Budget) error {
+	for _, name := range sortedKeys(budgets) {
+		budget := budgets[name]
+		if budget.Errors < 0 || budget.Warnings != nil && *budget.Warnings < 0 {
+			return fmt.Errorf("budget of %v must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// languageBudgets returns the budgets of the languages of config, by language. The budget of a tier is
+// the one of its languages, with the tightest counts for the languages of several tiers, and the budget
+// of a language itself has precedence over the ones of its tiers.
+func languageBudgets(config Config) map[string]Budget {
+	budgets := make(map[string]Budget)
+	for _, name := range sortedKeys(config.Budgets) {
+		tier, ok := config.Tiers[name]
+		if !ok {
+			continue
+		}
+		for _, lang := range tier {
+			budget, ok := budgets[lang]
+			if !ok {
+				budgets[lang] = config.Budgets[name]
+				continue
+			}
+			budget.Errors = min(budget.Errors, config.Budgets[name].Errors)
+			if warnings := config.Budgets[name].Warnings; warnings != nil && (budget.Warnings == nil || *warnings < *budget.Warnings) {
+				budget.Warnings = warnings
+			}
+			budgets[lang] = budget
+		}
+	}
+	for name, budget := range config.Budgets {
+		if _, ok := config.Tiers[name]; !ok {
+			budgets[name] = budget
+		}
+	}
+	return budgets
+}
+
+// overBudget returns the counts of findings of the languages of budgets which exceed them, sorted.
+func overBudget(findings []Finding, rules ruleSeverities, budgets map[string]Budget) (result []budgetExcess) {
+	errors := make(map[string]int)
+	warnings := make(map[string]int)
+	for _, finding := range findings {
+		if rules.isWarning(finding) {
+			warnings[finding.Lang]++
+		} else {
+			errors[finding.Lang]++
+		}
+	}
+	for _, lang := range sortedKeys(budgets) {
+		budget := budgets[lang]
+		if errors[lang] > budget.Errors {
+			result = append(result, budgetExcess{lang, false, errors[lang], budget.Errors})
+		}
+		if budget.Warnings != nil && warnings[lang] > *budget.Warnings {
+			result = append(result, budgetExcess{lang, true, warnings[lang], *budget.Warnings})
+		}
+	}
+	return result
+}
+
+// failsBudgets reports whether findings, of the severities of rules, fail the run with budgets:
+// if a language exceeds its budget, or if a language without one has errors.
+func failsBudgets(findings []Finding, rules ruleSeverities, budgets map[string]Budget) bool {
+	return len(overBudget(findings, rules, budgets)) > 0 || slices.ContainsFunc(findings, func(f Finding) bool {
+		_, ok := budgets[f.Lang]
+		return !ok && !rules.isWarning(f)
+	})
+}
+
+// reportBudgets writes the counts of findings over the budgets of their languages.
+func reportBudgets(w io.Writer, exceeded []budgetExcess) {
+	for _, excess := range exceeded {
+		format := "budget of %v: %v errors, over the %v allowed"
+		if excess.warnings {
+			format = "budget of %v: %v warnings, over the %v allowed"
+		}
+		fmt.Fprintln(w, reportLocale.sprintf(format, excess.lang, excess.count, excess.allowed))
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLanguageBudgets(t *testing.T) {
+	five, fifty := 5, 50
+	config := Config{
+		Tiers: map[string][]string{"tier-2": {"fi", "pl"}, "tier-3": {"pl", "ro"}},
+		Budgets: map[string]Budget{
+			"tier-2": {Errors: 2, Warnings: &fifty},
+			"tier-3": {Errors: 10, Warnings: &five},
+			"ro":     {Errors: 1},
+		},
+	}
+	want := map[string]Budget{
+		"fi": {Errors: 2, Warnings: &fifty},
+		"pl": {Errors: 2, Warnings: &five},
+		"ro": {Errors: 1},
+	}
+	if got := languageBudgets(config); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestOverBudget(t *testing.T) {
+	one := 1
+	budgets := map[string]Budget{"fi": {Errors: 1, Warnings: &one}, "pl": {Errors: 2}}
+	findings := []Finding{
+		{Lang: "fi", Key: "a", Check: "variables"},
+		{Lang: "fi", Key: "b", Check: "profanity"},
+		{Lang: "fi", Key: "c", Check: "profanity"},
+		{Lang: "pl", Key: "a", Check: "variables"},
+		{Lang: "pl", Key: "b", Check: "variables"},
+		{Lang: "pl", Key: "c", Check: "profanity"},
+	}
+	want := []budgetExcess{{"fi", true, 2, 1}}
+	if got := overBudget(findings, ruleSeverities{}, budgets); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if !failsBudgets(findings, ruleSeverities{}, budgets) {
+		t.Error("want a failure over a budget")
+	}
+	if failsBudgets(findings[3:], ruleSeverities{}, budgets) {
+		t.Error("want no failure within the budgets")
+	}
+	if !failsBudgets(append(findings[3:], Finding{Lang: "sv", Key: "a", Check: "variables"}), ruleSeverities{}, budgets) {
+		t.Error("want a failure of an error without a budget")
+	}
+	if err := validateBudgets(map[string]Budget{"fi": {Errors: -1}}); err == nil {
+		t.Error("want an error for a negative budget")
+	}
+}
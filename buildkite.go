@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// buildkiteMaxBody is the largest body of a Buildkite annotation, 1 MiB.
+const buildkiteMaxBody = 1 << 20
+
+// buildkiteAnnotation returns the Markdown body of the Buildkite annotation of findings, with their
+// counts by language and the report of every language, and its style. The reports of the languages
+// which don't fit in buildkiteMaxBody are left out.
+func buildkiteAnnotation(findings []Finding, rules ruleSeverities, failed bool) (body, style string) {
+	var buf strings.Builder
+	if len(findings) == 0 {
+		buf.WriteString("### check-translations: no problems\n")
+		return buf.String(), "success"
+	}
+	style = "warning"
+	if failed {
+		style = "error"
+	}
+	fmt.Fprintf(&buf, "### check-translations: %v problems\n\n| Language | Errors | Warnings |\n| --- | --- | --- |\n", len(findings))
+	byLang := findingsByLang(findings)
+	langs := sortedKeys(byLang)
+	names := make(map[string]string)
+	for _, lang := range langs {
+		names[lang] = lang
+		if lang == "" {
+			names[lang] = "files"
+		}
+		warnings := 0
+		for _, finding := range byLang[lang] {
+			if rules.isWarning(finding) {
+				warnings++
+			}
+		}
+		fmt.Fprintf(&buf, "| %v | %v | %v |\n", names[lang], len(byLang[lang])-warnings, warnings)
+	}
+	for i, lang := range langs {
+		var report bytes.Buffer
+		reportText(&report, byLang[lang], rules)
+		details := fmt.Sprintf("\n<details>\n<summary>%v: %v problems</summary>\n\n```\n%v```\n\n</details>\n",
+			names[lang], len(byLang[lang]), report.String())
+		// Room is kept for the note of the left out reports.
+		if buf.Len()+len(details) > buildkiteMaxBody-100 {
+			fmt.Fprintf(&buf, "\nThe reports of %v more languages are in the log.\n", len(langs)-i)
+			break
+		}
+		buf.WriteString(details)
+	}
+	return buf.String(), style
+}
+
+// buildkiteAnnotate runs the checks and reports the findings as an annotation of the Buildkite build,
+// shown at the top of its page, with buildkite-agent annotate.
+// See https://buildkite.com/docs/agent/v3/cli-annotate
+func buildkiteAnnotate(args []string) {
+	flags := flag.NewFlagSet("buildkite-annotate", flag.ExitOnError)
+	context := flags.String("context", "check-translations", "context of the annotation, replaced on every run")
+	stdout := flags.Bool("stdout", false, "write the Markdown of the annotation to standard output instead of running buildkite-agent")
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v buildkite-annotate [flags] <translation-root-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	rootDir := flags.Arg(0)
+	if err := checkRootDir(rootDir); err != nil {
+		log.Fatal(err)
+	}
+
+	config := loadConfig(*configPath)
+	_, _, findings := checkTranslationRoot(rootDir, config)
+	reportText(os.Stderr, findings, config.Rules)
+	failed := failsBudgets(findings, config.Rules, languageBudgets(config))
+	body, style := buildkiteAnnotation(findings, config.Rules, failed)
+	if *stdout {
+		fmt.Print(body)
+	} else {
+		cmd := exec.Command("buildkite-agent", "annotate", "--style", style, "--context", *context)
+		cmd.Stdin = strings.NewReader(body)
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			log.Fatalf("buildkiteAnnotate: %v", err)
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildkiteAnnotation(t *testing.T) {
+	findings := []Finding{
+		{Lang: "sv", Key: "greeting", Check: "variables", Message: "mismatch in variables"},
+		{Lang: "sv", Key: "rate", Check: "profanity", Message: "profanity"},
+		{Check: "files", Message: "unreadable file"},
+	}
+	body, style := buildkiteAnnotation(findings, ruleSeverities{}, true)
+	want := "### check-translations: 3 problems\n\n" +
+		"| Language | Errors | Warnings |\n| --- | --- | --- |\n" +
+		"| files | 1 | 0 |\n" +
+		"| sv | 1 | 1 |\n" +
+		"\n<details>\n<summary>files: 1 problems</summary>\n\n```\n[files]\n    unreadable file (FILE001)\n```\n\n</details>\n"
+	if !strings.HasPrefix(body, want) || style != "error" {
+		t.Errorf("want %q, prefix:\n%v\ngot %q:\n%v", "error", want, style, body)
+	}
+	if !strings.Contains(body, "<summary>sv: 2 problems</summary>") {
+		t.Errorf("no report of sv:\n%v", body)
+	}
+
+	if _, style := buildkiteAnnotation(findings[1:2], ruleSeverities{}, false); style != "warning" {
+		t.Errorf("warnings: want warning, got %q", style)
+	}
+	if body, style := buildkiteAnnotation(nil, ruleSeverities{}, false); style != "success" || body != "### check-translations: no problems\n" {
+		t.Errorf("no findings: got %q: %q", style, body)
+	}
+}
+
+func TestBuildkiteAnnotationWarnings(t *testing.T) {
+	findings := []Finding{{Lang: "sv", Key: "greeting", Check: "variables", Message: "mismatch in variables"}}
+	rules := newRuleSeverities(map[string]string{"variables": severityWarning})
+	failed := failsBudgets(findings, rules, nil)
+	body, style := buildkiteAnnotation(findings, rules, failed)
+	if failed || style != "warning" || !strings.Contains(body, "| sv | 0 | 1 |") {
+		t.Errorf("want a passing warning annotation, got failed %v, %q:\n%v", failed, style, body)
+	}
+}
@@ -0,0 +1,188 @@
+// The gRPC API of the check-translations server mode.
+// The Go stubs in checktranslationsv1 are generated from this file with go generate, see grpc.go.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: checker.proto
+
+package checktranslationsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Checker_CheckCatalog_FullMethodName = "/checktranslations.v1.Checker/CheckCatalog"
+	Checker_CheckString_FullMethodName  = "/checktranslations.v1.Checker/CheckString"
+)
+
+// CheckerClient is the client API for Checker service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CheckerClient interface {
+	// CheckCatalog checks a whole catalog and returns all the findings.
+	CheckCatalog(ctx context.Context, in *CheckCatalogRequest, opts ...grpc.CallOption) (*CheckCatalogResponse, error)
+	// CheckString checks single strings against the english reference loaded by the server.
+	// Every request is answered with one response, in order.
+	CheckString(ctx context.Context, opts ...grpc.CallOption) (Checker_CheckStringClient, error)
+}
+
+type checkerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCheckerClient(cc grpc.ClientConnInterface) CheckerClient {
+	return &checkerClient{cc}
+}
+
+func (c *checkerClient) CheckCatalog(ctx context.Context, in *CheckCatalogRequest, opts ...grpc.CallOption) (*CheckCatalogResponse, error) {
+	out := new(CheckCatalogResponse)
+	err := c.cc.Invoke(ctx, Checker_CheckCatalog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkerClient) CheckString(ctx context.Context, opts ...grpc.CallOption) (Checker_CheckStringClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Checker_ServiceDesc.Streams[0], Checker_CheckString_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &checkerCheckStringClient{stream}
+	return x, nil
+}
+
+type Checker_CheckStringClient interface {
+	Send(*CheckStringRequest) error
+	Recv() (*CheckStringResponse, error)
+	grpc.ClientStream
+}
+
+type checkerCheckStringClient struct {
+	grpc.ClientStream
+}
+
+func (x *checkerCheckStringClient) Send(m *CheckStringRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *checkerCheckStringClient) Recv() (*CheckStringResponse, error) {
+	m := new(CheckStringResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CheckerServer is the server API for Checker service.
+// All implementations must embed UnimplementedCheckerServer
+// for forward compatibility
+type CheckerServer interface {
+	// CheckCatalog checks a whole catalog and returns all the findings.
+	CheckCatalog(context.Context, *CheckCatalogRequest) (*CheckCatalogResponse, error)
+	// CheckString checks single strings against the english reference loaded by the server.
+	// Every request is answered with one response, in order.
+	CheckString(Checker_CheckStringServer) error
+	mustEmbedUnimplementedCheckerServer()
+}
+
+// UnimplementedCheckerServer must be embedded to have forward compatible implementations.
+type UnimplementedCheckerServer struct {
+}
+
+func (UnimplementedCheckerServer) CheckCatalog(context.Context, *CheckCatalogRequest) (*CheckCatalogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckCatalog not implemented")
+}
+func (UnimplementedCheckerServer) CheckString(Checker_CheckStringServer) error {
+	return status.Errorf(codes.Unimplemented, "method CheckString not implemented")
+}
+func (UnimplementedCheckerServer) mustEmbedUnimplementedCheckerServer() {}
+
+// UnsafeCheckerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CheckerServer will
+// result in compilation errors.
+type UnsafeCheckerServer interface {
+	mustEmbedUnimplementedCheckerServer()
+}
+
+func RegisterCheckerServer(s grpc.ServiceRegistrar, srv CheckerServer) {
+	s.RegisterService(&Checker_ServiceDesc, srv)
+}
+
+func _Checker_CheckCatalog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckCatalogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckerServer).CheckCatalog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Checker_CheckCatalog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckerServer).CheckCatalog(ctx, req.(*CheckCatalogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Checker_CheckString_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CheckerServer).CheckString(&checkerCheckStringServer{stream})
+}
+
+type Checker_CheckStringServer interface {
+	Send(*CheckStringResponse) error
+	Recv() (*CheckStringRequest, error)
+	grpc.ServerStream
+}
+
+type checkerCheckStringServer struct {
+	grpc.ServerStream
+}
+
+func (x *checkerCheckStringServer) Send(m *CheckStringResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *checkerCheckStringServer) Recv() (*CheckStringRequest, error) {
+	m := new(CheckStringRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Checker_ServiceDesc is the grpc.ServiceDesc for Checker service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Checker_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "checktranslations.v1.Checker",
+	HandlerType: (*CheckerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CheckCatalog",
+			Handler:    _Checker_CheckCatalog_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CheckString",
+			Handler:       _Checker_CheckString_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "checker.proto",
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// cjkLanguages are the Chinese, Japanese and Korean base languages, which also cover regional codes like zh-TW.
+var cjkLanguages = []string{"ja", "ko", "zh"}
+
+// fullWidthLanguages are the CJK languages punctuating with full-width marks, unlike Korean.
+var fullWidthLanguages = []string{"ja", "zh"}
+
+// halfWidthPunctuationRx matches half-width punctuation following a Chinese or Japanese character.
+var halfWidthPunctuationRx = regexp.MustCompile(`[\p{Han}\p{Hiragana}\p{Katakana}][,.!?:;]`)
+
+// cjkSpaceRx matches spaces between Chinese or Japanese characters.
+var cjkSpaceRx = regexp.MustCompile(`[\p{Han}\p{Hiragana}\p{Katakana}][ \x{3000}]+[\p{Han}\p{Hiragana}\p{Katakana}]`)
+
+// leadingPunctuationRx matches the punctuation which must not start a line, at the start of a text.
+var leadingPunctuationRx = regexp.MustCompile(`^[、。，．・：；？！）」』】〕〉》ー…]`)
+
+// checkCJK reports the Chinese, Japanese and Korean translations with half-width punctuation
+// after full-width characters, with spaces between the characters, or starting with punctuation
+// which must not start a line.
+func checkCJK(translations map[string]Translation) (result []Finding) {
+	for lang, translation := range translations {
+		base := baseLanguage(lang)
+		if !slices.Contains(cjkLanguages, base) {
+			continue
+		}
+		fullWidth := slices.Contains(fullWidthLanguages, base)
+		for key, translated := range translation {
+			var problems []string
+			if fullWidth && halfWidthPunctuationRx.MatchString(translated) {
+				problems = append(problems, "half-width punctuation after a full-width character")
+			}
+			if fullWidth && cjkSpaceRx.MatchString(translated) {
+				problems = append(problems, "space between characters")
+			}
+			if leadingPunctuationRx.MatchString(translated) {
+				problems = append(problems, "starting with punctuation which must not start a line")
+			}
+			for _, problem := range problems {
+				result = append(result, Finding{
+					Lang:    lang,
+					Key:     key,
+					Check:   "cjk",
+					Message: fmt.Sprintf("%v: %v", problem, translated),
+				})
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckCJK(t *testing.T) {
+	translations := map[string]Translation{
+		"ja": {
+			"good":    "保存しました。",
+			"period":  "保存しました.",
+			"space":   "ファイル を保存",
+			"leading": "。保存",
+			"latin":   "$name$ さん、Hello, world.",
+		},
+		"zh": {
+			"comma": "你好,世界",
+		},
+		"zh-TW": {
+			"good":  "你好，世界",
+			"space": "你好 世界",
+		},
+		"ko": {
+			"good":    "저장되었습니다. 다시 시도하세요?",
+			"leading": "、저장",
+		},
+	}
+	got := map[string]bool{}
+	for _, finding := range checkCJK(translations) {
+		got[finding.Lang+":"+finding.Key] = true
+	}
+	want := map[string]bool{"ja:period": true, "ja:space": true, "ja:leading": true, "zh:comma": true, "zh-TW:space": true,
+		"ko:leading": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
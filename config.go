@@ -0,0 +1,245 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// defaultConfigFile is read from the working directory when no -config flag is given.
+const defaultConfigFile = ".check-translations.json"
+
+// Config holds the settings read from the configuration file.
+// All the fields are optional; an empty Config runs the built-in checks only.
+type Config struct {
+	// Plugins lists Go plugin files providing additional checks.
+	// Relative paths are resolved against the directory of the configuration file.
+	Plugins []string `json:"plugins"`
+	// Languages lists the languages which must have a translation file, besides english.
+	// If set, the missing ones and the ones not listed are reported.
+	Languages []string `json:"languages"`
+	// Files configures how the translation files are found.
+	Files FilesConfig `json:"files"`
+	// Usage configures how the application source is scanned for references to translation keys.
+	Usage UsageConfig `json:"usage"`
+	// HTML enables the optional HTML checks.
+	HTML HTMLConfig `json:"html"`
+	// Numbers maps languages to the separators their number literals must use.
+	Numbers map[string]NumberFormat `json:"numbers"`
+	// Dates maps languages to the order of the year, month and day in their date formats, like "DMY".
+	Dates map[string]string `json:"dates"`
+	// Arrays is how arrays of strings in the translation files are read: "join" or "elements".
+	// By default they are reported like any other value which isn't a string.
+	Arrays string `json:"arrays"`
+	// Schema is a JSON Schema file all the JSON translation files must be valid against.
+	// A relative path is resolved against the directory of the configuration file.
+	Schema string `json:"schema"`
+	// Profanity maps languages to the files listing the offensive terms their texts are screened for,
+	// one per line. The terms found are only reported as warnings.
+	// Relative paths are resolved against the directory of the configuration file.
+	Profanity map[string]string `json:"profanity"`
+	// DetectLanguage reports the translations written in another language than the one of their file,
+	// as detected from their letter trigrams.
+	DetectLanguage bool `json:"detectLanguage"`
+	// Similarity reports the translations at least this similar to the english text, from 0 to 1,
+	// like 0.9 for a change of one character in ten, without being equal to it. 0 doesn't check it.
+	Similarity float64 `json:"similarity"`
+	// Fallbacks maps regional languages to the language shown in place of their missing translations,
+	// like pt-BR to pt, so that a key is only missing if no language of the chain translates it.
+	Fallbacks map[string]string `json:"fallbacks"`
+	// Tiers names sets of languages released together, like the tier-1 ones.
+	// A release of a tier, checked with -release, requires their translation files and no findings
+	// of theirs, while the findings of the other languages are only warnings.
+	Tiers map[string][]string `json:"tiers"`
+	// Budgets maps languages, or tiers, to the number of findings they may have without failing the run,
+	// like {"tier-3": {"errors": 5, "warnings": 50}}.
+	Budgets map[string]Budget `json:"budgets"`
+	// Rules maps rules, by identifier like HTML003 or by name, to their severity: "off", "warning" or "error".
+	Rules ruleSeverities `json:"rules"`
+	// CustomRules are the project-specific rules, matching regular expressions in the texts.
+	CustomRules []RuleConfig `json:"customRules"`
+	// Owners is the ownership file assigning the keys to the teams responsible for them,
+	// which the report and the notifications are grouped by. See parseOwners.
+	// A relative path is resolved against the directory of the configuration file.
+	Owners string `json:"owners"`
+	// Baseline is the file holding the accepted findings, which are not reported.
+	// A relative path is resolved against the directory of the configuration file.
+	Baseline string `json:"baseline"`
+	// Projects lists the translation root directories checked together when no directory is given,
+	// like the ones of the applications of a monorepo.
+	Projects []ProjectConfig `json:"projects"`
+}
+
+// ProjectConfig is a translation root directory checked with its own settings,
+// which default to the ones of the configuration file listing it.
+type ProjectConfig struct {
+	// Name identifies the project in the report, the root directory by default.
+	Name string `json:"name"`
+	// Root is the translation root directory of the project.
+	// A relative path is resolved against the directory of the configuration file.
+	Root string `json:"root"`
+	Config
+}
+
+// FilesConfig configures how the translation root directory is walked for translation files.
+type FilesConfig struct {
+	// FollowSymlinks walks the linked directories too, and reads the linked translation files.
+	FollowSymlinks bool `json:"followSymlinks"`
+	// MaxDepth limits how deep the translation files are looked for, like find -maxdepth:
+	// 1 only reads the files of the root directory itself. 0 is unlimited.
+	MaxDepth int `json:"maxDepth"`
+	// Pattern matches the names of the translation files, see newFileMatcher.
+	Pattern string `json:"pattern"`
+	// NoIgnore reads the translation files ignored by the .gitignore and .check-translationsignore files too.
+	NoIgnore bool `json:"noIgnore"`
+	// IncludeLanguages restricts the translation files read to the ones of these languages, and english.
+	IncludeLanguages []string `json:"includeLanguages"`
+	// ExcludeLanguages are the languages whose translation files aren't read, like the ones being brought up.
+	ExcludeLanguages []string `json:"excludeLanguages"`
+	// Arrays is how the arrays of strings are read, the Arrays of the configuration, which it is set from
+	// as the configuration is loaded.
+	Arrays string `json:"-"`
+}
+
+// wantsLanguage reports whether the translation file of lang is read.
+// The english reference always is.
+func (files FilesConfig) wantsLanguage(lang string) bool {
+	if lang == "en" {
+		return true
+	}
+	return (len(files.IncludeLanguages) == 0 || slices.Contains(files.IncludeLanguages, lang)) && !slices.Contains(files.ExcludeLanguages, lang)
+}
+
+// UsageConfig configures scanning the application source for references to translation keys.
+type UsageConfig struct {
+	// Dirs lists the source directories to scan.
+	// Relative paths are resolved against the directory of the configuration file.
+	Dirs []string `json:"dirs"`
+	// Patterns are regular expressions matching key references, with the key as the first group.
+	// If empty, calls like t("key") are matched.
+	Patterns []string `json:"patterns"`
+}
+
+// loadConfig loads the configuration file at path.
+// If path is empty, the default configuration file is used if it exists.
+func loadConfig(path string) Config {
+	config := Config{}
+	if path == "" {
+		if _, err := os.Stat(defaultConfigFile); err != nil {
+			return config
+		}
+		path = defaultConfigFile
+	}
+
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("loadConfig: %v: %v", path, err)
+	}
+	err = json.Unmarshal(bs, &config)
+	if err != nil {
+		log.Fatalf("loadConfig: %v: %v", path, err)
+	}
+	dir := filepath.Dir(path)
+	if err := config.resolve(dir); err != nil {
+		log.Fatalf("loadConfig: %v: %v", path, err)
+	}
+	// The projects are read again over the settings of the file, which they default to.
+	var projects struct {
+		Projects []json.RawMessage `json:"projects"`
+	}
+	json.Unmarshal(bs, &projects)
+	for i, raw := range projects.Projects {
+		project := ProjectConfig{Config: config}
+		project.Projects = nil
+		// Decoding into the maps and slices of the file would change them.
+		project.Languages = slices.Clone(config.Languages)
+		project.Files.IncludeLanguages = slices.Clone(config.Files.IncludeLanguages)
+		project.Files.ExcludeLanguages = slices.Clone(config.Files.ExcludeLanguages)
+		project.Plugins = slices.Clone(config.Plugins)
+		project.Usage.Dirs = slices.Clone(config.Usage.Dirs)
+		project.Usage.Patterns = slices.Clone(config.Usage.Patterns)
+		project.Numbers = maps.Clone(config.Numbers)
+		project.Dates = maps.Clone(config.Dates)
+		project.Tiers = maps.Clone(config.Tiers)
+		project.Fallbacks = maps.Clone(config.Fallbacks)
+		project.Profanity = maps.Clone(config.Profanity)
+		project.Rules = config.Rules.clone()
+		project.Budgets = maps.Clone(config.Budgets)
+		// Decoding into the custom rules of the file would keep their fields the project's rules don't set.
+		project.CustomRules = nil
+		if err := json.Unmarshal(raw, &project); err != nil {
+			log.Fatalf("loadConfig: %v: %v", path, err)
+		}
+		if project.CustomRules == nil {
+			project.CustomRules = slices.Clone(config.CustomRules)
+		}
+		if project.Root == "" {
+			log.Fatalf("loadConfig: %v: project %v has no root", path, i+1)
+		}
+		if err := project.resolve(dir); err != nil {
+			log.Fatalf("loadConfig: %v: project %v: %v", path, project.Root, err)
+		}
+		if !filepath.IsAbs(project.Root) {
+			project.Root = filepath.Join(dir, project.Root)
+		}
+		if project.Name == "" {
+			project.Name = project.Root
+		}
+		config.Projects[i] = project
+	}
+
+	return config
+}
+
+// resolve validates the settings of config and makes its relative paths absolute, relative to dir.
+func (config *Config) resolve(dir string) error {
+	switch config.Arrays {
+	case arraysUnsupported, arraysJoin, arraysElements:
+	default:
+		return fmt.Errorf("arrays must be %q or %q, not %q", arraysJoin, arraysElements, config.Arrays)
+	}
+	config.Files.Arrays = config.Arrays
+	if config.Similarity < 0 || config.Similarity > 1 {
+		return fmt.Errorf("similarity must be between 0 and 1, not %v", config.Similarity)
+	}
+	if err := validateBudgets(config.Budgets); err != nil {
+		return err
+	}
+	for i := range config.CustomRules {
+		rule := &config.CustomRules[i]
+		if err := rule.compile(); err != nil {
+			return err
+		}
+	}
+	rules, err := resolveRules(config.Rules.severities, config.CustomRules)
+	if err != nil {
+		return err
+	}
+	config.Rules = rules
+	resolvePaths(dir, config.Plugins)
+	resolvePaths(dir, config.Usage.Dirs)
+	for lang, p := range config.Profanity {
+		if !filepath.IsAbs(p) {
+			config.Profanity[lang] = filepath.Join(dir, p)
+		}
+	}
+	for _, p := range []*string{&config.Baseline, &config.Schema, &config.Owners} {
+		if *p != "" && !filepath.IsAbs(*p) {
+			*p = filepath.Join(dir, *p)
+		}
+	}
+	return nil
+}
+
+// resolvePaths makes the relative paths absolute, relative to dir.
+func resolvePaths(dir string, paths []string) {
+	for i, p := range paths {
+		if !filepath.IsAbs(p) {
+			paths[i] = filepath.Join(dir, p)
+		}
+	}
+}
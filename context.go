@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// contextFile maps the translation keys to a description for the translators.
+// It's read from the translation root directory, next to en.json.
+const contextFile = "context.json"
+
+var htmlTagRx = regexp.MustCompile("</?[a-zA-Z][^>]*>")
+
+// loadContext loads the translator context of rootDir, or returns nil if there is none.
+func loadContext(rootDir string) Translation {
+	path := filepath.Join(rootDir, contextFile)
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	context, _, err := loadTranslation(path, arraysUnsupported)
+	if err != nil {
+		log.Fatalf("loadContext: %v", err)
+	}
+	return context
+}
+
+// needsContext reports whether an english string is risky to translate without context,
+// because it contains variables or HTML.
+func needsContext(s string) bool {
+	return variableRx.MatchString(s) || htmlTagRx.MatchString(s)
+}
+
+// checkContext returns a check reporting the risky english strings without translator context.
+func checkContext(context Translation) checkFunc {
+	return func(translations map[string]Translation) (result []Finding) {
+		for key, enString := range translations["en"] {
+			if needsContext(enString) && context[key] == "" {
+				result = append(result, Finding{
+					Lang:    "en",
+					Key:     key,
+					Check:   "context",
+					Message: fmt.Sprintf("missing translator context for a string with variables or HTML: %v", enString),
+				})
+			}
+		}
+		return result
+	}
+}
+
+// addContext sets the translator context of the keys of findings.
+func addContext(findings []Finding, context Translation) {
+	for i := range findings {
+		findings[i].Context = context[findings[i].Key]
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCheckContext(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {
+			"plain":     "Save",
+			"variable":  "$count$ items",
+			"html":      "<b>Bold</b>",
+			"described": "Hello $name$",
+		},
+	}
+	context := Translation{"described": "Greeting on the dashboard, $name$ is the first name"}
+	findings := checkContext(context)(translations)
+	keys := map[string]bool{}
+	for _, finding := range findings {
+		keys[finding.Key] = true
+	}
+	if len(findings) != 2 || !keys["variable"] || !keys["html"] {
+		t.Errorf("unexpected findings: %v", findings)
+	}
+}
+
+func TestReportContext(t *testing.T) {
+	findings := []Finding{
+		{Lang: "sv", Key: "greeting", Check: "variables", Message: "mismatch in variables: Hello $name$ ⇒ Hej $namn$"},
+	}
+	addContext(findings, Translation{"greeting": "Greeting on the dashboard"})
+
+	var buf bytes.Buffer
+	reportText(&buf, findings, ruleSeverities{})
+	want := "[sv]\n    mismatch in variables: Hello $name$ ⇒ Hej $namn$ (VAR001)\n        context: Greeting on the dashboard\n"
+	if buf.String() != want {
+		t.Errorf("want:\n%v\ngot:\n%v", want, buf.String())
+	}
+}
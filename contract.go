@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The formats of the key contracts generated from en.json.
+const (
+	contractSchema     = "json-schema"
+	contractTypeScript = "typescript"
+	contractGo         = "go"
+)
+
+// generatedHeader marks the generated files, following the Go convention which other tools understand too.
+const generatedHeader = "// Code generated by check-translations schema; DO NOT EDIT.\n"
+
+// sortedKeys returns the keys of a map, like the ones of a translation, sorted.
+func sortedKeys[M ~map[string]V, V any](m M) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// keyVariables returns the distinct variables of an english string, without their $ delimiters, sorted.
+func keyVariables(s string) []string {
+	var names []string
+	for _, variable := range variableRx.FindAllString(s, -1) {
+		names = append(names, strings.Trim(variable, "$"))
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// generateJSONSchema returns a JSON Schema the translation files must be valid against to have
+// exactly the keys of en, all with string values. It can be given back as the schema to check.
+func generateJSONSchema(en Translation) ([]byte, error) {
+	properties := make(map[string]any, len(en))
+	keys := sortedKeys(en)
+	for _, key := range keys {
+		properties[key] = map[string]any{"type": "string"}
+	}
+	schema := map[string]any{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"type":                 "object",
+		"properties":           properties,
+		"required":             keys,
+		"additionalProperties": false,
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(schema); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// generateTypeScript returns a TypeScript module with the union type of the keys of en,
+// and the variables each key must be given.
+func generateTypeScript(en Translation) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(generatedHeader)
+	buf.WriteString("\nexport type TranslationKey =\n")
+	keys := sortedKeys(en)
+	for i, key := range keys {
+		fmt.Fprintf(&buf, "    | %v", strconv.Quote(key))
+		if i == len(keys)-1 {
+			buf.WriteString(";")
+		}
+		buf.WriteString("\n")
+	}
+	if len(keys) == 0 {
+		buf.WriteString("    never;\n")
+	}
+
+	buf.WriteString("\nexport interface TranslationVariables {\n")
+	for _, key := range keys {
+		variables := keyVariables(en[key])
+		names := "never"
+		if len(variables) > 0 {
+			quoted := make([]string, len(variables))
+			for i, name := range variables {
+				quoted[i] = strconv.Quote(name)
+			}
+			names = strings.Join(quoted, " | ")
+		}
+		fmt.Fprintf(&buf, "    %v: %v;\n", strconv.Quote(key), names)
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+// goIdentifier returns the exported Go identifier of a key, like KeyMenuSave for menu.save.
+func goIdentifier(key string) string {
+	var b strings.Builder
+	b.WriteString("Key")
+	upper := true
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// generateGo returns a Go file of package pkg with a constant for every key of en.
+// It fails if two keys have the same identifier, like menu.save and menu_save.
+func generateGo(en Translation, pkg string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(generatedHeader)
+	fmt.Fprintf(&buf, "\npackage %v\n\n// The translation keys of en.json.\nconst (\n", pkg)
+	seen := make(map[string]string)
+	for _, key := range sortedKeys(en) {
+		name := goIdentifier(key)
+		if other, ok := seen[name]; ok {
+			return nil, fmt.Errorf("keys %q and %q are both %v", other, key, name)
+		}
+		seen[name] = key
+		fmt.Fprintf(&buf, "\t%v = %v\n", name, strconv.Quote(key))
+	}
+	buf.WriteString(")\n")
+	return format.Source(buf.Bytes())
+}
+
+// schemaCommand writes a contract of the keys of en.json, for the application code to be checked
+// against the same source as the translations.
+func schemaCommand(args []string) {
+	flags := flag.NewFlagSet("schema", flag.ExitOnError)
+	formatFlag := flags.String("format", contractSchema,
+		fmt.Sprintf("format of the contract: %v, %v or %v", contractSchema, contractTypeScript, contractGo))
+	pkg := flags.String("package", "translations", "package of the generated Go file")
+	out := flags.String("out", "", "file the contract is written to (default standard output)")
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v schema [flags] <translation-root-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	rootDir := flags.Arg(0)
+	if err := checkRootDir(rootDir); err != nil {
+		log.Fatal(err)
+	}
+
+	config := loadConfig(*configPath)
+	translations, findings := loadTranslations(rootDir, config.Files)
+	en, ok := translations["en"]
+	if !ok {
+		reportText(os.Stderr, findings, config.Rules)
+		log.Fatalf("schema: no english translation in %v", rootDir)
+	}
+
+	var contract []byte
+	var err error
+	switch *formatFlag {
+	case contractSchema:
+		contract, err = generateJSONSchema(en)
+	case contractTypeScript:
+		contract = generateTypeScript(en)
+	case contractGo:
+		contract, err = generateGo(en, *pkg)
+	default:
+		err = fmt.Errorf("unknown format %q", *formatFlag)
+	}
+	if err != nil {
+		log.Fatalf("schema: %v", err)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(contract)
+		return
+	}
+	if err := os.WriteFile(*out, contract, 0644); err != nil {
+		log.Fatalf("schema: %v", err)
+	}
+}
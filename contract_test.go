@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGenerateJSONSchema(t *testing.T) {
+	en := Translation{"menu.save": "Save", "greeting": "Hello $name$"}
+	bs, err := generateJSONSchema(en)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema jsonSchema
+	if err := json.Unmarshal(bs, &schema); err != nil {
+		t.Fatal(err)
+	}
+	if err := schema.compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	valid := map[string]any{"menu.save": "Spara", "greeting": "Hej $name$"}
+	if violations := schema.validate(valid, ""); len(violations) != 0 {
+		t.Errorf("unexpected violations: %v", violations)
+	}
+	invalid := map[string]any{"menu.save": "Spara", "menu.open": "Öppna"}
+	if violations := schema.validate(invalid, ""); len(violations) != 2 {
+		t.Errorf("want 2 violations, got %v", violations)
+	}
+}
+
+func TestGenerateTypeScript(t *testing.T) {
+	en := Translation{"menu.save": "Save", "greeting": "Hello $name$, you have $count$ $name$"}
+	want := `// Code generated by check-translations schema; DO NOT EDIT.
+
+export type TranslationKey =
+    | "greeting"
+    | "menu.save";
+
+export interface TranslationVariables {
+    "greeting": "count" | "name";
+    "menu.save": never;
+}
+`
+	if got := string(generateTypeScript(en)); got != want {
+		t.Errorf("want\n%v\ngot\n%v", want, got)
+	}
+}
+
+func TestGenerateGo(t *testing.T) {
+	en := Translation{"menu.save": "Save", "dialog.close-all": "Close all", "2fa.code": "Code"}
+	want := `// Code generated by check-translations schema; DO NOT EDIT.
+
+package translations
+
+// The translation keys of en.json.
+const (
+	Key2faCode        = "2fa.code"
+	KeyDialogCloseAll = "dialog.close-all"
+	KeyMenuSave       = "menu.save"
+)
+`
+	got, err := generateGo(en, "translations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("want\n%v\ngot\n%v", want, string(got))
+	}
+
+	if _, err := generateGo(Translation{"menu.save": "Save", "menu_save": "Save"}, "translations"); err == nil {
+		t.Error("want an error for keys with the same identifier")
+	}
+}
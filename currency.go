@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// currencySymbolRx matches a currency symbol, like $, € or kr.
+var currencySymbolRx = regexp.MustCompile(`\p{Sc}`)
+
+// placeholderRx matches the variables and the {placeholders} of other formats.
+var placeholderRx = regexp.MustCompile(`\$[^$]+\$|\{[^{}]+\}`)
+
+// amountNameRx matches the names of placeholders standing for an amount of money.
+var amountNameRx = regexp.MustCompile(`(?i)amount|price|cost|total|sum|fee|currency`)
+
+// digitsRx matches the digits of a number.
+var digitsRx = regexp.MustCompile(`\d+`)
+
+// hasNewNumber reports whether translated has a number which isn't in the english source.
+func hasNewNumber(enString, translated string) bool {
+	enNumbers := digitsRx.FindAllString(enString, -1)
+	for _, number := range digitsRx.FindAllString(translated, -1) {
+		if !slices.Contains(enNumbers, number) {
+			return true
+		}
+	}
+	return false
+}
+
+// currencySymbols returns the sorted currency symbols of s, leaving out the $ delimiting variables.
+func currencySymbols(s string) []string {
+	symbols := currencySymbolRx.FindAllString(variableRx.ReplaceAllString(s, ""), -1)
+	slices.Sort(symbols)
+	return symbols
+}
+
+// checkCurrency reports the translations with other currency symbols than the english source,
+// and the ones replacing an amount placeholder with a literal amount.
+func checkCurrency(translations map[string]Translation) (result []Finding) {
+	for enKey, enString := range translations["en"] {
+		enSymbols := currencySymbols(enString)
+		var placeholders []string
+		for _, placeholder := range placeholderRx.FindAllString(enString, -1) {
+			if amountNameRx.MatchString(placeholder) {
+				placeholders = append(placeholders, placeholder)
+			}
+		}
+		for lang, translation := range translations {
+			translated := translation[enKey]
+			if lang == "en" || translated == "" {
+				continue
+			}
+			finding := Finding{Lang: lang, Key: enKey, Check: "currency"}
+			for _, placeholder := range placeholders {
+				if !strings.Contains(translated, placeholder) && hasNewNumber(enString, translated) {
+					finding.Message = fmt.Sprintf("amount placeholder %v replaced with a literal amount: %v ⇒ %v",
+						placeholder, enString, translated)
+					break
+				}
+			}
+			if finding.Message == "" && slices.Compare(enSymbols, currencySymbols(translated)) != 0 {
+				finding.Message = fmt.Sprintf("mismatch in currency symbols: %v ⇒ %v", enString, translated)
+			}
+			if finding.Message != "" {
+				result = append(result, finding)
+			}
+		}
+	}
+	return result
+}
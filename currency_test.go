@@ -0,0 +1,36 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckCurrency(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {
+			"price": "Only $price$ per month",
+			"fee":   "A fee of {fee} applies",
+			"limit": "Orders over $50 ship free",
+			"count": "$count$ items",
+		},
+		"sv": {
+			"price": "Endast 99 kr per månad",
+			"fee":   "En avgift på {fee} tillkommer",
+			"limit": "Beställningar över $50 skickas gratis",
+			"count": "$count$ saker",
+		},
+		"de": {
+			"price": "Nur $price$ pro Monat",
+			"fee":   "Es fällt eine Gebühr von 5 € an",
+			"limit": "Bestellungen über 50 € werden kostenlos versandt",
+			"count": "$count$ Artikel",
+		},
+	}
+	keys := map[string]bool{}
+	for _, finding := range checkCurrency(translations) {
+		keys[finding.Lang+":"+finding.Key] = true
+	}
+	if want := map[string]bool{"sv:price": true, "de:fee": true, "de:limit": true}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("want %v, got %v", want, keys)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// RuleConfig is a project-specific rule, matching a regular expression in the texts.
+type RuleConfig struct {
+	// ID identifies the rule, like SCR001, in the reports and the rules configuration.
+	ID string `json:"id"`
+	// Name is the name of its check, the ID by default.
+	Name string `json:"name"`
+	// Pattern is the regular expression matched in the texts.
+	// By default the texts matching it are reported.
+	Pattern string `json:"pattern"`
+	// Keep reports the translations which don't have the matches of Pattern in the english text instead,
+	// like a product name which must never be translated.
+	Keep bool `json:"keep"`
+	// Languages are the languages whose texts are checked, all of them by default.
+	Languages []string `json:"languages"`
+	// Keys is a regular expression the checked keys must match, all of them by default.
+	Keys string `json:"keys"`
+	// Severity is "warning" or "error", the default. The rules configuration overrides it.
+	Severity string `json:"severity"`
+	// Message describes the finding, followed by the text.
+	Message string `json:"message"`
+
+	patternRx *regexp.Regexp
+	keysRx    *regexp.Regexp
+}
+
+// compile validates rule, compiles its regular expressions and defaults its name.
+func (rule *RuleConfig) compile() error {
+	if !ruleIDRx.MatchString(rule.ID) {
+		return fmt.Errorf("custom rule %q must have an identifier like ABC001", rule.ID)
+	}
+	if rule.Name == "" {
+		rule.Name = rule.ID
+	}
+	if check := ruleCheck(rule.ID); check != rule.ID && check != rule.Name {
+		return fmt.Errorf("custom rule %v is already the identifier of %v", rule.ID, check)
+	}
+	if id, ok := ruleIDs[rule.Name]; ok && id != rule.ID {
+		return fmt.Errorf("custom rule %v is named %v, like %v", rule.ID, rule.Name, id)
+	}
+	switch rule.Severity {
+	case "", severityWarning, severityError:
+	default:
+		return fmt.Errorf("custom rule %v must be %q or %q, not %q", rule.ID, severityWarning, severityError, rule.Severity)
+	}
+	if rule.Message == "" {
+		return fmt.Errorf("custom rule %v has no message", rule.ID)
+	}
+	if rule.Pattern == "" {
+		return fmt.Errorf("custom rule %v has no pattern", rule.ID)
+	}
+	var err error
+	if rule.patternRx, err = regexp.Compile(rule.Pattern); err != nil {
+		return fmt.Errorf("custom rule %v: %w", rule.ID, err)
+	}
+	if rule.Keys != "" {
+		if rule.keysRx, err = regexp.Compile(rule.Keys); err != nil {
+			return fmt.Errorf("custom rule %v: %w", rule.ID, err)
+		}
+	}
+	return nil
+}
+
+// checkCustomRule returns a check reporting the texts in the scope of rule which match its pattern,
+// or the translations missing the matches of the english text if the rule keeps them.
+func checkCustomRule(rule RuleConfig) checkFunc {
+	return func(translations map[string]Translation) (result []Finding) {
+		for lang, translation := range translations {
+			if len(rule.Languages) > 0 && !slices.Contains(rule.Languages, lang) {
+				continue
+			}
+			for key, translated := range translation {
+				if rule.keysRx != nil && !rule.keysRx.MatchString(key) {
+					continue
+				}
+				report := func(text string) {
+					result = append(result, Finding{Lang: lang, Key: key, Check: rule.Name, Message: fmt.Sprintf("%v: %v", rule.Message, text)})
+				}
+				if !rule.Keep {
+					if rule.patternRx.MatchString(translated) {
+						report(translated)
+					}
+					continue
+				}
+				enString, ok := translations["en"][key]
+				if lang == "en" || !ok {
+					continue
+				}
+				for _, match := range rule.patternRx.FindAllString(enString, -1) {
+					if !strings.Contains(translated, match) {
+						report(enString + " ⇒ " + translated)
+						break
+					}
+				}
+			}
+		}
+		return result
+	}
+}
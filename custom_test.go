@@ -0,0 +1,45 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCheckCustomRule(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {"sign": "Sign with Scrive", "click": "Click here", "email": "Signed with Scrive"},
+		"sv": {"sign": "Signera med Skriv", "click": "Klicka här", "email": "Signerat med Scrive"},
+		"de": {"sign": "Mit Scrive unterschreiben", "click": "Hier klicken"},
+	}
+	keep := RuleConfig{ID: "SCR001", Name: "product-name", Pattern: `\bScrive\b`, Keep: true, Message: "Scrive must never be translated"}
+	forbid := RuleConfig{ID: "SCR002", Pattern: `(?i)click here|hier klicken`, Keys: `^click$`, Languages: []string{"en", "de"}, Message: "vague link text"}
+	for _, rule := range []*RuleConfig{&keep, &forbid} {
+		if err := rule.compile(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []Finding{
+		{Lang: "de", Key: "click", Check: "SCR002", Message: "vague link text: Hier klicken"},
+		{Lang: "en", Key: "click", Check: "SCR002", Message: "vague link text: Click here"},
+		{Lang: "sv", Key: "sign", Check: "product-name", Message: "Scrive must never be translated: Sign with Scrive ⇒ Signera med Skriv"},
+	}
+	findings := append(checkCustomRule(keep)(translations), checkCustomRule(forbid)(translations)...)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+
+	for _, rule := range []RuleConfig{
+		{ID: "scrive", Pattern: "x", Message: "m"},
+		{ID: "VAR001", Pattern: "x", Message: "m"},
+		{ID: "SCR003", Name: "variables", Pattern: "x", Message: "m"},
+		{ID: "SCR003", Pattern: "(", Message: "m"},
+		{ID: "SCR003", Pattern: "x"},
+		{ID: "SCR003", Pattern: "x", Message: "m", Severity: "off"},
+	} {
+		if err := rule.compile(); err == nil {
+			t.Errorf("%v: want an error", rule)
+		}
+	}
+}
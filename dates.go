@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// dateTokenPattern matches a single Moment or Intl style date token.
+// The single letter ones are left out, as they are too easy to confuse with words.
+const dateTokenPattern = `(?:YYYY|YY|MMMM|MMM|MM|Do|DD|dddd|ddd|HH|hh|mm|ss)`
+
+// dateFormatRx matches a Moment or Intl style date format like YYYY-MM-DD or DD.MM.YYYY HH:mm,
+// which takes at least two tokens so that it doesn't match words.
+var dateFormatRx = regexp.MustCompile(`\b` + dateTokenPattern + `(?:[-./:, ]+` + dateTokenPattern + `)+\b`)
+
+var dateTokenRx = regexp.MustCompile(dateTokenPattern)
+
+// strftimeRx matches a strftime directive like %d or %Y.
+var strftimeRx = regexp.MustCompile(`%[aAbBcdeHIjmMpSuUwWxXyYzZ]`)
+
+// dateTokens returns the date format tokens of s, in order.
+func dateTokens(s string) (tokens []string) {
+	for _, format := range dateFormatRx.FindAllString(s, -1) {
+		tokens = append(tokens, dateTokenRx.FindAllString(format, -1)...)
+	}
+	return append(tokens, strftimeRx.FindAllString(s, -1)...)
+}
+
+// dateOrder returns the order of the year, month and day tokens of a date format, e.g. "DMY".
+func dateOrder(tokens []string) string {
+	var order strings.Builder
+	for _, token := range tokens {
+		var part byte
+		switch token {
+		case "YYYY", "YY", "%Y", "%y":
+			part = 'Y'
+		case "MMMM", "MMM", "MM", "%m", "%b", "%B":
+			part = 'M'
+		case "Do", "DD", "%d", "%e":
+			part = 'D'
+		default:
+			continue
+		}
+		if !strings.ContainsRune(order.String(), rune(part)) {
+			order.WriteByte(part)
+		}
+	}
+	return order.String()
+}
+
+// checkDateFormats returns a check reporting the translations which don't keep the date format
+// tokens of the english source, e.g. YYYY translated to AAAA. The languages of orders must also
+// put the year, month and day in the given order, like "DMY".
+func checkDateFormats(orders map[string]string) checkFunc {
+	return func(translations map[string]Translation) (result []Finding) {
+		for enKey, enString := range translations["en"] {
+			enTokens := dateTokens(enString)
+			if len(enTokens) == 0 {
+				continue
+			}
+			slices.Sort(enTokens)
+			for lang, translation := range translations {
+				if lang == "en" || translation[enKey] == "" {
+					continue
+				}
+				tokens := dateTokens(translation[enKey])
+				order := dateOrder(tokens)
+				slices.Sort(tokens)
+				switch {
+				case slices.Compare(enTokens, tokens) != 0:
+					result = append(result, Finding{
+						Lang:  lang,
+						Key:   enKey,
+						Check: "dates",
+						Message: fmt.Sprintf("mismatch in date format tokens: %v ⇒ %v",
+							enString, translation[enKey]),
+					})
+				case orders[lang] != "" && len(order) == 3 && order != orders[lang]:
+					result = append(result, Finding{
+						Lang:  lang,
+						Key:   enKey,
+						Check: "dates",
+						Message: fmt.Sprintf("date format not in the %v order: %v",
+							orders[lang], translation[enKey]),
+					})
+				}
+			}
+		}
+		return result
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDateTokens(t *testing.T) {
+	tests := []struct {
+		s      string
+		tokens []string
+	}{
+		{"Use the YYYY-MM-DD format", []string{"YYYY", "MM", "DD"}},
+		{"Sent DD.MM.YYYY HH:mm", []string{"DD", "MM", "YYYY", "HH", "mm"}},
+		{"Dated %d/%m/%Y", []string{"%d", "%m", "%Y"}},
+		{"Press A a second time", nil},
+		{"Use the AAAA-MM-JJ format", nil},
+	}
+	for _, test := range tests {
+		if tokens := dateTokens(test.s); !reflect.DeepEqual(tokens, test.tokens) {
+			t.Errorf("%q: want %v, got %v", test.s, test.tokens, tokens)
+		}
+	}
+}
+
+func TestCheckDateFormats(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {"a": "Format: MM/DD/YYYY", "b": "Generated on %m/%d/%Y"},
+		"fr": {"a": "Format : JJ/MM/AAAA", "b": "Généré le %d/%m/%Y"},
+		"de": {"a": "Format: MM.DD.YYYY", "b": "Erstellt am %d.%m.%Y"},
+		"sv": {"a": "Format: YYYY-MM-DD", "b": "Skapad %Y-%m-%d"},
+	}
+	orders := map[string]string{"de": "DMY", "sv": "YMD"}
+	keys := map[string]bool{}
+	for _, finding := range checkDateFormats(orders)(translations) {
+		keys[finding.Lang+":"+finding.Key] = true
+	}
+	if want := map[string]bool{"fr:a": true, "de:a": true}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("want %v, got %v", want, keys)
+	}
+}
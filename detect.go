@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// languageSamples are the texts the trigram profiles of the detected languages are learned from,
+// the same user interface strings in every language, so that they differ by the language only.
+var languageSamples = map[string]string{
+	"da": "Dit dokument er blevet underskrevet af alle parter. Indtast din e-mailadresse og dit telefonnummer for at fortsætte. Filen kunne ikke uploades, prøv igen senere. Vil du virkelig slette dette dokument? Send dokumentet til de andre parter til underskrift. Din adgangskode skal være mindst otte tegn lang. Tak fordi du bruger vores tjeneste, vi har sendt dig en besked med et link til det underskrevne dokument. Invitationen er udløbet, og afsenderen har fået besked. Klik her for at læse og underskrive aftalen. Vælg hvordan du vil identificere dig, før du underskriver.",
+	"de": "Ihr Dokument wurde von allen Parteien unterzeichnet. Bitte geben Sie Ihre E-Mail-Adresse und Ihre Telefonnummer ein, um fortzufahren. Die Datei konnte nicht hochgeladen werden, bitte versuchen Sie es später noch einmal. Möchten Sie dieses Dokument wirklich löschen? Senden Sie das Dokument zur Unterzeichnung an die anderen Parteien. Ihr Passwort muss mindestens acht Zeichen lang sein. Vielen Dank, dass Sie unseren Dienst nutzen, wir haben Ihnen eine Nachricht mit einem Link zum unterzeichneten Dokument geschickt. Die Einladung ist abgelaufen und der Absender wurde benachrichtigt. Klicken Sie hier, um den Vertrag zu lesen und zu unterschreiben. Wählen Sie aus, wie Sie sich vor der Unterzeichnung identifizieren möchten.",
+	"en": "Your document has been signed by all parties. Please enter your email address and your phone number to continue. The file could not be uploaded, please try again later. Do you really want to delete this document? Send the document to the other parties for signing. Your password must be at least eight characters long. Thank you for using our service, we have sent you a message with a link to the signed document. The invitation has expired and the sender has been notified. Click here to read and sign the contract. Choose how you want to identify yourself before signing.",
+	"es": "Su documento ha sido firmado por todas las partes. Introduzca su dirección de correo electrónico y su número de teléfono para continuar. No se ha podido subir el archivo, inténtelo de nuevo más tarde. ¿Seguro que quiere eliminar este documento? Envíe el documento a las otras partes para que lo firmen. Su contraseña debe tener al menos ocho caracteres. Gracias por utilizar nuestro servicio, le hemos enviado un mensaje con un enlace al documento firmado. La invitación ha caducado y se ha avisado al remitente. Haga clic aquí para leer y firmar el contrato. Elija cómo quiere identificarse antes de firmar.",
+	"fi": "Kaikki osapuolet ovat allekirjoittaneet asiakirjasi. Anna sähköpostiosoitteesi ja puhelinnumerosi jatkaaksesi. Tiedostoa ei voitu ladata, yritä myöhemmin uudelleen. Haluatko varmasti poistaa tämän asiakirjan? Lähetä asiakirja muille osapuolille allekirjoitettavaksi. Salasanassasi on oltava vähintään kahdeksan merkkiä. Kiitos, että käytät palveluamme, lähetimme sinulle viestin, jossa on linkki allekirjoitettuun asiakirjaan. Kutsu on vanhentunut, ja lähettäjälle on ilmoitettu. Napsauta tästä lukeaksesi ja allekirjoittaaksesi sopimuksen. Valitse, miten haluat tunnistautua ennen allekirjoittamista.",
+	"fr": "Votre document a été signé par toutes les parties. Veuillez saisir votre adresse e-mail et votre numéro de téléphone pour continuer. Le fichier n'a pas pu être téléchargé, veuillez réessayer plus tard. Voulez-vous vraiment supprimer ce document ? Envoyez le document aux autres parties pour signature. Votre mot de passe doit contenir au moins huit caractères. Merci d'utiliser notre service, nous vous avons envoyé un message avec un lien vers le document signé. L'invitation a expiré et l'expéditeur a été prévenu. Cliquez ici pour lire et signer le contrat. Choisissez comment vous souhaitez vous identifier avant de signer.",
+	"it": "Il tuo documento è stato firmato da tutte le parti. Inserisci il tuo indirizzo email e il tuo numero di telefono per continuare. Non è stato possibile caricare il file, riprova più tardi. Vuoi davvero eliminare questo documento? Invia il documento alle altre parti per la firma. La password deve essere lunga almeno otto caratteri. Grazie per aver utilizzato il nostro servizio, ti abbiamo inviato un messaggio con un link al documento firmato. L'invito è scaduto e il mittente è stato avvisato. Fai clic qui per leggere e firmare il contratto. Scegli come vuoi identificarti prima di firmare.",
+	"nb": "Dokumentet ditt er signert av alle parter. Skriv inn e-postadressen og telefonnummeret ditt for å fortsette. Filen kunne ikke lastes opp, prøv igjen senere. Vil du virkelig slette dette dokumentet? Send dokumentet til de andre partene for signering. Passordet ditt må være minst åtte tegn langt. Takk for at du bruker tjenesten vår, vi har sendt deg en melding med en lenke til det signerte dokumentet. Invitasjonen har utløpt, og avsenderen har fått beskjed. Klikk her for å lese og signere avtalen. Velg hvordan du vil identifisere deg før du signerer.",
+	"nl": "Uw document is door alle partijen ondertekend. Voer uw e-mailadres en uw telefoonnummer in om verder te gaan. Het bestand kon niet worden geüpload, probeer het later opnieuw. Weet u zeker dat u dit document wilt verwijderen? Stuur het document ter ondertekening naar de andere partijen. Uw wachtwoord moet minstens acht tekens lang zijn. Bedankt voor het gebruik van onze dienst, we hebben u een bericht gestuurd met een link naar het ondertekende document. De uitnodiging is verlopen en de afzender is op de hoogte gebracht. Klik hier om het contract te lezen en te ondertekenen. Kies hoe u zich wilt identificeren voordat u ondertekent.",
+	"pl": "Twój dokument został podpisany przez wszystkie strony. Wpisz swój adres e-mail i numer telefonu, aby kontynuować. Nie udało się przesłać pliku, spróbuj ponownie później. Czy na pewno chcesz usunąć ten dokument? Wyślij dokument do pozostałych stron do podpisu. Hasło musi mieć co najmniej osiem znaków. Dziękujemy za korzystanie z naszej usługi, wysłaliśmy Ci wiadomość z linkiem do podpisanego dokumentu. Zaproszenie wygasło, a nadawca został powiadomiony. Kliknij tutaj, aby przeczytać i podpisać umowę. Wybierz, jak chcesz się zidentyfikować przed podpisaniem.",
+	"pt": "O seu documento foi assinado por todas as partes. Introduza o seu endereço de e-mail e o seu número de telefone para continuar. Não foi possível carregar o ficheiro, tente novamente mais tarde. Tem a certeza de que pretende eliminar este documento? Envie o documento às outras partes para assinatura. A sua palavra-passe deve ter pelo menos oito caracteres. Obrigado por utilizar o nosso serviço, enviámos-lhe uma mensagem com uma ligação para o documento assinado. O convite expirou e o remetente foi notificado. Clique aqui para ler e assinar o contrato. Escolha como pretende identificar-se antes de assinar.",
+	"sv": "Ditt dokument har undertecknats av alla parter. Ange din e-postadress och ditt telefonnummer för att fortsätta. Filen kunde inte laddas upp, försök igen senare. Vill du verkligen ta bort det här dokumentet? Skicka dokumentet till de andra parterna för undertecknande. Ditt lösenord måste vara minst åtta tecken långt. Tack för att du använder vår tjänst, vi har skickat ett meddelande till dig med en länk till det undertecknade dokumentet. Inbjudan har gått ut och avsändaren har meddelats. Klicka här för att läsa och underteckna avtalet. Välj hur du vill identifiera dig innan du skriver under.",
+}
+
+// detectMinLetters is how many letters a text must have for its language to be detected reliably.
+const detectMinLetters = 20
+
+// detectMargin is how much more likely, in average log-probability per trigram, the detected language
+// must be than the language of the file for the text to be reported.
+const detectMargin = 0.5
+
+// markupRx matches the HTML tags, entities and placeholders left out of the detection.
+var markupRx = regexp.MustCompile(`<[^>]*>|&[#\w]+;|\{\{[^}]*\}\}|\{[^}]*\}`)
+
+// trigramProfile is the log-probability of the letter trigrams of a language.
+type trigramProfile struct {
+	logProbs map[string]float64
+	// unseen is the log-probability of the trigrams which aren't in the sample.
+	unseen float64
+}
+
+// detectionWords returns the lowercased words of s, leaving out its variables and markup.
+func detectionWords(s string) []string {
+	s = markupRx.ReplaceAllString(variableRx.ReplaceAllString(s, " "), " ")
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return !unicode.IsLetter(r) })
+}
+
+// trigrams returns the letter trigrams of words, padded with spaces.
+func trigrams(words []string) (result []string) {
+	for _, word := range words {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			result = append(result, string(runes[i:i+3]))
+		}
+	}
+	return result
+}
+
+// newTrigramProfiles learns the trigram profiles of the samples, with add-one smoothing
+// over the trigrams of all of them.
+func newTrigramProfiles(samples map[string]string) map[string]trigramProfile {
+	counts := make(map[string]map[string]int)
+	vocabulary := make(map[string]bool)
+	for lang, sample := range samples {
+		counts[lang] = make(map[string]int)
+		for _, trigram := range trigrams(detectionWords(sample)) {
+			counts[lang][trigram]++
+			vocabulary[trigram] = true
+		}
+	}
+	profiles := make(map[string]trigramProfile)
+	for lang, langCounts := range counts {
+		total := 0
+		for _, count := range langCounts {
+			total += count
+		}
+		denominator := float64(total + len(vocabulary) + 1)
+		profile := trigramProfile{logProbs: make(map[string]float64), unseen: math.Log(1 / denominator)}
+		for trigram, count := range langCounts {
+			profile.logProbs[trigram] = math.Log(float64(count+1) / denominator)
+		}
+		profiles[lang] = profile
+	}
+	return profiles
+}
+
+// languageProfiles are the trigram profiles of the languages which can be detected.
+var languageProfiles = newTrigramProfiles(languageSamples)
+
+// languageScores returns the average log-probability per trigram of s in every language of profiles.
+// It returns nil if s is too short for its language to be detected.
+func languageScores(s string, profiles map[string]trigramProfile) map[string]float64 {
+	words := detectionWords(s)
+	if utf8.RuneCountInString(strings.Join(words, "")) < detectMinLetters {
+		return nil
+	}
+	grams := trigrams(words)
+	scores := make(map[string]float64, len(profiles))
+	for lang, profile := range profiles {
+		sum := 0.0
+		for _, trigram := range grams {
+			logProb, ok := profile.logProbs[trigram]
+			if !ok {
+				logProb = profile.unseen
+			}
+			sum += logProb
+		}
+		scores[lang] = sum / float64(len(grams))
+	}
+	return scores
+}
+
+// detectLanguage returns the language of s, if it's clearly another one than lang, the language
+// it's expected to be in. ok is false if it can't tell, or lang can't be detected.
+func detectLanguage(s, lang string) (detected string, ok bool) {
+	expected := baseLanguage(lang)
+	scores := languageScores(s, languageProfiles)
+	if _, known := scores[expected]; !known {
+		return "", false
+	}
+	for candidate, score := range scores {
+		if score-scores[expected] >= detectMargin && (detected == "" || score > scores[detected]) {
+			detected = candidate
+		}
+	}
+	return detected, detected != ""
+}
+
+// checkDetectedLanguage reports the translations which are clearly written in another language than
+// the one of their file, like french in de.json, among the languages with a trigram profile.
+func checkDetectedLanguage(translations map[string]Translation) (result []Finding) {
+	for lang, translation := range translations {
+		if lang == "en" {
+			continue
+		}
+		for key, translated := range translation {
+			if translated == translations["en"][key] {
+				continue
+			}
+			if detected, ok := detectLanguage(translated, lang); ok {
+				result = append(result, Finding{
+					Lang:    lang,
+					Key:     key,
+					Check:   "language",
+					Message: fmt.Sprintf("looks like %v, not %v: %v", detected, lang, translated),
+				})
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	for _, test := range []struct {
+		s, lang, want string
+	}{
+		{"Le document a été envoyé à tous les signataires", "de", "fr"},
+		{"Das Dokument wurde an alle Unterzeichner gesendet", "de", ""},
+		{"Das Dokument wurde an alle Unterzeichner gesendet", "de-AT", ""},
+		{"Het document is naar alle ondertekenaars verstuurd", "de", "nl"},
+		{"Dokumentet har skickats till alla undertecknare", "sv", ""},
+		{"The document was sent to all the signatories", "fi", "en"},
+		{"Asiakirja on lähetetty kaikille allekirjoittajille", "fi", ""},
+		{"El documento se ha enviado a todos los firmantes", "it", "es"},
+		{"Il documento è stato inviato a tutti i firmatari", "it", ""},
+		{"Dokument został wysłany do wszystkich sygnatariuszy", "pl", ""},
+		// Too short to tell, or no profile of the language.
+		{"Skicka", "de", ""},
+		{"Le document a été envoyé à tous les signataires", "ja", ""},
+	} {
+		if detected, _ := detectLanguage(test.s, test.lang); detected != test.want {
+			t.Errorf("%v %q: want %q, got %q", test.lang, test.s, test.want, detected)
+		}
+	}
+}
+
+func TestCheckDetectedLanguage(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {"sent": "The document was sent to all the signatories", "brand": "Scrive eSign"},
+		"de": {"sent": "Le document a été envoyé à <b>tous</b> les $count$ signataires", "brand": "Scrive eSign"},
+		"fr": {"sent": "Le document a été envoyé à tous les signataires"},
+	}
+	want := []Finding{
+		{Lang: "de", Key: "sent", Check: "language", Message: "looks like fr, not de: Le document a été envoyé à <b>tous</b> les $count$ signataires"},
+	}
+	findings := checkDetectedLanguage(translations)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"slices"
+)
+
+// translationDiff lists the keys which differ between two versions of a translation.
+type translationDiff struct {
+	added, removed, changed []string
+}
+
+// diffTranslation compares two versions of a translation, either of which can be nil.
+// The keys are sorted.
+func diffTranslation(old, new Translation) (d translationDiff) {
+	for key, value := range new {
+		oldValue, ok := old[key]
+		switch {
+		case !ok:
+			d.added = append(d.added, key)
+		case oldValue != value:
+			d.changed = append(d.changed, key)
+		}
+	}
+	for key := range old {
+		if _, ok := new[key]; !ok {
+			d.removed = append(d.removed, key)
+		}
+	}
+	slices.Sort(d.added)
+	slices.Sort(d.removed)
+	slices.Sort(d.changed)
+	return d
+}
+
+// newFindings returns the findings of current which aren't among the previous ones.
+func newFindings(previous, current []Finding) (result []Finding) {
+	seen := make(map[Finding]bool)
+	for _, finding := range previous {
+		seen[finding] = true
+	}
+	for _, finding := range current {
+		if !seen[finding] {
+			result = append(result, finding)
+		}
+	}
+	return result
+}
+
+// reportDiff writes the differences between two snapshots of the translations to w,
+// one section per language, followed by the findings introduced by the new snapshot.
+func reportDiff(w io.Writer, old, new map[string]Translation, introduced []Finding) {
+	var langs []string
+	for lang := range old {
+		langs = append(langs, lang)
+	}
+	for lang := range new {
+		if _, ok := old[lang]; !ok {
+			langs = append(langs, lang)
+		}
+	}
+	slices.Sort(langs)
+
+	byLang := findingsByLang(introduced)
+	for _, lang := range langs {
+		d := diffTranslation(old[lang], new[lang])
+		if len(d.added)+len(d.removed)+len(d.changed)+len(byLang[lang]) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "[%v]\n", lang)
+		switch {
+		case old[lang] == nil:
+			fmt.Fprintf(w, "    new language\n")
+		case new[lang] == nil:
+			fmt.Fprintf(w, "    removed language\n")
+		}
+		for _, key := range d.added {
+			fmt.Fprintf(w, "    + %v\n", key)
+		}
+		for _, key := range d.removed {
+			fmt.Fprintf(w, "    - %v\n", key)
+		}
+		for _, key := range d.changed {
+			fmt.Fprintf(w, "    ~ %v\n", key)
+		}
+		for _, finding := range byLang[lang] {
+			fmt.Fprintf(w, "    new problem: %v\n", finding.Message)
+		}
+	}
+}
+
+// diff compares two snapshots of the translations and reports the changes and the new problems.
+func diff(args []string) {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	exitCode := flags.Bool("exit-code", false, "exit with an error if the translations differ at all")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v diff [flags] <old-dir> <new-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 2 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	if err := checkRoot(flags.Arg(0)); err != nil {
+		log.Fatal(err)
+	}
+	if err := checkRoot(flags.Arg(1)); err != nil {
+		log.Fatal(err)
+	}
+
+	config := loadConfig(*configPath)
+	checks := loadChecks(config)
+	old, previous := loadRoot(flags.Arg(0), config.Files)
+	new, current := loadRoot(flags.Arg(1), config.Files)
+	introduced := newFindings(append(previous, runChecks(checks, old)...), append(current, runChecks(checks, new)...))
+	reportDiff(os.Stdout, old, new, introduced)
+
+	if len(introduced) > 0 || *exitCode && !maps.EqualFunc(old, new, maps.Equal) {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	old := map[string]Translation{
+		"en": {"a": "$x$ items", "b": "Bold", "c": "Gone"},
+		"sv": {"a": "$x$ saker", "b": "Fet", "c": "Borta"},
+		"fi": {"a": "$x$ asiaa"},
+	}
+	new := map[string]Translation{
+		"en": {"a": "$x$ items", "b": "<b>Bold</b>", "d": "New"},
+		"sv": {"a": "$y$ saker", "b": "<b>Fet</b>", "d": "Ny"},
+		"de": {"a": "$x$ Dinge"},
+	}
+	introduced := newFindings(runChecks(builtinChecks, old), runChecks(builtinChecks, new))
+	if len(introduced) != 1 || introduced[0].Lang != "sv" || introduced[0].Check != "variables" {
+		t.Errorf("unexpected new findings: %v", introduced)
+	}
+
+	var buf bytes.Buffer
+	reportDiff(&buf, old, new, introduced)
+	want := `[de]
+    new language
+    + a
+[en]
+    + d
+    - c
+    ~ b
+[fi]
+    removed language
+    - a
+[sv]
+    + d
+    - c
+    ~ a
+    ~ b
+    new problem: mismatch in variables: $x$ items ⇒ $y$ saker
+`
+	if buf.String() != want {
+		t.Errorf("want:\n%v\ngot:\n%v", want, buf.String())
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// dummyTextRx matches the fragments of lorem ipsum and the dummy strings typed to fill a layout.
+var dummyTextRx = regexp.MustCompile(`(?i)\b(?:lorem|ipsum|dolor sit amet|consectetur adipiscing|asdf\w*|qwerty|test test|blah blah|foo bar)\b`)
+
+// checkDummyText reports the texts with lorem ipsum or dummy strings, like asdf, in any language.
+// The ones of the english text are only reported in english, not in the translations copying them.
+func checkDummyText(translations map[string]Translation) (result []Finding) {
+	for lang, translation := range translations {
+		for key, translated := range translation {
+			// A text is reported once, for its first dummy fragment.
+			dummy := dummyTextRx.FindString(translated)
+			if dummy == "" || lang != "en" && strings.Contains(strings.ToLower(translations["en"][key]), strings.ToLower(dummy)) {
+				continue
+			}
+			result = append(result, Finding{
+				Lang:    lang,
+				Key:     key,
+				Check:   "dummy-text",
+				Message: fmt.Sprintf("dummy text %q: %v", dummy, translated),
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCheckDummyText(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {"intro": "Lorem ipsum dolor sit amet", "save": "Save", "test": "Test your signature"},
+		"sv": {"intro": "Lorem ipsum dolor sit amet", "save": "asdfgh", "test": "Testa din signatur"},
+		"de": {"intro": "Einführung", "save": "Test test"},
+	}
+	want := []Finding{
+		{Lang: "de", Key: "save", Check: "dummy-text", Message: `dummy text "Test test": Test test`},
+		{Lang: "en", Key: "intro", Check: "dummy-text", Message: `dummy text "Lorem": Lorem ipsum dolor sit amet`},
+		{Lang: "sv", Key: "save", Check: "dummy-text", Message: `dummy text "asdfgh": asdfgh`},
+	}
+	findings := checkDummyText(translations)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExplainRule(t *testing.T) {
+	// Every built-in check must be explained.
+	for _, check := range builtinChecks {
+		if _, ok := ruleDocs[check.name]; !ok {
+			t.Errorf("%v is not explained", check.name)
+		}
+	}
+
+	var buf bytes.Buffer
+	if !explainRule(&buf, "variables") {
+		t.Fatal("variables is not explained")
+	}
+	for _, want := range []string{"VAR001 variables: ", "failing:\n    en: Hello $name$\n    translation: Hej $namn$\n", "suppressing:\n"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("want %q in:\n%v", want, buf.String())
+		}
+	}
+	if explainRule(&buf, "unknown") {
+		t.Error("unknown is explained")
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// exportHeader is the first row of the exported CSV files.
+var exportHeader = []string{"key", "en", "translation", "problem"}
+
+// missingTranslation is the problem exported for the english keys without a translation.
+const missingTranslation = "missing translation"
+
+// exportRows returns the rows to hand over to translators for every language with problems:
+// one row per key with findings or without a translation, sorted by key. The keys translated
+// in a language of the fallbacks chain of a language aren't missing from it.
+// Findings which aren't tied to a key are exported with an empty key.
+func exportRows(translations map[string]Translation, findings []Finding, fallbacks map[string]string) map[string][][]string {
+	problems := make(map[string]map[string][]string)
+	add := func(lang, key, problem string) {
+		if problems[lang] == nil {
+			problems[lang] = make(map[string][]string)
+		}
+		problems[lang][key] = append(problems[lang][key], problem)
+	}
+	for lang := range translations {
+		if lang == "en" {
+			continue
+		}
+		for key := range translations["en"] {
+			if !isTranslated(translations, fallbacks, lang, key) {
+				add(lang, key, missingTranslation)
+			}
+		}
+	}
+	for _, finding := range findings {
+		add(finding.Lang, finding.Key, finding.Message)
+	}
+
+	rows := make(map[string][][]string)
+	for lang, byKey := range problems {
+		var keys []string
+		for key := range byKey {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			rows[lang] = append(rows[lang], []string{
+				key,
+				translations["en"][key],
+				translations[lang][key],
+				strings.Join(byKey[key], "\n"),
+			})
+		}
+	}
+	return rows
+}
+
+// writeCSV writes the header and rows to a CSV file at path.
+func writeCSV(path string, header []string, rows [][]string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(file)
+	w.Write(header)
+	w.WriteAll(rows)
+	if err := w.Error(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// export writes a CSV file per language listing the problems and the missing translations.
+func export(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	outDir := flags.String("out", ".", "directory the <lang>.csv files are written to")
+	baselineFlag := flags.String("baseline", "",
+		fmt.Sprintf("file of accepted findings, which are not exported (default %v, if it exists)", defaultBaselineFile))
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v export [flags] <translation-root-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	rootDir := flags.Arg(0)
+	if err := checkRootDir(rootDir); err != nil {
+		log.Fatal(err)
+	}
+
+	config := loadConfig(*configPath)
+	translations, findings := loadTranslations(rootDir, config.Files)
+	findings = append(findings, runChecks(loadChecks(config), translations)...)
+	findings = newFindings(loadBaseline(baselinePath(*baselineFlag, config)), findings)
+
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		log.Fatalf("export: %v", err)
+	}
+	for lang, rows := range exportRows(translations, findings, config.Fallbacks) {
+		path := filepath.Join(*outDir, lang+".csv")
+		if err := writeCSV(path, exportHeader, rows); err != nil {
+			log.Fatalf("export: %v: %v", path, err)
+		}
+		fmt.Printf("%v: %v strings\n", path, len(rows))
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExportRows(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {"a": "$x$ items", "b": "Bold", "c": "Done"},
+		"sv": {"a": "$y$ saker", "b": "Fet", "c": "Klar"},
+		"fi": {"a": "$x$ asiaa", "c": ""},
+	}
+	findings := runChecks(builtinChecks, translations)
+	findings = append(findings, Finding{Lang: "sv", Check: "plugin", Message: "too formal"})
+	want := map[string][][]string{
+		"sv": {
+			{"", "", "", "too formal"},
+			{"a", "$x$ items", "$y$ saker", "mismatch in variables: $x$ items ⇒ $y$ saker"},
+		},
+		"fi": {
+			{"b", "Bold", "", missingTranslation},
+			{"c", "Done", "", missingTranslation},
+		},
+	}
+	if got := exportRows(translations, findings, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	translations["fi-SV"] = Translation{"c": "Klar"}
+	delete(want, "sv")
+	want["fi-SV"] = [][]string{{"b", "Bold", "", missingTranslation}}
+	if got := exportRows(translations, nil, map[string]string{"fi-SV": "fi"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("fallbacks: want %q, got %q", want, got)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sv.csv")
+	rows := [][]string{{"a", "Hello, \"world\"", "Hej", "first\nsecond"}}
+	if err := writeCSV(path, exportHeader, rows); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	got, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := append([][]string{exportHeader}, rows...); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
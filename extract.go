@@ -0,0 +1,111 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+)
+
+// newKeys returns the referenced keys missing from en, with their english text.
+// The first default text given with a reference is used if there is one, otherwise the key itself.
+func newKeys(en Translation, refs []keyReference) Translation {
+	added := Translation{}
+	for _, ref := range refs {
+		if _, ok := en[ref.key]; !ok {
+			added[ref.key] = ref.key
+		}
+	}
+	withText := make(map[string]bool)
+	for _, ref := range refs {
+		if _, ok := added[ref.key]; ok && ref.text != "" && !withText[ref.key] {
+			added[ref.key] = ref.text
+			withText[ref.key] = true
+		}
+	}
+	return added
+}
+
+// extract collects the keys referenced in the application source and merges the new ones into the english
+// translation file, which is named after the configured pattern if it doesn't exist yet.
+func extract(args []string) {
+	flags := flag.NewFlagSet("extract", flag.ExitOnError)
+	var dirs listFlag
+	var patterns []string
+	flags.Var(&dirs, "dir", "comma separated source directories to scan, can be repeated (default from the configuration file)")
+	// The patterns aren't split, since regular expressions have commas.
+	flags.Func("pattern",
+		"regular expression matching key references, with the key as the first group and the optional default text "+
+			"as the second one, can be repeated (default t(\"key\", \"Text\") and {{ t \"key\" \"Text\" }} calls)",
+		func(pattern string) error { patterns = append(patterns, pattern); return nil })
+	dryRun := flags.Bool("dry-run", false, "only print the new keys, without changing en.json")
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v extract [flags] <translation-root-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	rootDir := flags.Arg(0)
+	if err := checkRootDir(rootDir); err != nil {
+		log.Fatal(err)
+	}
+
+	config := loadConfig(*configPath)
+	paths, walkFindings := findTranslationFiles(rootDir, config.Files)
+	for _, finding := range walkFindings {
+		log.Printf("extract: %v", finding.Message)
+	}
+	refs := scanSources("extract", config, dirs, patterns, paths)
+
+	enPath, ok := paths["en"]
+	en := Translation{}
+	var raw rawValues
+	if ok {
+		if isPOPath(enPath) {
+			log.Fatalf("extract: %v: the english reference is a PO file, add the keys to its template instead", enPath)
+		}
+		var err error
+		if en, raw, err = loadTranslation(enPath, config.Files.Arrays); err != nil {
+			log.Fatalf("extract: %v", err)
+		}
+	} else {
+		for _, path := range paths {
+			if isPOPath(path) {
+				// A new en.json would replace the source strings of the PO files as the reference.
+				log.Fatalf("extract: %v: the english reference is made of the source strings of the PO files, "+
+					"add the keys to their template instead", rootDir)
+			}
+		}
+		var err error
+		if enPath, err = translationFilePath(rootDir, config.Files.Pattern, "en", paths); err != nil {
+			log.Fatalf("extract: %v, create it first", err)
+		}
+	}
+
+	added := newKeys(en, refs)
+	var keys []string
+	for key := range added {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fmt.Printf("%v: %v\n", key, added[key])
+	}
+	if *dryRun || len(added) == 0 {
+		return
+	}
+
+	for key, text := range added {
+		en[key] = text
+	}
+	if err := writeTranslation(enPath, en, raw); err != nil {
+		log.Fatalf("extract: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "added %v keys to %v\n", len(added), enPath)
+}
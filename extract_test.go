@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewKeys(t *testing.T) {
+	en := Translation{"menu.file": "File"}
+	refs := []keyReference{
+		{key: "menu.file", text: "Changed"},
+		{key: "menu.edit"},
+		{key: "menu.edit", text: "Edit"},
+		{key: "menu.edit", text: "Edit again"},
+		{key: "menu.view"},
+	}
+	added := newKeys(en, refs)
+	want := Translation{"menu.edit": "Edit", "menu.view": "menu.view"}
+	if len(added) != len(want) {
+		t.Errorf("want %v, got %v", want, added)
+	}
+	for key, text := range want {
+		if added[key] != text {
+			t.Errorf("%v: want %q, got %q", key, text, added[key])
+		}
+	}
+}
+
+func TestExtractKeepsOtherValues(t *testing.T) {
+	dir, src := t.TempDir(), t.TempDir()
+	enPath := filepath.Join(dir, "en.json")
+	os.WriteFile(enPath, []byte(`{"a": "A", "meta": {"v": 1}, "n": 3}`), 0644)
+	os.WriteFile(filepath.Join(src, "app.js"), []byte(`t("a"); t("b", "B")`), 0644)
+
+	extract([]string{"-dir", src, dir})
+	bs, err := os.ReadFile(enPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(bs, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"a": "A", "b": "B", "meta": map[string]any{"v": 1.0}, "n": 3.0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import "slices"
+
+// fallbackChain returns the languages whose translations are shown in place of the missing ones of lang,
+// in order, following fallbacks, which maps a language to its fallback, like pt-BR to pt.
+func fallbackChain(lang string, fallbacks map[string]string) (chain []string) {
+	for {
+		next, ok := fallbacks[lang]
+		// A cycle would otherwise be followed forever.
+		if !ok || next == lang || slices.Contains(chain, next) {
+			return chain
+		}
+		chain = append(chain, next)
+		lang = next
+	}
+}
+
+// isTranslated reports whether key is translated in lang, or in one of the languages it falls back to.
+func isTranslated(translations map[string]Translation, fallbacks map[string]string, lang, key string) bool {
+	if translations[lang][key] != "" {
+		return true
+	}
+	for _, fallback := range fallbackChain(lang, fallbacks) {
+		if translations[fallback][key] != "" {
+			return true
+		}
+	}
+	return false
+}
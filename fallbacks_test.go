@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFallbackChain(t *testing.T) {
+	fallbacks := map[string]string{"pt-BR": "pt-PT", "pt-PT": "pt", "en-GB": "en", "a": "b", "b": "a", "c": "c"}
+	for lang, want := range map[string][]string{
+		"pt-BR": {"pt-PT", "pt"},
+		"en-GB": {"en"},
+		"sv":    nil,
+		"a":     {"b", "a"},
+		"c":     nil,
+	} {
+		if chain := fallbackChain(lang, fallbacks); !reflect.DeepEqual(chain, want) {
+			t.Errorf("%v: want %v, got %v", lang, want, chain)
+		}
+	}
+
+	translations := map[string]Translation{
+		"en":    {"a": "Color", "b": "Save", "c": "Done"},
+		"en-GB": {"a": "Colour"},
+		"pt":    {"b": "Salvar"},
+		"pt-BR": {},
+	}
+	for _, test := range []struct {
+		lang, key string
+		want      bool
+	}{
+		{"en-GB", "a", true},
+		{"en-GB", "c", true},
+		{"pt-BR", "b", true},
+		{"pt-BR", "c", false},
+	} {
+		if got := isTranslated(translations, fallbacks, test.lang, test.key); got != test.want {
+			t.Errorf("%v %v: want %v, got %v", test.lang, test.key, test.want, got)
+		}
+	}
+}
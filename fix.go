@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// fixVariables restores the english variables in a translated string whose variables have been
+// changed, e.g. translated. This is only possible when the changed variable can be paired with
+// the missing one unambiguously, i.e. when exactly one distinct variable is wrong.
+func fixVariables(enString, translated string) (string, bool) {
+	enVars := variableRx.FindAllString(enString, -1)
+	langVars := variableRx.FindAllString(translated, -1)
+	var missing, extra []string
+	for _, v := range enVars {
+		if !slices.Contains(langVars, v) && !slices.Contains(missing, v) {
+			missing = append(missing, v)
+		}
+	}
+	for _, v := range langVars {
+		if !slices.Contains(enVars, v) && !slices.Contains(extra, v) {
+			extra = append(extra, v)
+		}
+	}
+	if len(missing) != 1 || len(extra) != 1 {
+		return "", false
+	}
+	return strings.ReplaceAll(translated, extra[0], missing[0]), true
+}
+
+// unclosedTags returns the tags of input which are started but never ended, the innermost first.
+// ok is false if input has other markup errors, like an ending tag without a starting one.
+func unclosedTags(input string) (tags []string, ok bool) {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			slices.Reverse(tags)
+			return tags, errors.Is(tokenizer.Err(), io.EOF)
+		case html.StartTagToken:
+			name, _ := tokenizer.TagName()
+			tags = append(tags, string(name))
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if len(tags) == 0 || tags[len(tags)-1] != string(name) {
+				return nil, false
+			}
+			tags = tags[:len(tags)-1]
+		}
+	}
+}
+
+// fixUnclosedTags closes the tags of a translated string which are started but never ended,
+// at its end. This is only done when the english string is valid and ends with the same tags
+// being closed, like <b>Saved</b> translated to <b>Sparat.
+func fixUnclosedTags(enString, translated string) (string, bool) {
+	if len(checkHTML(enString)) > 0 {
+		return "", false
+	}
+	tags, ok := unclosedTags(translated)
+	if !ok || len(tags) == 0 {
+		return "", false
+	}
+	var closing strings.Builder
+	for _, tag := range tags {
+		fmt.Fprintf(&closing, "</%v>", tag)
+	}
+	if !strings.HasSuffix(enString, closing.String()) {
+		return "", false
+	}
+	return translated + closing.String(), true
+}
+
+// fixFinding returns the translated string of finding fixed, if it has an obvious mechanical fix.
+func fixFinding(finding Finding, enString, translated string) (string, bool) {
+	switch finding.Check {
+	case "variables":
+		return fixVariables(enString, translated)
+	case "html":
+		return fixUnclosedTags(enString, translated)
+	}
+	return "", false
+}
+
+// encodeJSONString encodes s as a JSON string, leaving HTML characters unescaped
+// as they are usually written in translation files.
+func encodeJSONString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
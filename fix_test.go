@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestFixVariables(t *testing.T) {
+	var tests = []struct {
+		en, translated string
+		want           string
+		ok             bool
+	}{
+		{"Hello $name$", "Hej $namn$", "Hej $name$", true},
+		{"$a$ and $b$", "$a$ och $c$, $c$", "$a$ och $b$, $b$", true},
+		{"$a$ and $b$", "$c$ och $d$", "", false},
+		{"Hello $name$", "Hej", "", false},
+		{"Hello", "Hej $name$", "", false},
+	}
+	for _, test := range tests {
+		got, ok := fixVariables(test.en, test.translated)
+		if got != test.want || ok != test.ok {
+			t.Errorf("%q ⇒ %q: want %q, %v, got %q, %v", test.en, test.translated, test.want, test.ok, got, ok)
+		}
+	}
+}
+
+func TestFixUnclosedTags(t *testing.T) {
+	var tests = []struct {
+		en, translated string
+		want           string
+		ok             bool
+	}{
+		{"<b>Saved</b>", "<b>Sparat", "<b>Sparat</b>", true},
+		{"Read <a href='/x'><b>this</b></a>", "Läs <a href='/x'><b>detta", "Läs <a href='/x'><b>detta</b></a>", true},
+		{"<b>Bold</b> text", "<b>Fet text", "", false},
+		{"<b>Saved</b>", "<b>Sparat</i>", "", false},
+		{"<b>Saved</b>", "<b>Sparat</b>", "", false},
+		{"<b>Saved", "<b>Sparat", "", false},
+	}
+	for _, test := range tests {
+		got, ok := fixUnclosedTags(test.en, test.translated)
+		if got != test.want || ok != test.ok {
+			t.Errorf("%q ⇒ %q: want %q, %v, got %q, %v", test.en, test.translated, test.want, test.ok, got, ok)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// gerritRobotID identifies the robot comments of check-translations, and the tag of its reviews
+// marks them as automated, which Gerrit can hide.
+const (
+	gerritRobotID = "check-translations"
+	gerritTag     = "autogenerated:check-translations"
+)
+
+// gerritRange is a range of a file of a change: the lines start at 1 and the characters at 0.
+type gerritRange struct {
+	StartLine      int `json:"start_line"`
+	StartCharacter int `json:"start_character"`
+	EndLine        int `json:"end_line"`
+	EndCharacter   int `json:"end_character"`
+}
+
+// gerritReplacement replaces a range of a file.
+type gerritReplacement struct {
+	Path        string      `json:"path"`
+	Range       gerritRange `json:"range"`
+	Replacement string      `json:"replacement"`
+}
+
+// gerritFixSuggestion is a fix a reviewer can preview and apply from a robot comment.
+type gerritFixSuggestion struct {
+	Description  string              `json:"description"`
+	Replacements []gerritReplacement `json:"replacements"`
+}
+
+// gerritRobotComment is a robot comment on a line of a file, or on the whole file without a line.
+// See https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#robot-comment-input
+type gerritRobotComment struct {
+	RobotID        string                `json:"robot_id"`
+	RobotRunID     string                `json:"robot_run_id"`
+	URL            string                `json:"url,omitempty"`
+	Line           int                   `json:"line,omitempty"`
+	Message        string                `json:"message"`
+	FixSuggestions []gerritFixSuggestion `json:"fix_suggestions,omitempty"`
+}
+
+// gerritRobotComments turns the findings on the files of a change into robot comments, by file, with
+// a fix suggestion replacing the value of their key when fixFinding can fix it, and the warnings of
+// rules marked as such. The translation
+// files are read from paths, while repoPaths holds their paths relative to the root of the repository,
+// as used by files, the files of the change, and the comments. The number of findings which aren't
+// on a file of the change is also returned.
+func gerritRobotComments(findings []Finding, rules ruleSeverities, translations map[string]Translation, paths, repoPaths map[string]string,
+	files map[string]bool, runID, runURL string) (comments map[string][]gerritRobotComment, outside int) {
+	comments = make(map[string][]gerritRobotComment)
+	contents := make(map[string][]byte)
+	members := make(map[string]map[string]member)
+	for _, finding := range findings {
+		path, ok := repoPaths[finding.Lang]
+		if !ok || !files[path] {
+			outside++
+			continue
+		}
+		if _, ok := contents[path]; !ok {
+			// The files which can't be read or parsed, like the PO ones, are commented as a whole.
+			contents[path], _ = os.ReadFile(paths[finding.Lang])
+			members[path], _ = locateMembers(contents[path])
+		}
+		rule := rules.id(finding.Check)
+		if rules.isWarning(finding) {
+			rule += ", warning"
+		}
+		comment := gerritRobotComment{
+			RobotID:    gerritRobotID,
+			RobotRunID: runID,
+			URL:        runURL,
+			Message:    fmt.Sprintf("%v (%v): %v", finding.Check, rule, finding.Message),
+		}
+		if m, ok := members[path][finding.Key]; ok && finding.Key != "" {
+			bs := contents[path]
+			line, _ := lineCol(bs, m.key.start)
+			comment.Line = line + 1
+			if fixed, ok := fixFinding(finding, translations["en"][finding.Key], translations[finding.Lang][finding.Key]); ok {
+				startLine, startChar := lineCol(bs, m.value.start)
+				endLine, endChar := lineCol(bs, m.value.end)
+				comment.FixSuggestions = []gerritFixSuggestion{{
+					Description: fmt.Sprintf("Fix %v", finding.Check),
+					Replacements: []gerritReplacement{{
+						Path:        path,
+						Range:       gerritRange{startLine + 1, startChar, endLine + 1, endChar},
+						Replacement: encodeJSONString(fixed),
+					}},
+				}}
+			}
+		}
+		comments[path] = append(comments[path], comment)
+	}
+	return comments, outside
+}
+
+// gerritReviewInput returns the review posting the robot comments of count findings, outside of them
+// not being on the files of the change, and voting on label, if not "", -1 if the run failed and +1 otherwise.
+func gerritReviewInput(count, outside int, comments map[string][]gerritRobotComment, label string, failed bool) map[string]any {
+	message := fmt.Sprintf("check-translations found %v problems.", count)
+	if outside > 0 {
+		message += fmt.Sprintf(" %v of them are not on files of this change, see the CI log for details.", outside)
+	}
+	review := map[string]any{
+		"message":        message,
+		"tag":            gerritTag,
+		"robot_comments": comments,
+	}
+	if label != "" {
+		vote := 1
+		if failed {
+			vote = -1
+		}
+		review["labels"] = map[string]int{label: vote}
+	}
+	return review
+}
+
+// gerritClient is a minimal client of the REST API of a Gerrit server, authenticated with the HTTP
+// password of a user, or anonymous.
+type gerritClient struct {
+	base   string
+	header http.Header
+}
+
+func newGerritClient(server, user, password string) *gerritClient {
+	c := &gerritClient{base: strings.TrimSuffix(server, "/"), header: http.Header{}}
+	// The authenticated endpoints are under /a/.
+	if user != "" {
+		c.base += "/a"
+		c.header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+password)))
+	}
+	return c
+}
+
+// request sends a request with an optional JSON body to the endpoint at path and decodes the JSON
+// response into out, if not nil. Gerrit prefixes its JSON responses against XSSI.
+func (c *gerritClient) request(method, path string, body, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(bs)
+	}
+	req, err := http.NewRequest(method, c.base+path, reqBody)
+	if err != nil {
+		return err
+	}
+	for name, values := range c.header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	bs, err := fetch(httpClient, req)
+	if err != nil || out == nil {
+		return err
+	}
+	return json.Unmarshal(bytes.TrimPrefix(bs, []byte(")]}'")), out)
+}
+
+// revisionFiles returns the files of a revision of a change.
+func (c *gerritClient) revisionFiles(change, revision string) (map[string]bool, error) {
+	var files map[string]json.RawMessage
+	path := fmt.Sprintf("/changes/%v/revisions/%v/files", url.PathEscape(change), url.PathEscape(revision))
+	if err := c.request(http.MethodGet, path, nil, &files); err != nil {
+		return nil, err
+	}
+	result := make(map[string]bool)
+	for file := range files {
+		result[file] = true
+	}
+	return result, nil
+}
+
+// gerritReview posts the findings on the translation files of a Gerrit change as robot comments,
+// with fix suggestions, in a review voting on a label if one is given.
+func gerritReview(args []string) {
+	flags := flag.NewFlagSet("gerrit-review", flag.ExitOnError)
+	server := flags.String("url", os.Getenv("GERRIT_URL"), "URL of the Gerrit server")
+	change := flags.String("change", os.Getenv("GERRIT_CHANGE_NUMBER"), "number or identifier of the change")
+	revision := flags.String("revision", os.Getenv("GERRIT_PATCHSET_REVISION"), "revision reviewed (default the current one)")
+	var user, password string
+	envFlag(flags, &user, "user", "GERRIT_USER", "user reviewing the change")
+	envFlag(flags, &password, "password", "GERRIT_HTTP_PASSWORD", "HTTP password of the user")
+	label := flags.String("label", "", "label voted on, -1 if the run fails and +1 otherwise, like Verified")
+	runURL := flags.String("run-url", os.Getenv("BUILD_URL"), "URL of the build, linked from the comments")
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v gerrit-review [flags] <translation-root-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 || *server == "" || *change == "" {
+		flags.Usage()
+		os.Exit(1)
+	}
+	rootDir := flags.Arg(0)
+	if err := checkRootDir(rootDir); err != nil {
+		log.Fatal(err)
+	}
+	if *revision == "" {
+		*revision = "current"
+	}
+
+	config := loadConfig(*configPath)
+	paths, translations, findings := checkTranslationRoot(rootDir, config)
+	reportText(os.Stderr, findings, config.Rules)
+	failed := failsBudgets(findings, config.Rules, languageBudgets(config))
+
+	repoPaths, err := repoRelativePaths(paths)
+	if err != nil {
+		log.Fatalf("gerritReview: %v", err)
+	}
+	client := newGerritClient(*server, user, password)
+	files, err := client.revisionFiles(*change, *revision)
+	if err != nil {
+		log.Fatalf("gerritReview: %v", err)
+	}
+	runID := time.Now().UTC().Format(time.RFC3339)
+	comments, outside := gerritRobotComments(findings, config.Rules, translations, paths, repoPaths, files, runID, *runURL)
+	review := gerritReviewInput(len(findings), outside, comments, *label, failed)
+	path := fmt.Sprintf("/changes/%v/revisions/%v/review", url.PathEscape(*change), url.PathEscape(*revision))
+	if err := client.request(http.MethodPost, path, review, nil); err != nil {
+		log.Fatalf("gerritReview: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "review of %v,%v: %v robot comments\n", *change, *revision, len(findings)-outside)
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGerritRobotComments(t *testing.T) {
+	dir := t.TempDir()
+	svPath := filepath.Join(dir, "sv.json")
+	sv := "{\n  \"greeting\": \"Hej $namn$ <b>!</b>\",\n  \"old\": \"$x$\"\n}\n"
+	os.WriteFile(svPath, []byte(sv), 0644)
+
+	translations := map[string]Translation{
+		"en": {"greeting": "Hello $name$ <b>!</b>", "old": "$y$"},
+		"sv": {"greeting": "Hej $namn$ <b>!</b>", "old": "$x$"},
+	}
+	findings := append(checkTranslationVariables(translations), Finding{Lang: "de", Check: "languages", Message: "missing translation file"})
+	paths := map[string]string{"sv": svPath}
+	repoPaths := map[string]string{"sv": "locales/sv.json"}
+	files := map[string]bool{"locales/sv.json": true}
+
+	comments, outside := gerritRobotComments(findings, ruleSeverities{}, translations, paths, repoPaths, files, "run", "")
+	if outside != 1 {
+		t.Errorf("want 1 finding outside the change, got %v", outside)
+	}
+	if len(comments["locales/sv.json"]) != 2 {
+		t.Fatalf("want 2 comments on locales/sv.json, got %v", comments)
+	}
+	var fixed *gerritRobotComment
+	for i, c := range comments["locales/sv.json"] {
+		if c.RobotID != gerritRobotID || c.RobotRunID != "run" {
+			t.Errorf("want robot %v of run, got %+v", gerritRobotID, c)
+		}
+		if c.Line == 2 {
+			fixed = &comments["locales/sv.json"][i]
+		}
+	}
+	if fixed == nil || len(fixed.FixSuggestions) != 1 {
+		t.Fatalf("want a fix suggestion on line 2, got %+v", comments)
+	}
+	want := []gerritReplacement{{
+		Path:        "locales/sv.json",
+		Range:       gerritRange{2, 14, 2, 35},
+		Replacement: `"Hej $name$ <b>!</b>"`,
+	}}
+	if got := fixed.FixSuggestions[0].Replacements; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestGerritReviewWarnings(t *testing.T) {
+	dir := t.TempDir()
+	svPath := filepath.Join(dir, "sv.json")
+	os.WriteFile(svPath, []byte("{\n  \"greeting\": \"Hej $namn$\"\n}\n"), 0644)
+	findings := []Finding{{Lang: "sv", Key: "greeting", Check: "variables", Message: "mismatch in variables"}}
+	rules := newRuleSeverities(map[string]string{"variables": severityWarning})
+	comments, outside := gerritRobotComments(findings, rules, nil, map[string]string{"sv": svPath},
+		map[string]string{"sv": "locales/sv.json"}, map[string]bool{"locales/sv.json": true}, "run", "")
+	if got := comments["locales/sv.json"]; len(got) != 1 || got[0].Message != "variables (VAR001, warning): mismatch in variables" {
+		t.Errorf("want a warning comment, got %+v", comments)
+	}
+	review := gerritReviewInput(len(findings), outside, comments, "Verified", failsBudgets(findings, rules, nil))
+	if want := map[string]int{"Verified": 1}; !reflect.DeepEqual(review["labels"], want) {
+		t.Errorf("want the vote %v, got %v", want, review["labels"])
+	}
+}
+
+func TestGerritClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, _ := r.BasicAuth(); user != "bot" || password != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.EscapedPath() != "/a/changes/project~42/revisions/current/files" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, ")]}'\n{\"/COMMIT_MSG\": {}, \"locales/sv.json\": {\"lines_inserted\": 1}}")
+	}))
+	defer server.Close()
+
+	files, err := newGerritClient(server.URL, "bot", "secret").revisionFiles("project~42", "current")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]bool{"/COMMIT_MSG": true, "locales/sv.json": true}; !reflect.DeepEqual(files, want) {
+		t.Errorf("want %v, got %v", want, files)
+	}
+}
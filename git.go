@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// gitOutput runs git with args and returns its standard output.
+func gitOutput(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %v: %w: %v", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// gitLines runs git with args and returns the non-empty lines of its output.
+func gitLines(args ...string) ([]string, error) {
+	out, err := gitOutput(args...)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// loadStagedTranslations loads the translation files under rootDir from the git index, like
+// loadRevisionTranslations does from a revision. It also returns the languages whose files are
+// staged for the next commit.
+func loadStagedTranslations(rootDir string, files FilesConfig) (map[string]Translation, []Finding, []string, error) {
+	match, wants, err := translationFileFilter(files)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// The paths are relative to the current directory, like the one of rootDir.
+	paths, err := gitLines("ls-files", "--", rootDir)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	contents := make(map[string][]byte)
+	for _, p := range paths {
+		file, err := filepath.Rel(rootDir, p)
+		if err != nil || !wants(filepath.ToSlash(file)) {
+			continue
+		}
+		bs, err := gitOutput("show", ":./"+filepath.ToSlash(p))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		contents[filepath.ToSlash(file)] = bs
+	}
+	translations, findings := loadFileContents(":"+filepath.ToSlash(rootDir), contents, match, files.Arrays)
+
+	staged, err := gitLines("diff", "--cached", "--name-only", "--relative", "--diff-filter=ACMR", "--", rootDir)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var langs []string
+	for _, p := range staged {
+		file, err := filepath.Rel(rootDir, p)
+		if err != nil || !wants(filepath.ToSlash(file)) {
+			continue
+		}
+		lang, _ := match(filepath.Base(file))
+		langs = append(langs, lang)
+	}
+	return translations, findings, langs, nil
+}
+
+// loadRevisionTranslations loads the translation files under rootDir from the git revision ref, like a
+// tag, instead of the working tree, so rootDir doesn't need to exist anymore. The files which can't be
+// loaded are left out and reported as findings, like loadTranslations does.
+func loadRevisionTranslations(rootDir, ref string, files FilesConfig) (map[string]Translation, []Finding, error) {
+	match, wants, err := translationFileFilter(files)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The paths are relative to the current directory, like the one of rootDir.
+	paths, err := gitLines("ls-tree", "-r", "--name-only", ref, "--", rootDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	contents := make(map[string][]byte)
+	for _, p := range paths {
+		file, err := filepath.Rel(rootDir, p)
+		if err != nil || !wants(filepath.ToSlash(file)) {
+			continue
+		}
+		bs, err := gitOutput("show", ref+":./"+filepath.ToSlash(p))
+		if err != nil {
+			return nil, nil, err
+		}
+		contents[filepath.ToSlash(file)] = bs
+	}
+	translations, findings := loadFileContents(ref+":"+filepath.ToSlash(rootDir), contents, match, files.Arrays)
+	return translations, findings, nil
+}
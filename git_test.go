@@ -0,0 +1,120 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestLoadRevisionTranslations(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("no git")
+	}
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	git := func(args ...string) {
+		args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	write := func(file, content string) {
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q")
+	write("locales/en.json", `{"save": "Save"}`)
+	write("locales/sv.json", `{"save": "Spara"}`)
+	write("locales/de.json", `{"save": `)
+	git("add", ".")
+	git("commit", "-q", "-m", "v1")
+	git("tag", "v1")
+	// The directory was renamed since.
+	git("mv", "locales", "i18n")
+	write("i18n/sv.json", `{"save": "Spara!"}`)
+	git("commit", "-q", "-a", "-m", "v2")
+
+	translations, findings, err := loadRevisionTranslations("locales", "v1", FilesConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]Translation{"en": {"save": "Save"}, "sv": {"save": "Spara"}}
+	if !reflect.DeepEqual(translations, want) {
+		t.Errorf("want %v, got %v", want, translations)
+	}
+	if len(findings) != 1 || findings[0].Lang != "de" || findings[0].Check != "load" {
+		t.Errorf("unexpected findings: %v", findings)
+	}
+
+	if _, _, err := loadRevisionTranslations("locales", "v3", FilesConfig{}); err == nil {
+		t.Error("want an error for an unknown revision")
+	}
+}
+
+func TestLoadStagedTranslations(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("no git")
+	}
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	git := func(args ...string) {
+		args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	os.Mkdir("locales", 0o755)
+	os.WriteFile("locales/en.json", []byte(`{"save": "Save"}`), 0o644)
+	os.WriteFile("locales/de.json", []byte(`{"save": "Speichern"}`), 0o644)
+	git("init", "-q")
+	git("add", ".")
+	git("commit", "-q", "-m", "v1")
+	os.WriteFile("locales/sv.json", []byte(`{"save": "Spara", "count": 3}`), 0o644)
+	os.WriteFile("locales/fi.po", []byte("msgid \"Save\"\nmsgstr \"Tallenna\"\n"), 0o644)
+	os.WriteFile("locales/de.json", []byte(`{"save": `), 0o644)
+	git("add", ".")
+	// Only the staged contents are read.
+	os.WriteFile("locales/sv.json", []byte(`{"save": "Spara!"}`), 0o644)
+
+	translations, findings, langs, err := loadStagedTranslations("locales", FilesConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]Translation{"en": {"save": "Save"}, "sv": {"save": "Spara"}, "fi": {"Save": "Tallenna"}}
+	if !reflect.DeepEqual(translations, want) {
+		t.Errorf("want %v, got %v", want, translations)
+	}
+	var checks []string
+	for _, finding := range findings {
+		checks = append(checks, finding.Lang+" "+finding.Check)
+	}
+	slices.Sort(checks)
+	if want := []string{"de load", "sv value"}; !reflect.DeepEqual(checks, want) {
+		t.Errorf("want findings %v, got %v", want, checks)
+	}
+	slices.Sort(langs)
+	if want := []string{"de", "fi", "sv"}; !reflect.DeepEqual(langs, want) {
+		t.Errorf("want staged %v, got %v", want, langs)
+	}
+}
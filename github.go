@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// githubClient is a minimal client of the GitHub REST API, for the endpoints of a single repository.
+type githubClient struct {
+	apiClient
+}
+
+// newGithubClient returns a client of repo, in the owner/name form, on the API at api,
+// e.g. https://api.github.com
+func newGithubClient(api, repo, token string) *githubClient {
+	return &githubClient{apiClient{
+		base: fmt.Sprintf("%v/repos/%v", strings.TrimSuffix(api, "/"), repo),
+		header: http.Header{
+			"Accept":        {"application/vnd.github+json"},
+			"Authorization": {"Bearer " + token},
+		},
+	}}
+}
+
+// githubReviewComment is a comment on a single line of a pull request review.
+type githubReviewComment struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Side string `json:"side"`
+	Body string `json:"body"`
+}
+
+// pullRequestChanges returns the head commit of a pull request, and for every file it changes,
+// the lines added by it.
+func (c *githubClient) pullRequestChanges(number int) (string, map[string]map[int]bool, error) {
+	var pr struct {
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	}
+	if err := c.do(http.MethodGet, fmt.Sprintf("/pulls/%v", number), nil, &pr); err != nil {
+		return "", nil, err
+	}
+
+	changed := make(map[string]map[int]bool)
+	for page := 1; ; page++ {
+		var files []struct {
+			Filename string `json:"filename"`
+			Patch    string `json:"patch"`
+		}
+		err := c.do(http.MethodGet, fmt.Sprintf("/pulls/%v/files?per_page=100&page=%v", number, page), nil, &files)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(files) == 0 {
+			break
+		}
+		for _, file := range files {
+			changed[file.Filename] = changedLines(file.Patch)
+		}
+	}
+	return pr.Head.SHA, changed, nil
+}
+
+var hunkRx = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// changedLines returns the line numbers, in the new version of the file, added by a unified diff.
+func changedLines(patch string) map[int]bool {
+	lines := make(map[int]bool)
+	line := 0
+	for _, l := range strings.Split(patch, "\n") {
+		if m := hunkRx.FindStringSubmatch(l); m != nil {
+			line, _ = strconv.Atoi(m[1])
+			continue
+		}
+		switch {
+		case strings.HasPrefix(l, "+"):
+			lines[line] = true
+			line++
+		case strings.HasPrefix(l, " "):
+			line++
+		}
+	}
+	return lines
+}
+
+// postedComments returns the review comments already posted on a pull request.
+func (c *githubClient) postedComments(number int) ([]githubReviewComment, error) {
+	var posted []githubReviewComment
+	for page := 1; ; page++ {
+		var comments []githubReviewComment
+		err := c.do(http.MethodGet, fmt.Sprintf("/pulls/%v/comments?per_page=100&page=%v", number, page), nil, &comments)
+		if err != nil {
+			return nil, err
+		}
+		if len(comments) == 0 {
+			return posted, nil
+		}
+		posted = append(posted, comments...)
+	}
+}
+
+// newReviewComments returns the comments which haven't been posted yet, at the same path and line.
+func newReviewComments(comments, posted []githubReviewComment) (fresh []githubReviewComment) {
+	for _, comment := range comments {
+		if !slices.ContainsFunc(posted, func(p githubReviewComment) bool {
+			return p.Path == comment.Path && p.Line == comment.Line && p.Body == comment.Body
+		}) {
+			fresh = append(fresh, comment)
+		}
+	}
+	return fresh
+}
+
+// reviewComments turns the findings on the lines changed by a pull request into review comments,
+// the warnings of rules marked as such. The translation files are read from paths, while repoPaths
+// holds their paths relative to the root of the repository, as used by changed and the comments.
+// The number of findings which couldn't be placed on a changed line is also returned.
+func reviewComments(findings []Finding, rules ruleSeverities, translations map[string]Translation, paths, repoPaths map[string]string,
+	changed map[string]map[int]bool) (comments []githubReviewComment, outside int) {
+	contents := make(map[string][]byte)
+	members := make(map[string]map[string]member)
+	for _, finding := range findings {
+		path := repoPaths[finding.Lang]
+		if _, ok := members[path]; !ok && finding.Key != "" {
+			bs, err := os.ReadFile(paths[finding.Lang])
+			if err == nil {
+				contents[path] = bs
+				members[path], _ = locateMembers(bs)
+			}
+		}
+		m, ok := members[path][finding.Key]
+		if !ok {
+			outside++
+			continue
+		}
+		bs := contents[path]
+		line, _ := lineCol(bs, m.value.start)
+		line++
+		if !changed[path][line] {
+			outside++
+			continue
+		}
+
+		rule := rules.id(finding.Check)
+		if rules.isWarning(finding) {
+			rule += ", warning"
+		}
+		body := fmt.Sprintf("**%v** (%v): %v", finding.Check, rule, finding.Message)
+		if suggestion, ok := suggestLine(finding, translations, bs, m); ok {
+			body += "\n\n```suggestion\n" + suggestion + "\n```"
+		}
+		comments = append(comments, githubReviewComment{Path: path, Line: line, Side: "RIGHT", Body: body})
+	}
+	return comments, outside
+}
+
+// suggestLine returns the line of the translation file bs holding m, with the value fixed, if possible.
+// A suggestion replaces whole lines, so only values written on a single line can be fixed.
+func suggestLine(finding Finding, translations map[string]Translation, bs []byte, m member) (string, bool) {
+	fixed, ok := fixFinding(finding, translations["en"][finding.Key], translations[finding.Lang][finding.Key])
+	if !ok {
+		return "", false
+	}
+	lineStart := bytes.LastIndexByte(bs[:m.value.start], '\n') + 1
+	lineEnd := len(bs)
+	if i := bytes.IndexByte(bs[m.value.start:], '\n'); i >= 0 {
+		lineEnd = m.value.start + i
+	}
+	if lineEnd < m.value.end {
+		return "", false
+	}
+	return string(bs[lineStart:m.value.start]) + encodeJSONString(fixed) + string(bs[m.value.end:lineEnd]), true
+}
+
+// repoRelativePaths returns the paths of the translation files relative to the root of the git
+// repository, slash separated, which is how GitHub identifies files.
+func repoRelativePaths(paths map[string]string) (map[string]string, error) {
+	out, err := gitOutput("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	top := strings.TrimSpace(string(out))
+	repoPaths := make(map[string]string)
+	for lang, path := range paths {
+		abs, _ := filepath.Abs(path)
+		rel, err := filepath.Rel(top, abs)
+		if err != nil {
+			return nil, err
+		}
+		repoPaths[lang] = filepath.ToSlash(rel)
+	}
+	return repoPaths, nil
+}
+
+// githubReview posts the findings on the translation files changed by a pull request as review comments.
+func githubReview(args []string) {
+	flags := flag.NewFlagSet("github-review", flag.ExitOnError)
+	repo := flags.String("repo", os.Getenv("GITHUB_REPOSITORY"), "repository in the owner/name form")
+	number := flags.Int("pr", 0, "number of the pull request")
+	token := flags.String("token", os.Getenv("GITHUB_TOKEN"), "GitHub token allowed to review the pull request")
+	defaultAPI := os.Getenv("GITHUB_API_URL")
+	if defaultAPI == "" {
+		defaultAPI = "https://api.github.com"
+	}
+	api := flags.String("api", defaultAPI, "base URL of the GitHub API")
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v github-review [flags] <translation-root-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 || *number == 0 || *repo == "" || *token == "" {
+		flags.Usage()
+		os.Exit(1)
+	}
+	rootDir := flags.Arg(0)
+	if err := checkRootDir(rootDir); err != nil {
+		log.Fatal(err)
+	}
+
+	config := loadConfig(*configPath)
+	paths, translations, findings := checkTranslationRoot(rootDir, config)
+	reportText(os.Stderr, findings, config.Rules)
+	if len(findings) == 0 {
+		return
+	}
+	failed := failsBudgets(findings, config.Rules, languageBudgets(config))
+
+	repoPaths, err := repoRelativePaths(paths)
+	if err != nil {
+		log.Fatalf("githubReview: %v", err)
+	}
+
+	client := newGithubClient(*api, *repo, *token)
+	sha, changed, err := client.pullRequestChanges(*number)
+	if err != nil {
+		log.Fatalf("githubReview: %v", err)
+	}
+	comments, outside := reviewComments(findings, config.Rules, translations, paths, repoPaths, changed)
+	posted, err := client.postedComments(*number)
+	if err != nil {
+		log.Fatalf("githubReview: %v", err)
+	}
+	fresh := newReviewComments(comments, posted)
+
+	errorCount := len(slices.DeleteFunc(slices.Clone(findings), config.Rules.isWarning))
+	body := fmt.Sprintf("check-translations found %v errors and %v warnings.", errorCount, len(findings)-errorCount)
+	if outside > 0 {
+		body += fmt.Sprintf(" %v of them are not on lines changed by this pull request, see the CI log for details.", outside)
+	}
+	if len(fresh) < len(comments) {
+		body += fmt.Sprintf(" %v of them were already commented on.", len(comments)-len(fresh))
+	}
+	// A review of the comments already posted only would repeat the previous one.
+	if len(fresh) == 0 && len(comments) > 0 {
+		fmt.Fprintf(os.Stderr, "all the %v comments were already posted\n", len(comments))
+		if failed {
+			os.Exit(1)
+		}
+		return
+	}
+	review := map[string]any{
+		"commit_id": sha,
+		"event":     "COMMENT",
+		"body":      body,
+		"comments":  fresh,
+	}
+	if err := client.do(http.MethodPost, fmt.Sprintf("/pulls/%v/reviews", *number), review, nil); err != nil {
+		log.Fatalf("githubReview: %v", err)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// githubAnnotationsPerRequest is the most annotations a request of the Checks API can add.
+const githubAnnotationsPerRequest = 50
+
+// githubMaxText is the longest summary or text of the output of a check run.
+const githubMaxText = 65535
+
+// githubAnnotation is an annotation of a check run on the lines of a file.
+type githubAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title"`
+	Message         string `json:"message"`
+}
+
+// checkAnnotations turns findings into annotations of the translation files of their language,
+// located by locateFindings. The translation files are read from paths, while repoPaths holds their
+// paths relative to the root of the repository. The number of findings without a translation file is also returned.
+func checkAnnotations(findings []Finding, rules ruleSeverities, paths, repoPaths map[string]string) (annotations []githubAnnotation, outside int) {
+	located, rest := locateFindings(findings, paths)
+	for _, finding := range located {
+		level := "failure"
+		if rules.isWarning(finding.Finding) {
+			level = "warning"
+		}
+		title := fmt.Sprintf("%v %v", rules.id(finding.Check), finding.Check)
+		if finding.Key != "" {
+			title += ": " + finding.Key
+		}
+		annotations = append(annotations, githubAnnotation{
+			Path:            repoPaths[finding.Lang],
+			StartLine:       finding.start,
+			EndLine:         finding.end,
+			AnnotationLevel: level,
+			Title:           title,
+			Message:         finding.Message,
+		})
+	}
+	return annotations, len(rest)
+}
+
+// checkRunOutput returns the output of a check run reporting findings: a title, a summary
+// of their counts and the text report.
+func checkRunOutput(findings []Finding, rules ruleSeverities, outside int) map[string]any {
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "check-translations found %v problems.", len(findings))
+	if outside > 0 {
+		fmt.Fprintf(&summary, " %v of them are not in a translation file, see the details.", outside)
+	}
+	if len(findings) > 0 {
+		summary.WriteString("\n\n| Language | Findings |\n| --- | --- |")
+		byLang := findingsByLang(findings)
+		for _, lang := range sortedKeys(byLang) {
+			name := lang
+			if lang == "" {
+				name = "files"
+			}
+			fmt.Fprintf(&summary, "\n| %v | %v |", name, len(byLang[lang]))
+		}
+	}
+	var report bytes.Buffer
+	reportText(&report, findings, rules)
+	text := "```\n" + report.String() + "```"
+	if len(text) > githubMaxText {
+		text = strings.ToValidUTF8(text[:githubMaxText-len("\n…\n```")], "") + "\n…\n```"
+	}
+	return map[string]any{
+		"title":   fmt.Sprintf("%v problems", len(findings)),
+		"summary": summary.String(),
+		"text":    text,
+	}
+}
+
+// githubAppToken returns an installation token of the GitHub App appID for repo, authenticating as
+// the app with a JWT signed by its private key keyPEM.
+// See https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app
+func githubAppToken(api, repo, appID string, keyPEM []byte, now time.Time) (string, error) {
+	// The clock of GitHub may be late, and the JWT can't be valid for more than 10 minutes.
+	jwt, err := signJWT(keyPEM, map[string]any{
+		"iss": appID,
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	app := &apiClient{
+		base: strings.TrimSuffix(api, "/"),
+		header: http.Header{
+			"Accept":        {"application/vnd.github+json"},
+			"Authorization": {"Bearer " + jwt},
+		},
+	}
+	var installation struct {
+		ID int64 `json:"id"`
+	}
+	if err := app.do(http.MethodGet, fmt.Sprintf("/repos/%v/installation", repo), nil, &installation); err != nil {
+		return "", err
+	}
+	var token struct {
+		Token string `json:"token"`
+	}
+	err = app.do(http.MethodPost, fmt.Sprintf("/app/installations/%v/access_tokens", installation.ID), nil, &token)
+	return token.Token, err
+}
+
+// createCheckRun creates a completed check run of the commit sha reporting findings, adding the
+// annotations beyond the ones the creation can hold by updating it.
+func (c *githubClient) createCheckRun(name, sha, conclusion string, output map[string]any, annotations []githubAnnotation) error {
+	first := annotations[:min(len(annotations), githubAnnotationsPerRequest)]
+	output["annotations"] = first
+	run := map[string]any{
+		"name":       name,
+		"head_sha":   sha,
+		"status":     "completed",
+		"conclusion": conclusion,
+		"output":     output,
+	}
+	var created struct {
+		ID int64 `json:"id"`
+	}
+	if err := c.do(http.MethodPost, "/check-runs", run, &created); err != nil {
+		return err
+	}
+	for rest := annotations[len(first):]; len(rest) > 0; {
+		batch := rest[:min(len(rest), githubAnnotationsPerRequest)]
+		rest = rest[len(batch):]
+		update := map[string]any{"output": map[string]any{
+			"title":       output["title"],
+			"summary":     output["summary"],
+			"annotations": batch,
+		}}
+		if err := c.do(http.MethodPatch, fmt.Sprintf("/check-runs/%v", created.ID), update, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// githubCheck runs the checks and reports the findings as a check run of a commit, with annotations
+// on the translation files, from CI systems other than GitHub Actions as well.
+func githubCheck(args []string) {
+	flags := flag.NewFlagSet("github-check", flag.ExitOnError)
+	repo := flags.String("repo", os.Getenv("GITHUB_REPOSITORY"), "repository in the owner/name form")
+	sha := flags.String("sha", os.Getenv("GITHUB_SHA"), "commit the check run is reported on (default the HEAD of the repository)")
+	name := flags.String("name", "check-translations", "name of the check run")
+	token := flags.String("token", os.Getenv("GITHUB_TOKEN"), "GitHub App installation token allowed to write checks")
+	// An app can authenticate on its own, outside of GitHub Actions.
+	appID := flags.String("app-id", os.Getenv("GITHUB_APP_ID"),
+		"identifier of the GitHub App to authenticate as, instead of a token")
+	appKey := flags.String("app-key", os.Getenv("GITHUB_APP_PRIVATE_KEY_PATH"), "file of the private key of the GitHub App")
+	defaultAPI := os.Getenv("GITHUB_API_URL")
+	if defaultAPI == "" {
+		defaultAPI = "https://api.github.com"
+	}
+	api := flags.String("api", defaultAPI, "base URL of the GitHub API")
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v github-check [flags] <translation-root-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 || *repo == "" || *token == "" && (*appID == "" || *appKey == "") {
+		flags.Usage()
+		os.Exit(1)
+	}
+	rootDir := flags.Arg(0)
+	if err := checkRootDir(rootDir); err != nil {
+		log.Fatal(err)
+	}
+	if *sha == "" {
+		out, err := gitOutput("rev-parse", "HEAD")
+		if err != nil {
+			log.Fatalf("githubCheck: %v", err)
+		}
+		*sha = strings.TrimSpace(string(out))
+	}
+	if *appID != "" && *appKey != "" {
+		keyPEM, err := os.ReadFile(*appKey)
+		if err != nil {
+			log.Fatalf("githubCheck: %v", err)
+		}
+		if *token, err = githubAppToken(*api, *repo, *appID, keyPEM, time.Now()); err != nil {
+			log.Fatalf("githubCheck: %v", err)
+		}
+	}
+
+	config := loadConfig(*configPath)
+	paths, _, findings := checkTranslationRoot(rootDir, config)
+	reportText(os.Stderr, findings, config.Rules)
+
+	repoPaths, err := repoRelativePaths(paths)
+	if err != nil {
+		log.Fatalf("githubCheck: %v", err)
+	}
+	annotations, outside := checkAnnotations(findings, config.Rules, paths, repoPaths)
+	conclusion := "success"
+	failed := failsBudgets(findings, config.Rules, languageBudgets(config))
+	if failed {
+		conclusion = "failure"
+	}
+	client := newGithubClient(*api, *repo, *token)
+	if err := client.createCheckRun(*name, *sha, conclusion, checkRunOutput(findings, config.Rules, outside), annotations); err != nil {
+		log.Fatalf("githubCheck: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "check run %v of %v: %v, %v annotations\n", *name, *sha, conclusion, len(annotations))
+	if failed {
+		os.Exit(1)
+	}
+}
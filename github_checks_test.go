@@ -0,0 +1,115 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckAnnotations(t *testing.T) {
+	dir := t.TempDir()
+	svPath := filepath.Join(dir, "sv.json")
+	os.WriteFile(svPath, []byte("{\n  \"greeting\": \"Hej $namn$\",\n  \"bye\": \"Hej då\"\n}\n"), 0644)
+	findings := []Finding{
+		{Lang: "sv", Key: "greeting", Check: "variables", Message: "mismatch in variables: Hello $name$ ⇒ Hej $namn$"},
+		{Lang: "sv", Check: "load", Message: "broken"},
+		{Lang: "de", Check: "languages", Message: "missing translation file"},
+	}
+	annotations, outside := checkAnnotations(findings, ruleSeverities{}, map[string]string{"sv": svPath}, map[string]string{"sv": "locales/sv.json"})
+	want := []githubAnnotation{
+		{"locales/sv.json", 2, 2, "failure", "VAR001 variables: greeting", "mismatch in variables: Hello $name$ ⇒ Hej $namn$"},
+		{"locales/sv.json", 1, 1, "failure", "FILE002 load", "broken"},
+	}
+	if !reflect.DeepEqual(annotations, want) || outside != 1 {
+		t.Errorf("want %v and 1 outside, got %v and %v", want, annotations, outside)
+	}
+}
+
+func TestCheckAnnotationsWarnings(t *testing.T) {
+	dir := t.TempDir()
+	svPath := filepath.Join(dir, "sv.json")
+	os.WriteFile(svPath, []byte("{\n  \"greeting\": \"Hej $namn$\"\n}\n"), 0644)
+	findings := []Finding{{Lang: "sv", Key: "greeting", Check: "variables", Message: "mismatch in variables"}}
+	rules := newRuleSeverities(map[string]string{"variables": severityWarning})
+	annotations, _ := checkAnnotations(findings, rules, map[string]string{"sv": svPath}, map[string]string{"sv": "locales/sv.json"})
+	if len(annotations) != 1 || annotations[0].AnnotationLevel != "warning" {
+		t.Errorf("want a warning annotation, got %v", annotations)
+	}
+	if failsBudgets(findings, rules, nil) {
+		t.Error("want the warning not to fail the check run")
+	}
+}
+
+func TestCreateCheckRun(t *testing.T) {
+	var requests []string
+	var created map[string]any
+	updated := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		switch r.Method {
+		case http.MethodPost:
+			created = body
+			fmt.Fprint(w, `{"id": 7}`)
+		case http.MethodPatch:
+			updated += len(body["output"].(map[string]any)["annotations"].([]any))
+		}
+	}))
+	defer ts.Close()
+
+	annotations := make([]githubAnnotation, 70)
+	output := checkRunOutput([]Finding{{Lang: "sv", Key: "a", Check: "variables", Message: "one"}}, ruleSeverities{}, 0)
+	client := newGithubClient(ts.URL, "scrive/app", "token")
+	if err := client.createCheckRun("check-translations", "abc", "failure", output, annotations); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"POST /repos/scrive/app/check-runs", "PATCH /repos/scrive/app/check-runs/7"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("want the requests %v, got %v", want, requests)
+	}
+	if created["head_sha"] != "abc" || created["conclusion"] != "failure" || updated != 20 {
+		t.Errorf("unexpected check run %v, with %v more annotations", created, updated)
+	}
+	if summary := created["output"].(map[string]any)["summary"].(string); !strings.Contains(summary, "| sv | 1 |") {
+		t.Errorf("unexpected summary %q", summary)
+	}
+}
+
+func TestGithubAppToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Count(r.Header.Get("Authorization"), ".") != 2 {
+			http.Error(w, "no JWT", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/repos/scrive/app/installation":
+			fmt.Fprint(w, `{"id": 42}`)
+		case "/app/installations/42/access_tokens":
+			fmt.Fprint(w, `{"token": "ghs_installation"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	token, err := githubAppToken(ts.URL, "scrive/app", "123", keyPEM, time.Now())
+	if token != "ghs_installation" || err != nil {
+		t.Errorf("want the installation token, got %q, %v", token, err)
+	}
+}
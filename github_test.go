@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestChangedLines(t *testing.T) {
+	patch := "@@ -1,4 +1,5 @@\n {\n-  \"a\": \"old\",\n+  \"a\": \"new\",\n+  \"b\": \"added\",\n   \"c\": \"kept\"\n }\n@@ -10,2 +11,2 @@\n-  \"x\": \"old\"\n+  \"x\": \"new\"\n\\ No newline at end of file"
+	got := changedLines(patch)
+	want := []int{2, 3, 11}
+	if len(got) != len(want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	for _, line := range want {
+		if !got[line] {
+			t.Errorf("want line %v changed, got %v", line, got)
+		}
+	}
+}
+
+func TestReviewComments(t *testing.T) {
+	dir := t.TempDir()
+	svPath := filepath.Join(dir, "sv.json")
+	sv := "{\n  \"greeting\": \"Hej $namn$ <b>!</b>\",\n  \"old\": \"$x$\"\n}\n"
+	os.WriteFile(svPath, []byte(sv), 0644)
+
+	translations := map[string]Translation{
+		"en": {"greeting": "Hello $name$ <b>!</b>", "old": "$y$"},
+		"sv": {"greeting": "Hej $namn$ <b>!</b>", "old": "$x$"},
+	}
+	findings := checkTranslationVariables(translations)
+	paths := map[string]string{"sv": svPath}
+	repoPaths := map[string]string{"sv": "locales/sv.json"}
+	changed := map[string]map[int]bool{"locales/sv.json": {2: true}}
+
+	comments, outside := reviewComments(findings, ruleSeverities{}, translations, paths, repoPaths, changed)
+	if outside != 1 {
+		t.Errorf("want 1 finding outside the changed lines, got %v", outside)
+	}
+	if len(comments) != 1 {
+		t.Fatalf("want 1 comment, got %v", comments)
+	}
+	c := comments[0]
+	if c.Path != "locales/sv.json" || c.Line != 2 {
+		t.Errorf("want comment on locales/sv.json:2, got %v:%v", c.Path, c.Line)
+	}
+	if want := "```suggestion\n  \"greeting\": \"Hej $name$ <b>!</b>\",\n```"; !strings.Contains(c.Body, want) {
+		t.Errorf("want suggestion %q in %q", want, c.Body)
+	}
+
+	comments, _ = reviewComments(findings, newRuleSeverities(map[string]string{"variables": severityWarning}), translations, paths, repoPaths, changed)
+	if want := "**variables** (VAR001, warning):"; !strings.HasPrefix(comments[0].Body, want) {
+		t.Errorf("want %q to start with %q", comments[0].Body, want)
+	}
+
+	posted := []githubReviewComment{{Path: c.Path, Line: c.Line, Body: c.Body}, {Path: c.Path, Line: 3, Body: c.Body}}
+	if fresh := newReviewComments([]githubReviewComment{c}, posted); len(fresh) != 0 {
+		t.Errorf("want the comment already posted to be left out, got %v", fresh)
+	}
+	if fresh := newReviewComments([]githubReviewComment{c}, posted[1:]); len(fresh) != 1 {
+		t.Errorf("want the comment to be posted, got %v", fresh)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/scrive/check-translations/checktranslationsv1"
+)
+
+//go:generate protoc --go_out=. --go_opt=module=github.com/scrive/check-translations --go-grpc_out=. --go-grpc_opt=module=github.com/scrive/check-translations checker.proto
+
+// grpcSeverities are the severities of the rules as messages.
+var grpcSeverities = map[string]pb.Severity{
+	severityWarning: pb.Severity_SEVERITY_WARNING,
+	severityError:   pb.Severity_SEVERITY_ERROR,
+}
+
+// grpcChecker implements the Checker service of checker.proto with the checks of a server.
+type grpcChecker struct {
+	pb.UnimplementedCheckerServer
+	server *server
+}
+
+// newGRPCServer returns the gRPC server of the Checker service of s.
+func newGRPCServer(s *server) *grpc.Server {
+	g := grpc.NewServer(grpc.MaxRecvMsgSize(maxUploadSize))
+	pb.RegisterCheckerServer(g, &grpcChecker{server: s})
+	return g
+}
+
+// CheckCatalog checks a whole catalog and returns all the findings.
+func (c *grpcChecker) CheckCatalog(ctx context.Context, req *pb.CheckCatalogRequest) (*pb.CheckCatalogResponse, error) {
+	translations := make(map[string]Translation, len(req.GetTranslations()))
+	for lang, translation := range req.GetTranslations() {
+		translations[lang] = translation.GetStrings()
+	}
+	findings := runChecks(c.server.checks, translations)
+	return &pb.CheckCatalogResponse{Findings: grpcFindings(findings, c.server.rules, c.server.files.Arrays)}, nil
+}
+
+// CheckString checks single strings against the reference of the server.
+// Every request is answered as soon as it is read.
+func (c *grpcChecker) CheckString(stream pb.Checker_CheckStringServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		findings, err := c.server.checkStringRequest(stringRequest{Lang: req.GetLang(), Key: req.GetKey(), Value: req.GetValue()})
+		if errors.Is(err, errUnknownKey) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err := stream.Send(&pb.CheckStringResponse{Findings: grpcFindings(findings, c.server.rules, c.server.files.Arrays)}); err != nil {
+			return err
+		}
+	}
+}
+
+// grpcFindings returns findings as messages, with their rule, its severity in rules and their pointer,
+// as jsonFindings does.
+func grpcFindings(findings []Finding, rules ruleSeverities, arrays string) []*pb.Finding {
+	result := make([]*pb.Finding, len(findings))
+	for i, finding := range jsonFindings(findings, rules, arrays) {
+		result[i] = &pb.Finding{
+			Lang:     finding.Lang,
+			Key:      finding.Key,
+			Check:    finding.Check,
+			Message:  finding.Message,
+			Rule:     finding.Rule,
+			Severity: grpcSeverities[finding.Severity],
+			Pointer:  finding.Pointer,
+		}
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/scrive/check-translations/checktranslationsv1"
+)
+
+// newGRPCTestClient starts s and returns a client of its Checker service, over HTTP/2 without TLS.
+func newGRPCTestClient(t *testing.T, s *server) pb.CheckerClient {
+	t.Helper()
+	ts := httptest.NewServer(h2c.NewHandler(s.handler(), &http2.Server{}))
+	t.Cleanup(ts.Close)
+	conn, err := grpc.NewClient(strings.TrimPrefix(ts.URL, "http://"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewCheckerClient(conn)
+}
+
+func TestGRPCCheckCatalog(t *testing.T) {
+	client := newGRPCTestClient(t, &server{checks: builtinChecks, rules: newRuleSeverities(map[string]string{"html": severityWarning})})
+	resp, err := client.CheckCatalog(context.Background(), &pb.CheckCatalogRequest{Translations: map[string]*pb.Translation{
+		"en": {Strings: map[string]string{"a": "$x$ items", "b": "<b>bold</b>"}},
+		"sv": {Strings: map[string]string{"a": "$y$ saker", "b": "<b>fet"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range resp.GetFindings() {
+		got = append(got, strings.Join([]string{f.GetLang(), f.GetKey(), f.GetCheck(), f.GetRule(), f.GetSeverity().String(), f.GetPointer()}, " "))
+	}
+	want := []string{"sv a variables VAR001 SEVERITY_ERROR /a", "sv b html HTML001 SEVERITY_WARNING /b"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("want findings %q, got %q", want, got)
+	}
+}
+
+func TestGRPCCheckString(t *testing.T) {
+	s := &server{
+		reference: Translation{"greeting": "Hello $name$"},
+		checks:    builtinChecks,
+	}
+	client := newGRPCTestClient(t, s)
+	stream, err := client.CheckString(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every request is answered before the next one is sent.
+	var tests = []struct {
+		value string
+		want  int
+	}{
+		{"Hej $name$", 0},
+		{"Hej $namn$", 1},
+		{"<b>Hej $namn$", 3},
+	}
+	for _, test := range tests {
+		if err := stream.Send(&pb.CheckStringRequest{Lang: "sv", Key: "greeting", Value: test.value}); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.GetFindings()) != test.want {
+			t.Errorf("%q: want %v findings, got %v", test.value, test.want, resp.GetFindings())
+		}
+	}
+
+	stream.Send(&pb.CheckStringRequest{Lang: "sv", Key: "missing"})
+	stream.CloseSend()
+	if _, err := stream.Recv(); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown key: want %v, got %v", codes.NotFound, err)
+	}
+}
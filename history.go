@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// historyLang is the summary of the findings of a language in a run.
+type historyLang struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	// Coverage is the percentage of the english keys translated, unset for english and the files.
+	Coverage *float64 `json:"coverage,omitempty"`
+}
+
+// historyRecord is the summary of a run in the history file, by language, the findings of the
+// files being under the language "".
+type historyRecord struct {
+	Time      time.Time              `json:"time"`
+	Commit    string                 `json:"commit,omitempty"`
+	Languages map[string]historyLang `json:"languages"`
+}
+
+// summarizeRun returns the history record of a run on translations which found findings.
+// The coverage follows fallbacks, see translationCoverage.
+func summarizeRun(translations map[string]Translation, findings []Finding, rules ruleSeverities, fallbacks map[string]string,
+	now time.Time, commit string) historyRecord {
+	record := historyRecord{Time: now.UTC().Truncate(time.Second), Commit: commit, Languages: make(map[string]historyLang)}
+	// The files are always recorded, for the trend to show when their errors are fixed.
+	record.Languages[""] = historyLang{}
+	for lang := range translations {
+		record.Languages[lang] = historyLang{}
+	}
+	for _, finding := range findings {
+		summary := record.Languages[finding.Lang]
+		if rules.isWarning(finding) {
+			summary.Warnings++
+		} else {
+			summary.Errors++
+		}
+		record.Languages[finding.Lang] = summary
+	}
+	for lang, percent := range translationCoverage(translations, fallbacks) {
+		summary := record.Languages[lang]
+		percent := percent
+		summary.Coverage = &percent
+		record.Languages[lang] = summary
+	}
+	return record
+}
+
+// appendHistory appends record to the history file at path, a JSON Lines file of a record per run,
+// which only ever grows and merges easily.
+func appendHistory(path string, record historyRecord) error {
+	bs, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(bs, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// loadHistory loads the records of the history file at path, sorted by time.
+func loadHistory(path string) ([]historyRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var records []historyRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 16<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var record historyRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%v:%v: %w", path, line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	slices.SortStableFunc(records, func(a, b historyRecord) int { return a.Time.Compare(b.Time) })
+	return records, nil
+}
+
+// trendPeriods are the periods the trend can be shown by, and their names.
+var trendPeriods = map[string]func(t time.Time) string{
+	"run":     func(t time.Time) string { return t.Format("2006-01-02 15:04") },
+	"day":     func(t time.Time) string { return t.Format("2006-01-02") },
+	"week":    func(t time.Time) string { year, week := t.ISOWeek(); return fmt.Sprintf("%v-W%02d", year, week) },
+	"month":   func(t time.Time) string { return t.Format("2006-01") },
+	"quarter": func(t time.Time) string { return fmt.Sprintf("%v-Q%v", t.Year(), (int(t.Month())+2)/3) },
+}
+
+// trendPoint is the summary of a language at the end of a period.
+type trendPoint struct {
+	period string
+	historyLang
+}
+
+// languageTrends returns the summaries of the languages of records at the end of every period by
+// which they are named, by language. The records are sorted by time.
+func languageTrends(records []historyRecord, period func(time.Time) string) map[string][]trendPoint {
+	trends := make(map[string][]trendPoint)
+	for _, record := range records {
+		name := period(record.Time)
+		for lang, summary := range record.Languages {
+			points := trends[lang]
+			// The last run of a period is the one it ends with.
+			if len(points) > 0 && points[len(points)-1].period == name {
+				points = points[:len(points)-1]
+			}
+			trends[lang] = append(points, trendPoint{name, summary})
+		}
+	}
+	return trends
+}
+
+// reportTrend writes the trends of the languages, with the changes from their first period to their last.
+func reportTrend(w io.Writer, trends map[string][]trendPoint) {
+	coverage := func(summary historyLang) string {
+		if summary.Coverage == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f%%", *summary.Coverage)
+	}
+	for _, lang := range sortedKeys(trends) {
+		name := lang
+		if lang == "" {
+			name = "files"
+		}
+		fmt.Fprintf(w, "[%v]\n", name)
+		points := trends[lang]
+		for _, point := range points {
+			fmt.Fprintf(w, "    %-16v %6v errors %6v warnings %8v\n", point.period, point.Errors, point.Warnings, coverage(point.historyLang))
+		}
+		if len(points) < 2 {
+			continue
+		}
+		first, last := points[0], points[len(points)-1]
+		change := fmt.Sprintf("%+d errors, %+d warnings", last.Errors-first.Errors, last.Warnings-first.Warnings)
+		if first.Coverage != nil && last.Coverage != nil {
+			change += fmt.Sprintf(", %+.1f%% coverage", *last.Coverage-*first.Coverage)
+		}
+		fmt.Fprintf(w, "    %v → %v: %v\n", first.period, last.period, change)
+	}
+}
+
+// trend shows the history of the errors, warnings and coverage of every language recorded by -history.
+func trend(args []string) {
+	flags := flag.NewFlagSet("trend", flag.ExitOnError)
+	by := flags.String("by", "month", "period of the trend: run, day, week, month or quarter")
+	since := flags.String("since", "", "only show the runs since this date, like 2026-01-01")
+	var langs listFlag
+	flags.Var(&langs, "langs", "comma separated languages to show, can be repeated (default all)")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v trend [flags] <history-file>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	period, ok := trendPeriods[*by]
+	if flags.NArg() < 1 || !ok {
+		flags.Usage()
+		os.Exit(1)
+	}
+	records, err := loadHistory(flags.Arg(0))
+	if err != nil {
+		log.Fatalf("trend: %v", err)
+	}
+	if *since != "" {
+		start, err := time.Parse("2006-01-02", *since)
+		if err != nil {
+			log.Fatalf("trend: -since: %v", err)
+		}
+		records = slices.DeleteFunc(records, func(r historyRecord) bool { return r.Time.Before(start) })
+	}
+	trends := languageTrends(records, period)
+	if len(langs) > 0 {
+		for lang := range trends {
+			if !slices.Contains(langs, lang) {
+				delete(trends, lang)
+			}
+		}
+	}
+	reportTrend(os.Stdout, trends)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	translations := map[string]Translation{
+		"en": {"a": "A", "b": "B"},
+		"sv": {"a": "A"},
+		"fi": {"a": "A", "b": "B"},
+	}
+	findings := []Finding{
+		{Lang: "sv", Key: "a", Check: "variables", Message: "mismatch"},
+		{Lang: "sv", Key: "a", Check: "profanity", Message: "profanity"},
+		{Check: "files", Message: "unreadable file"},
+	}
+	times := []time.Time{
+		time.Date(2026, 4, 2, 10, 0, 0, 0, time.UTC),
+		time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 20, 10, 0, 0, 0, time.UTC),
+	}
+	// The records are appended out of order, as merged branches would.
+	if err := appendHistory(path, summarizeRun(translations, nil, ruleSeverities{}, nil, times[0], "c")); err != nil {
+		t.Fatal(err)
+	}
+	if err := appendHistory(path, summarizeRun(translations, findings, ruleSeverities{}, nil, times[1], "a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := appendHistory(path, summarizeRun(translations, findings[:1], ruleSeverities{}, nil, times[2], "b")); err != nil {
+		t.Fatal(err)
+	}
+	records, err := loadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var commits []string
+	for _, record := range records {
+		commits = append(commits, record.Commit)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(commits, want) {
+		t.Errorf("want records of %v, got %v", want, commits)
+	}
+	half, full := 50.0, 100.0
+	if want := (historyLang{Errors: 1, Warnings: 1, Coverage: &half}); !reflect.DeepEqual(records[0].Languages["sv"], want) {
+		t.Errorf("sv: want %+v, got %+v", want, records[0].Languages["sv"])
+	}
+	if want := (historyLang{Coverage: &full}); !reflect.DeepEqual(records[0].Languages["fi"], want) {
+		t.Errorf("fi: want %+v, got %+v", want, records[0].Languages["fi"])
+	}
+
+	var buf bytes.Buffer
+	reportTrend(&buf, languageTrends(records, trendPeriods["quarter"]))
+	want := "[files]\n" +
+		"    2026-Q1               0 errors      0 warnings        -\n" +
+		"    2026-Q2               0 errors      0 warnings        -\n" +
+		"    2026-Q1 → 2026-Q2: +0 errors, +0 warnings\n" +
+		"[en]\n" +
+		"    2026-Q1               0 errors      0 warnings        -\n" +
+		"    2026-Q2               0 errors      0 warnings        -\n" +
+		"    2026-Q1 → 2026-Q2: +0 errors, +0 warnings\n" +
+		"[fi]\n" +
+		"    2026-Q1               0 errors      0 warnings   100.0%\n" +
+		"    2026-Q2               0 errors      0 warnings   100.0%\n" +
+		"    2026-Q1 → 2026-Q2: +0 errors, +0 warnings, +0.0% coverage\n" +
+		"[sv]\n" +
+		"    2026-Q1               1 errors      0 warnings    50.0%\n" +
+		"    2026-Q2               0 errors      0 warnings    50.0%\n" +
+		"    2026-Q1 → 2026-Q2: -1 errors, +0 warnings, +0.0% coverage\n"
+	if buf.String() != want {
+		t.Errorf("want:\n%v\ngot:\n%v", want, buf.String())
+	}
+}
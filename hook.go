@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// preCommitConfigFile is the configuration file of the pre-commit framework, https://pre-commit.com
+const preCommitConfigFile = ".pre-commit-config.yaml"
+
+const hookTemplate = `#!/bin/sh
+# Installed by check-translations install-hook.
+# Checks the staged translation files before every commit.
+exec %v -staged %v
+`
+
+// preCommitConfigTemplate runs the hook on the commits changing files under the translation root only:
+// -staged tells the translation files with the configured pattern, which the framework can't.
+const preCommitConfigTemplate = `repos:
+  - repo: local
+    hooks:
+      - id: check-translations
+        name: check translations
+        entry: check-translations -staged
+        args: [%q]
+        language: system
+        pass_filenames: false
+%v`
+
+// installHook writes a git pre-commit hook, or a pre-commit framework configuration,
+// running the checks on the staged translation files.
+func installHook(args []string) {
+	flags := flag.NewFlagSet("install-hook", flag.ExitOnError)
+	force := flags.Bool("force", false, "overwrite an existing pre-commit hook")
+	framework := flags.Bool("pre-commit-framework", false,
+		fmt.Sprintf("write a %v for the pre-commit framework instead of a git hook", preCommitConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v install-hook [flags] <translation-root-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	rootDir := flags.Arg(0)
+	if err := checkRootDir(rootDir); err != nil {
+		log.Fatal(err)
+	}
+
+	if *framework {
+		config := fmt.Sprintf(preCommitConfigTemplate, rootDir, preCommitFiles(rootDir))
+		if _, err := os.Stat(preCommitConfigFile); err == nil {
+			log.Fatalf("installHook: %v already exists, add the following to it:\n%v", preCommitConfigFile, config)
+		}
+		if err := os.WriteFile(preCommitConfigFile, []byte(config), 0644); err != nil {
+			log.Fatalf("installHook: %v", err)
+		}
+		fmt.Printf("wrote %v, run `pre-commit install` to enable it\n", preCommitConfigFile)
+		return
+	}
+
+	hooksDir, err := gitOutput("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		log.Fatalf("installHook: %v", err)
+	}
+	hookPath := filepath.Join(strings.TrimSpace(string(hooksDir)), "pre-commit")
+	if _, err := os.Stat(hookPath); err == nil && !*force {
+		log.Fatalf("installHook: %v already exists, use -force to overwrite it", hookPath)
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		log.Fatalf("installHook: %v", err)
+	}
+
+	hook := fmt.Sprintf(hookTemplate, shellQuote(executable), shellQuote(rootDir))
+	if err := os.MkdirAll(filepath.Dir(hookPath), 0755); err != nil {
+		log.Fatalf("installHook: %v", err)
+	}
+	if err := os.WriteFile(hookPath, []byte(hook), 0755); err != nil {
+		log.Fatalf("installHook: %v", err)
+	}
+	// WriteFile doesn't change the mode of existing files.
+	if err := os.Chmod(hookPath, 0755); err != nil {
+		log.Fatalf("installHook: %v", err)
+	}
+	fmt.Printf("installed %v\n", hookPath)
+}
+
+// preCommitFiles returns the files setting of the pre-commit framework matching the files under rootDir,
+// relative to the root of the repository, or "" for the whole repository.
+func preCommitFiles(rootDir string) string {
+	dir := filepath.ToSlash(filepath.Clean(rootDir))
+	if dir == "." || filepath.IsAbs(rootDir) || strings.HasPrefix(dir, "../") {
+		return ""
+	}
+	// The expression is quoted for YAML, in which a single quote is doubled.
+	return fmt.Sprintf("        files: '^%v/'\n", strings.ReplaceAll(regexp.QuoteMeta(dir), "'", "''"))
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestPreCommitFiles(t *testing.T) {
+	for _, test := range []struct {
+		rootDir string
+		want    string
+	}{
+		{"./localizations/", "        files: '^localizations/'\n"},
+		{"web/i18n.v2", "        files: '^web/i18n\\.v2/'\n"},
+		{".", ""},
+		{"/srv/locales", ""},
+		{"../locales", ""},
+	} {
+		if got := preCommitFiles(test.rootDir); got != test.want {
+			t.Errorf("%v: want %q, got %q", test.rootDir, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,604 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// HTMLConfig enables the optional HTML checks.
+type HTMLConfig struct {
+	// ContentModel checks the nesting rules of the HTML tags, on top of them being balanced.
+	ContentModel bool `json:"contentModel"`
+}
+
+// blockElements can't be put inside the phrasingElements.
+var blockElements = []string{
+	"address", "article", "aside", "blockquote", "dd", "div", "dl", "dt", "fieldset", "figure", "footer",
+	"form", "h1", "h2", "h3", "h4", "h5", "h6", "header", "hr", "li", "main", "nav", "ol", "p", "pre",
+	"section", "table", "ul",
+}
+
+// phrasingElements can only contain phrasing content, i.e. no blockElements.
+var phrasingElements = []string{
+	"a", "abbr", "b", "button", "cite", "code", "em", "i", "label", "p", "q", "s", "small", "span",
+	"strong", "sub", "sup", "u",
+}
+
+// voidElements have no content nor end tag.
+var voidElements = []string{"area", "br", "col", "embed", "hr", "img", "input", "link", "meta", "source", "wbr"}
+
+// checkHTMLContentModel checks whether the HTML tags in input follow the nesting rules
+// which matter for rendering: no block elements inside phrasing ones like <span> or <a>, and no nested <a>.
+// An empty list is returned in case of success, otherwise a list of errors.
+func checkHTMLContentModel(input string) (errs []string) {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	var open []string
+	for {
+		tt := tokenizer.Next()
+		switch tt {
+		case html.ErrorToken:
+			return errs
+		case html.StartTagToken, html.SelfClosingTagToken:
+			nameb, _ := tokenizer.TagName()
+			name := string(nameb)
+			if err := nestingError(open, name); err != "" {
+				errs = append(errs, err)
+			}
+			if tt == html.StartTagToken && !slices.Contains(voidElements, name) {
+				open = append(open, name)
+			}
+		case html.EndTagToken:
+			nameb, _ := tokenizer.TagName()
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == string(nameb) {
+					open = open[:i]
+					break
+				}
+			}
+		}
+	}
+}
+
+// nestingError returns the error of opening the element name inside the open ones, if any.
+func nestingError(open []string, name string) string {
+	for _, parent := range open {
+		switch {
+		case name == "a" && parent == "a":
+			return "nested link: <a> inside <a>"
+		case slices.Contains(blockElements, name) && slices.Contains(phrasingElements, parent):
+			return fmt.Sprintf("block element inside a phrasing element: <%v> inside <%v>", name, parent)
+		}
+	}
+	return ""
+}
+
+// checkTranslationHTMLContentModel runs checkHTMLContentModel on all the strings of all the translations.
+func checkTranslationHTMLContentModel(translations map[string]Translation) (result []Finding) {
+	check := memoize(checkHTMLContentModel)
+	for lang, translation := range translations {
+		for key, translatedString := range translation {
+			for _, err := range check(translatedString) {
+				result = append(result, Finding{
+					Lang:    lang,
+					Key:     key,
+					Check:   "html-content-model",
+					Message: fmt.Sprintf("%v: %v", err, translatedString),
+				})
+			}
+		}
+	}
+	return result
+}
+
+// htmlTags returns the sorted names of the start tags of input, e.g. [b br b].
+func htmlTags(input string) (tags []string) {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			slices.Sort(tags)
+			return tags
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			tags = append(tags, string(name))
+		}
+	}
+}
+
+// sortedDifference returns the elements of the sorted en missing from the sorted xs,
+// and the ones of xs not in en, counting duplicates.
+func sortedDifference(en, xs []string) (missing, extra []string) {
+	i, j := 0, 0
+	for i < len(en) || j < len(xs) {
+		switch {
+		case j == len(xs) || i < len(en) && en[i] < xs[j]:
+			missing = append(missing, en[i])
+			i++
+		case i == len(en) || xs[j] < en[i]:
+			extra = append(extra, xs[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return missing, extra
+}
+
+// describeDifference describes the missing and extra elements, formatted with format.
+func describeDifference(missing, extra []string, format string) string {
+	var parts []string
+	for _, group := range []struct {
+		name     string
+		elements []string
+	}{{"missing", missing}, {"extra", extra}} {
+		if len(group.elements) == 0 {
+			continue
+		}
+		formatted := make([]string, len(group.elements))
+		for i, element := range group.elements {
+			formatted[i] = fmt.Sprintf(format, element)
+		}
+		parts = append(parts, group.name+" "+strings.Join(formatted, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// tagDifference describes the tags missing from and added to the sorted tags of the english source.
+func tagDifference(enTags, tags []string) string {
+	missing, extra := sortedDifference(enTags, tags)
+	return describeDifference(missing, extra, "<%v>")
+}
+
+// checkTranslationHTMLTags checks whether the translations have the same HTML tags as the english source,
+// as many times each.
+func checkTranslationHTMLTags(translations map[string]Translation) (result []Finding) {
+	tagsOf := memoize(htmlTags)
+	for enKey, enString := range translations["en"] {
+		enTags := tagsOf(enString)
+		for lang, translation := range translations {
+			if lang == "en" || translation[enKey] == "" {
+				continue
+			}
+			tags := tagsOf(translation[enKey])
+			if slices.Equal(enTags, tags) {
+				continue
+			}
+			result = append(result, Finding{
+				Lang:  lang,
+				Key:   enKey,
+				Check: "html-tags",
+				Message: fmt.Sprintf("mismatch in HTML tags (%v): %v ⇒ %v",
+					tagDifference(enTags, tags), enString, translation[enKey]),
+			})
+		}
+	}
+	return result
+}
+
+// translatableAttributes hold text for the readers, which is translated unlike the other attributes.
+var translatableAttributes = []string{
+	"alt", "aria-description", "aria-label", "aria-placeholder", "aria-roledescription", "placeholder", "title",
+}
+
+// htmlAttributes returns the sorted attributes of the tags of input which must not be translated,
+// in the form a href="/terms".
+func htmlAttributes(input string) (attrs []string) {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			slices.Sort(attrs)
+			return attrs
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				if !slices.Contains(translatableAttributes, string(key)) {
+					attrs = append(attrs, fmt.Sprintf("%s %s=%q", name, key, value))
+				}
+			}
+		}
+	}
+}
+
+// checkTranslationHTMLAttributes checks whether the HTML tags of the translations have the same
+// attributes as the ones of the english source, like the href of links, apart from the translatable ones.
+// The layouts of right to left translations may be mirrored.
+func checkTranslationHTMLAttributes(translations map[string]Translation) (result []Finding) {
+	attributesOf := memoize(htmlAttributes)
+	for enKey, enString := range translations["en"] {
+		enAttrs := attributesOf(enString)
+		for lang, translation := range translations {
+			if lang == "en" || translation[enKey] == "" {
+				continue
+			}
+			attrs := attributesOf(translation[enKey])
+			missing, extra := sortedDifference(enAttrs, attrs)
+			if isRTL(lang) && slices.Equal(unmirrored(enAttrs), unmirrored(attrs)) {
+				continue
+			}
+			if len(missing)+len(extra) == 0 {
+				continue
+			}
+			result = append(result, Finding{
+				Lang:  lang,
+				Key:   enKey,
+				Check: "html-attributes",
+				Message: fmt.Sprintf("mismatch in HTML attributes (%v): %v ⇒ %v",
+					describeDifference(missing, extra, "%v"), enString, translation[enKey]),
+			})
+		}
+	}
+	return result
+}
+
+// htmlComments returns the comments of input, like <!-- TODO check with legal -->.
+func htmlComments(input string) (comments []string) {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return comments
+		case html.CommentToken:
+			comments = append(comments, string(tokenizer.Raw()))
+		}
+	}
+}
+
+// checkHTMLComments reports the HTML comments of all the strings, which are notes left by
+// the authors or the translators and must not be shipped.
+func checkHTMLComments(translations map[string]Translation) (result []Finding) {
+	commentsOf := memoize(htmlComments)
+	for lang, translation := range translations {
+		for key, translated := range translation {
+			for _, comment := range commentsOf(translated) {
+				result = append(result, Finding{
+					Lang:    lang,
+					Key:     key,
+					Check:   "html-comments",
+					Message: fmt.Sprintf("HTML comment %v: %v", comment, translated),
+				})
+			}
+		}
+	}
+	return result
+}
+
+// forbiddenElements carry executable or style content, or change the document,
+// which a rendered translation must never do.
+var forbiddenElements = []string{
+	"applet", "base", "embed", "frame", "frameset", "iframe", "link", "meta", "noscript", "object", "script", "style",
+}
+
+// forbiddenTags returns the forbidden elements of input, in order.
+func forbiddenTags(input string) (tags []string) {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return tags
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			if slices.Contains(forbiddenElements, string(name)) {
+				tags = append(tags, string(name))
+			}
+		}
+	}
+}
+
+// checkForbiddenElements reports the strings with forbidden elements, like <script> or <iframe>,
+// whether they are balanced or not.
+func checkForbiddenElements(translations map[string]Translation) (result []Finding) {
+	tagsOf := memoize(forbiddenTags)
+	for lang, translation := range translations {
+		for key, translated := range translation {
+			for _, tag := range tagsOf(translated) {
+				result = append(result, Finding{
+					Lang:    lang,
+					Key:     key,
+					Check:   "html-elements",
+					Message: fmt.Sprintf("forbidden element <%v>: %v", tag, translated),
+				})
+			}
+		}
+	}
+	return result
+}
+
+// imageAlts returns the alt attributes of the images of input, in order,
+// with a nil element for the images without one.
+func imageAlts(input string) (alts []*string) {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return alts
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			if string(name) != "img" {
+				continue
+			}
+			var alt *string
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				if string(key) == "alt" {
+					s := string(value)
+					alt = &s
+				}
+			}
+			alts = append(alts, alt)
+		}
+	}
+}
+
+// checkImageAlts reports the images without an alt text. The alt texts copied from the english
+// source are reported by the translatable-attributes check.
+func checkImageAlts(translations map[string]Translation) (result []Finding) {
+	altsOf := memoize(imageAlts)
+	for lang, translation := range translations {
+		for key, translated := range translation {
+			for _, alt := range altsOf(translated) {
+				if alt != nil && strings.TrimSpace(*alt) != "" {
+					continue
+				}
+				result = append(result, Finding{
+					Lang:    lang,
+					Key:     key,
+					Check:   "html-alt",
+					Message: fmt.Sprintf("image without alt text: %v", translated),
+				})
+			}
+		}
+	}
+	return result
+}
+
+// tagAttribute is an attribute of a tag, like the aria-label of a button.
+type tagAttribute struct {
+	tag, name, value string
+}
+
+// tagAttributes returns the attributes of the tags of input selected by their name, in order.
+func tagAttributes(input string, selected func(name string) bool) (attrs []tagAttribute) {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return attrs
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				if selected(string(key)) {
+					attrs = append(attrs, tagAttribute{string(name), string(key), string(value)})
+				}
+			}
+		}
+	}
+}
+
+// checkAttributesKept returns a check reporting the attributes selected by their name which the english
+// source has and the translations dropped, as in <button aria-label="Close"> translated to <button>,
+// and the translatable ones copied from the english source without translating them.
+// The findings are reported as check and the attributes described as kind, like "ARIA attributes".
+func checkAttributesKept(name, kind string, selected func(name string) bool) checkFunc {
+	return func(translations map[string]Translation) (result []Finding) {
+		attrsOf := memoize(func(s string) []tagAttribute { return tagAttributes(s, selected) })
+		for enKey, enString := range translations["en"] {
+			enAttrs := attrsOf(enString)
+			if len(enAttrs) == 0 {
+				continue
+			}
+			for lang, translation := range translations {
+				translated := translation[enKey]
+				if lang == "en" || translated == "" {
+					continue
+				}
+				attrs := attrsOf(translated)
+				report := func(format string, args ...any) {
+					result = append(result, Finding{
+						Lang:    lang,
+						Key:     enKey,
+						Check:   name,
+						Message: fmt.Sprintf("%v: %v ⇒ %v", fmt.Sprintf(format, args...), enString, translated),
+					})
+				}
+
+				var enNames, names []string
+				for _, attr := range enAttrs {
+					enNames = append(enNames, attr.tag+" "+attr.name)
+				}
+				for _, attr := range attrs {
+					names = append(names, attr.tag+" "+attr.name)
+				}
+				slices.Sort(enNames)
+				slices.Sort(names)
+				if missing, _ := sortedDifference(enNames, names); len(missing) > 0 {
+					report("dropped %v (%v)", kind, strings.Join(missing, ", "))
+				}
+
+				// An untranslated string is untranslated as a whole.
+				if translated == enString {
+					continue
+				}
+				for _, attr := range attrs {
+					if slices.Contains(translatableAttributes, attr.name) && strings.TrimSpace(attr.value) != "" &&
+						slices.Contains(enAttrs, attr) {
+						report("%v of <%v> not translated (%q)", attr.name, attr.tag, attr.value)
+					}
+				}
+			}
+		}
+		return result
+	}
+}
+
+// isARIAAttribute reports whether name is the one of an ARIA attribute, like aria-label.
+func isARIAAttribute(name string) bool {
+	return strings.HasPrefix(name, "aria-")
+}
+
+// isTextAttribute reports whether name is the one of an attribute holding text for the readers
+// which isn't an ARIA attribute, like title.
+func isTextAttribute(name string) bool {
+	return slices.Contains(translatableAttributes, name) && !isARIAAttribute(name)
+}
+
+// checkARIA reports the ARIA attributes of the english source dropped from the translations,
+// and the translatable ones, like aria-label, left untranslated. The values of the other ones,
+// like the ids of aria-describedby, are compared by the html-attributes check.
+var checkARIA = checkAttributesKept("aria", "ARIA attributes", isARIAAttribute)
+
+// checkTextAttributes reports the attributes holding text for the readers, like title, alt
+// or placeholder, dropped from the translations or left untranslated.
+var checkTextAttributes = checkAttributesKept("translatable-attributes", "translatable attributes", isTextAttribute)
+
+// markupPlaceholderRx matches the $variable$ and {{variable}} placeholders.
+var markupPlaceholderRx = regexp.MustCompile(`\$[^$\s]+\$|\{\{\s*[^{}]+?\s*\}\}`)
+
+// placeholderPlaces returns where every placeholder of input is: "text" in the text content,
+// or the name of the attribute it's in, like "href". The places of a placeholder are sorted.
+func placeholderPlaces(input string) map[string][]string {
+	places := make(map[string][]string)
+	add := func(s, place string) {
+		for _, placeholder := range markupPlaceholderRx.FindAllString(s, -1) {
+			placeholder = strings.Join(strings.Fields(placeholder), "")
+			places[placeholder] = append(places[placeholder], place)
+		}
+	}
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			for _, p := range places {
+				slices.Sort(p)
+			}
+			return places
+		case html.TextToken:
+			add(string(tokenizer.Text()), "text")
+		case html.StartTagToken, html.SelfClosingTagToken:
+			_, hasAttr := tokenizer.TagName()
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				add(string(value), string(key))
+			}
+		}
+	}
+}
+
+// describePlaces describes the places of a placeholder, e.g. "the href attribute and the text".
+func describePlaces(places []string) string {
+	var parts []string
+	for _, place := range places {
+		if place == "text" {
+			parts = append(parts, "the text")
+		} else {
+			parts = append(parts, fmt.Sprintf("the %v attribute", place))
+		}
+	}
+	return strings.Join(parts, " and ")
+}
+
+// checkAttributePlaceholders reports the placeholders which are inside an attribute, like the href
+// of a link, in the english source but in the text in a translation, or the other way around.
+// The renderer escapes them differently, which breaks the links.
+func checkAttributePlaceholders(translations map[string]Translation) (result []Finding) {
+	placesOf := memoize(placeholderPlaces)
+	for enKey, enString := range translations["en"] {
+		enPlaces := placesOf(enString)
+		if len(enPlaces) == 0 {
+			continue
+		}
+		for lang, translation := range translations {
+			translated := translation[enKey]
+			if lang == "en" || translated == "" {
+				continue
+			}
+			places := placesOf(translated)
+			for _, placeholder := range sortedKeys(enPlaces) {
+				// Missing placeholders are reported by the variables check.
+				if len(places[placeholder]) == 0 || slices.Equal(enPlaces[placeholder], places[placeholder]) {
+					continue
+				}
+				result = append(result, Finding{
+					Lang:  lang,
+					Key:   enKey,
+					Check: "attribute-variables",
+					Message: fmt.Sprintf("%v is in %v in english but in %v: %v ⇒ %v", placeholder,
+						describePlaces(enPlaces[placeholder]), describePlaces(places[placeholder]), enString, translated),
+				})
+			}
+		}
+	}
+	return result
+}
+
+// entityRx matches the named and numeric HTML character references, and textTagRx the tags around the text.
+var (
+	entityRx  = regexp.MustCompile(`&(?:[A-Za-z][A-Za-z0-9]*|#[0-9]+|#[xX][0-9A-Fa-f]+);`)
+	textTagRx = regexp.MustCompile(`</?[A-Za-z][^>]*>`)
+)
+
+// textEntities are the characters written as entities in a text, mapped to their first entity,
+// and the text without them, where the other characters are written raw.
+type textEntities struct {
+	entities map[string]string
+	raw      string
+}
+
+// findTextEntities returns the entities of the text of input, leaving out its tags.
+func findTextEntities(input string) textEntities {
+	text := textTagRx.ReplaceAllString(input, " ")
+	entities := make(map[string]string)
+	for _, entity := range entityRx.FindAllString(text, -1) {
+		if char := html.UnescapeString(entity); char != entity && entities[char] == "" {
+			entities[char] = entity
+		}
+	}
+	return textEntities{entities, entityRx.ReplaceAllString(text, " ")}
+}
+
+// checkHTMLEntities reports the characters written as an entity in the english source, like &amp;
+// or &nbsp;, but raw in a translation, or the other way around. The two rendering paths don't
+// treat them the same, and one of them shows the entity as text.
+func checkHTMLEntities(translations map[string]Translation) (result []Finding) {
+	entitiesOf := memoize(findTextEntities)
+	for enKey, enString := range translations["en"] {
+		en := entitiesOf(enString)
+		for lang, translation := range translations {
+			translated := translation[enKey]
+			if lang == "en" || translated == "" {
+				continue
+			}
+			tr := entitiesOf(translated)
+			report := func(format string, args ...any) {
+				result = append(result, Finding{
+					Lang:    lang,
+					Key:     enKey,
+					Check:   "html-entities",
+					Message: fmt.Sprintf(format+": %v ⇒ %v", append(args, enString, translated)...),
+				})
+			}
+			for _, char := range sortedKeys(en.entities) {
+				if _, ok := tr.entities[char]; !ok && strings.Contains(tr.raw, char) {
+					report("%v in english but a raw %q in the translation", en.entities[char], char)
+				}
+			}
+			for _, char := range sortedKeys(tr.entities) {
+				if _, ok := en.entities[char]; !ok && strings.Contains(en.raw, char) {
+					report("raw %q in english but %v in the translation", char, tr.entities[char])
+				}
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCheckHTMLContentModel(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"<b>Bold</b> and <a href=\"/x\">link</a>", nil},
+		{"<div><span>Fine</span></div>", nil},
+		{"<span>Line<br>break</span>", nil},
+		{"<span><div>Block</div></span>", []string{"block element inside a phrasing element: <div> inside <span>"}},
+		{"<a href=\"/x\"><b><p>Block</p></b></a>", []string{"block element inside a phrasing element: <p> inside <a>"}},
+		{"<a href=\"/x\">Outer <a href=\"/y\">inner</a></a>", []string{"nested link: <a> inside <a>"}},
+		{"<span>Closed</span><div>Block</div>", nil},
+	}
+	for _, test := range tests {
+		if got := checkHTMLContentModel(test.input); !slices.Equal(got, test.want) {
+			t.Errorf("%q: want %q, got %q", test.input, test.want, got)
+		}
+	}
+}
+
+func TestCheckTranslationHTMLTags(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {
+			"a": "Read the <strong>terms</strong> and <a href=\"/x\">policy</a>",
+			"b": "Line<br>break",
+			"c": "Plain",
+		},
+		"sv": {
+			"a": "Läs <a href=\"/x\">villkoren</a> och <strong>policyn</strong>",
+			"b": "Rad<br>brytning<br>",
+			"c": "<em>Enkel</em>",
+		},
+		"de": {
+			"a": "Lies die Bedingungen und <a href=\"/x\">Richtlinie</a>",
+		},
+	}
+	got := map[string]string{}
+	for _, finding := range checkTranslationHTMLTags(translations) {
+		got[finding.Lang+":"+finding.Key] = tagDifference(htmlTags(translations["en"][finding.Key]),
+			htmlTags(translations[finding.Lang][finding.Key]))
+	}
+	want := map[string]string{
+		"sv:b": "extra <br>",
+		"sv:c": "extra <em>",
+		"de:a": "missing <strong>",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestCheckTranslationHTMLAttributes(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {
+			"a": `Read the <a href="/terms" class="link" title="Terms">terms</a>`,
+			"b": `<span data-id="1">One</span> and <span data-id="2">two</span>`,
+		},
+		"sv": {
+			"a": `Läs <a href="/villkor" class="link" title="Villkor">villkoren</a>`,
+			"b": `<span data-id="2">Två</span> och <span data-id="1">ett</span>`,
+		},
+		"de": {
+			"a": `Lies die <a class="link" href="/terms" title="Bedingungen" target="_blank">Bedingungen</a>`,
+		},
+	}
+	got := map[string]string{}
+	for _, finding := range checkTranslationHTMLAttributes(translations) {
+		got[finding.Lang+":"+finding.Key] = finding.Message
+	}
+	want := map[string]string{
+		"sv:a": `mismatch in HTML attributes (missing a href="/terms"; extra a href="/villkor"): ` +
+			translations["en"]["a"] + " ⇒ " + translations["sv"]["a"],
+		"de:a": `mismatch in HTML attributes (extra a target="_blank"): ` +
+			translations["en"]["a"] + " ⇒ " + translations["de"]["a"],
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestHTMLComments(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {"save": "Save", "terms": "Accept the <a href=\"/terms\">terms</a>"},
+		"sv": {"save": "Spara<!-- kolla med juristerna -->", "terms": "Godkänn <a href=\"/terms\">villkoren</a>"},
+		"de": {"save": "Speichern <!-- unclosed"},
+	}
+	want := []Finding{
+		{Lang: "de", Key: "save", Check: "html-comments", Message: "HTML comment <!-- unclosed: Speichern <!-- unclosed"},
+		{Lang: "sv", Key: "save", Check: "html-comments", Message: "HTML comment <!-- kolla med juristerna -->: Spara<!-- kolla med juristerna -->"},
+	}
+	findings := checkHTMLComments(translations)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+}
+
+func TestForbiddenElements(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {"save": "<b>Save</b>"},
+		"sv": {"save": "<b>Spara</b><script>alert(1)</script>", "video": "<iframe src=\"https://example.com\"/>", "styled": "<STYLE>b{}"},
+	}
+	want := []Finding{
+		{Lang: "sv", Key: "save", Check: "html-elements", Message: "forbidden element <script>: <b>Spara</b><script>alert(1)</script>"},
+		{Lang: "sv", Key: "styled", Check: "html-elements", Message: "forbidden element <style>: <STYLE>b{}"},
+		{Lang: "sv", Key: "video", Check: "html-elements", Message: "forbidden element <iframe>: <iframe src=\"https://example.com\"/>"},
+	}
+	findings := checkForbiddenElements(translations)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+}
+
+func TestImageAlts(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {
+			"logo":   `<img src="/logo.png" alt="Company logo"> Welcome`,
+			"icon":   `<img src="/icon.png">`,
+			"banner": `<img src="/a.png" alt="Sunrise"><img src="/b.png" alt="Sunset">`,
+		},
+		"sv": {
+			"logo":   `<img src="/logo.png" alt="Company logo"> Välkommen`,
+			"icon":   `<img src="/icon.png" alt="Ikon">`,
+			"banner": `<img src="/a.png" alt="Soluppgång"><img src="/b.png" alt=" ">`,
+		},
+		"de": {"logo": `<img src="/logo.png" alt="Company logo"> Welcome`},
+	}
+	want := []Finding{
+		{Lang: "en", Key: "icon", Check: "html-alt", Message: `image without alt text: <img src="/icon.png">`},
+		{Lang: "sv", Key: "banner", Check: "html-alt", Message: `image without alt text: <img src="/a.png" alt="Soluppgång"><img src="/b.png" alt=" ">`},
+	}
+	findings := checkImageAlts(translations)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+}
+
+func TestARIA(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {
+			"close":  `<button aria-label="Close dialog">×</button>`,
+			"help":   `<input aria-describedby="help-text"> <span id="help-text">Required</span>`,
+			"plain":  `Save`,
+			"copied": `<nav aria-label="Main menu">Menu</nav>`,
+		},
+		"sv": {
+			"close":  `<button>×</button>`,
+			"help":   `<input aria-describedby="help-text"> <span id="help-text">Obligatorisk</span>`,
+			"plain":  `Spara`,
+			"copied": `<nav aria-label="Main menu">Meny</nav>`,
+		},
+		"de": {"close": `<button aria-label="Dialog schließen">×</button>`},
+	}
+	want := []Finding{
+		{Lang: "sv", Key: "close", Check: "aria",
+			Message: `dropped ARIA attributes (button aria-label): <button aria-label="Close dialog">×</button> ⇒ <button>×</button>`},
+		{Lang: "sv", Key: "copied", Check: "aria",
+			Message: `aria-label of <nav> not translated ("Main menu"): <nav aria-label="Main menu">Menu</nav> ⇒ <nav aria-label="Main menu">Meny</nav>`},
+	}
+	findings := checkARIA(translations)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+}
+
+func TestAttributePlaceholders(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {
+			"link":    `Read the <a href="$url$">terms</a>`,
+			"title":   `<span title="{{ name }}">{{ name }}</span>`,
+			"plain":   `Hello $name$`,
+			"missing": `<a href="$url$">Open</a>`,
+		},
+		"sv": {
+			"link":    `Läs <a href="/terms">villkoren</a> på $url$`,
+			"title":   `<span title="{{name}}">{{name}}</span>`,
+			"plain":   `Hej <b title="$name$">där</b>`,
+			"missing": `<a href="/x">Öppna</a>`,
+		},
+	}
+	want := []Finding{
+		{Lang: "sv", Key: "link", Check: "attribute-variables",
+			Message: `$url$ is in the href attribute in english but in the text: Read the <a href="$url$">terms</a> ⇒ Läs <a href="/terms">villkoren</a> på $url$`},
+		{Lang: "sv", Key: "plain", Check: "attribute-variables",
+			Message: `$name$ is in the text in english but in the title attribute: Hello $name$ ⇒ Hej <b title="$name$">där</b>`},
+	}
+	findings := checkAttributePlaceholders(translations)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+}
+
+func TestTextAttributes(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {
+			"logo":   `<img src="/logo.png" alt="Company logo"> Welcome`,
+			"search": `<input placeholder="Search" title="Search the archive">`,
+			"same":   `<abbr title="Portable Document Format">PDF</abbr>`,
+		},
+		"sv": {
+			"logo":   `<img src="/logo.png" alt="Company logo"> Välkommen`,
+			"search": `<input placeholder="Sök">`,
+			"same":   `<abbr title="Portable Document Format">PDF</abbr>`,
+		},
+	}
+	want := []Finding{
+		{Lang: "sv", Key: "logo", Check: "translatable-attributes",
+			Message: `alt of <img> not translated ("Company logo"): <img src="/logo.png" alt="Company logo"> Welcome ⇒ <img src="/logo.png" alt="Company logo"> Välkommen`},
+		{Lang: "sv", Key: "search", Check: "translatable-attributes",
+			Message: `dropped translatable attributes (input title): <input placeholder="Search" title="Search the archive"> ⇒ <input placeholder="Sök">`},
+	}
+	findings := checkTextAttributes(translations)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+}
+
+func TestHTMLEntities(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {
+			"terms": "Terms &amp; conditions",
+			"space": "Total:&nbsp;$amount$",
+			"raw":   "Questions & answers",
+			"link":  `<a href="/a?b=1&amp;c=2">Read</a> more`,
+		},
+		"sv": {
+			"terms": "Villkor & bestämmelser",
+			"space": "Totalt:\u00a0$amount$",
+			"raw":   "Frågor &amp; svar",
+			"link":  `<a href="/a?b=1&amp;c=2">Läs</a> mer`,
+		},
+		"de": {
+			"terms": "AGB &amp; Bedingungen",
+			"space": "Summe: $amount$",
+		},
+	}
+	want := []Finding{
+		{Lang: "sv", Key: "raw", Check: "html-entities", Message: `raw "&" in english but &amp; in the translation: Questions & answers ⇒ Frågor &amp; svar`},
+		{Lang: "sv", Key: "space", Check: "html-entities", Message: "&nbsp; in english but a raw \"\\u00a0\" in the translation: Total:&nbsp;$amount$ ⇒ Totalt:\u00a0$amount$"},
+		{Lang: "sv", Key: "terms", Check: "html-entities", Message: `&amp; in english but a raw "&" in the translation: Terms &amp; conditions ⇒ Villkor & bestämmelser`},
+	}
+	findings := checkHTMLEntities(translations)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %q, got %q", want, findings)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// identicalMinWords is how many words a translation must have for being identical in two languages
+// to be suspicious. Shorter ones, like Datum in German and Dutch, often legitimately are.
+const identicalMinWords = 3
+
+// relatedLanguages are groups of languages close enough to share their translations.
+var relatedLanguages = [][]string{
+	{"bs", "hr", "sr"},
+	{"id", "ms"},
+	{"nb", "nn", "no"},
+}
+
+// baseLanguage returns the primary language subtag of lang, like pt for pt-BR.
+func baseLanguage(lang string) string {
+	base, _, _ := strings.Cut(strings.ReplaceAll(lang, "_", "-"), "-")
+	return strings.ToLower(base)
+}
+
+// areRelated reports whether the languages a and b are variants of the same language, like pt and pt-BR,
+// or are related languages.
+func areRelated(a, b string) bool {
+	a, b = baseLanguage(a), baseLanguage(b)
+	return a == b || slices.ContainsFunc(relatedLanguages, func(group []string) bool {
+		return slices.Contains(group, a) && slices.Contains(group, b)
+	})
+}
+
+// countWords returns the number of words of s with letters, leaving out its variables.
+func countWords(s string) (n int) {
+	for _, word := range strings.Fields(variableRx.ReplaceAllString(s, " ")) {
+		if strings.IndexFunc(word, unicode.IsLetter) >= 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// checkIdentical reports the translations which are the same in unrelated languages, while different
+// from english, which usually means they were pasted into the wrong language. Every one of the languages
+// is reported, since which one is wrong can't be told.
+func checkIdentical(translations map[string]Translation) (result []Finding) {
+	var langs []string
+	for lang := range translations {
+		if lang != "en" {
+			langs = append(langs, lang)
+		}
+	}
+	slices.Sort(langs)
+	words := memoize(countWords)
+	for enKey, enString := range translations["en"] {
+		for _, lang := range langs {
+			translated := translations[lang][enKey]
+			if translated == "" || translated == enString || words(translated) < identicalMinWords {
+				continue
+			}
+			var others []string
+			for _, other := range langs {
+				if other != lang && translations[other][enKey] == translated && !areRelated(lang, other) {
+					others = append(others, other)
+				}
+			}
+			if len(others) > 0 {
+				result = append(result, Finding{
+					Lang:    lang,
+					Key:     enKey,
+					Check:   "identical",
+					Message: fmt.Sprintf("same translation as %v: %v", strings.Join(others, ", "), translated),
+				})
+			}
+		}
+	}
+	return result
+}
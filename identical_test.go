@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCheckIdentical(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {
+			"pasted":  "Save your changes now",
+			"short":   "Date",
+			"brand":   "Scrive eSign Online",
+			"related": "Your document was signed",
+		},
+		"de": {
+			"pasted":  "Slaat u uw wijzigingen op",
+			"short":   "Datum",
+			"brand":   "Scrive eSign Online",
+			"related": "Ihr Dokument wurde unterzeichnet",
+		},
+		"nl": {
+			"pasted": "Slaat u uw wijzigingen op",
+			"short":  "Datum",
+			"brand":  "Scrive eSign Online",
+		},
+		"de-AT": {"related": "Ihr Dokument wurde unterzeichnet"},
+		"nb":    {"related": "Dokumentet ditt ble signert"},
+		"nn":    {"related": "Dokumentet ditt ble signert"},
+	}
+	want := []Finding{
+		{Lang: "de", Key: "pasted", Check: "identical", Message: "same translation as nl: Slaat u uw wijzigingen op"},
+		{Lang: "nl", Key: "pasted", Check: "identical", Message: "same translation as de: Slaat u uw wijzigingen op"},
+	}
+	findings := checkIdentical(translations)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestIsIgnored(t *testing.T) {
+	dir := filepath.FromSlash("/repo")
+	var rules []ignoreRule
+	for _, line := range []string{"# build artifacts", "dist/", "/out", "*.tmp.json", "vendor/**/fi.json", "!keep.tmp.json", `\#hash`, ""} {
+		if rule, ok := parseIgnoreRule(dir, line); ok {
+			rules = append(rules, rule)
+		}
+	}
+	for _, test := range []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"dist", true, true},
+		{"web/dist", true, true},
+		{"dist", false, false},
+		{"out", true, true},
+		{"web/out", true, false},
+		{"sv.tmp.json", false, true},
+		{"web/sv.tmp.json", false, true},
+		{"keep.tmp.json", false, false},
+		{"vendor/fi.json", false, true},
+		{"vendor/a/b/fi.json", false, true},
+		{"fi.json", false, false},
+		{"#hash", false, true},
+		{"../dist", true, false},
+	} {
+		if got := isIgnored(rules, filepath.Join(dir, filepath.FromSlash(test.path)), test.isDir); got != test.want {
+			t.Errorf("%v: want ignored %v, got %v", test.path, test.want, got)
+		}
+	}
+}
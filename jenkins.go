@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+)
+
+// jenkinsIssue is an issue of the native JSON format of the Warnings Next Generation plugin of Jenkins.
+// See https://github.com/jenkinsci/analysis-model/blob/main/src/main/java/edu/hm/hafner/analysis/parser/JsonParser.java
+type jenkinsIssue struct {
+	FileName   string `json:"fileName"`
+	LineStart  int    `json:"lineStart,omitempty"`
+	LineEnd    int    `json:"lineEnd,omitempty"`
+	Severity   string `json:"severity"`
+	Message    string `json:"message"`
+	Category   string `json:"category"`
+	Type       string `json:"type"`
+	ModuleName string `json:"moduleName,omitempty"`
+	// Fingerprint tells the plugin which issues are new, outstanding or fixed from a build to the next.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// reportJenkins writes findings as a report of the native JSON format of the Warnings Next Generation
+// plugin, on the lines of their key in the translation file of their language in paths, or on rootDir
+// without one. Their languages are the modules of the report, and all the findings are written so
+// that the trends of the plugin count them all.
+func reportJenkins(w io.Writer, findings []Finding, rules ruleSeverities, rootDir string, paths map[string]string) error {
+	findings = slices.Clone(findings)
+	slices.SortStableFunc(findings, compareFindings)
+	located, outside := locateFindings(findings, paths)
+	issues := []jenkinsIssue{}
+	add := func(finding Finding, fileName string, start, end int) {
+		severity := "ERROR"
+		if rules.isWarning(finding) {
+			severity = "NORMAL"
+		}
+		message := reportLocale.message(finding.Message)
+		if finding.Key != "" {
+			message = finding.Key + ": " + message
+		}
+		issues = append(issues, jenkinsIssue{
+			FileName:    fileName,
+			LineStart:   start,
+			LineEnd:     end,
+			Severity:    severity,
+			Message:     message,
+			Category:    finding.Check,
+			Type:        rules.id(finding.Check),
+			ModuleName:  finding.Lang,
+			Fingerprint: hashString(strings.Join([]string{finding.Lang, finding.Key, finding.Check, finding.Message}, "\x00")),
+		})
+	}
+	for _, finding := range outside {
+		add(finding, rootDir, 0, 0)
+	}
+	for _, finding := range located {
+		add(finding.Finding, finding.path, finding.start, finding.end)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]any{"issues": issues})
+}
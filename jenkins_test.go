@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReportJenkins(t *testing.T) {
+	dir := t.TempDir()
+	svPath := filepath.Join(dir, "sv.json")
+	os.WriteFile(svPath, []byte("{\n  \"greeting\": \"Hej $namn$\",\n  \"rate\": \"100%\"\n}\n"), 0644)
+	findings := []Finding{
+		{Lang: "sv", Key: "rate", Check: "profanity", Message: "profanity"},
+		{Lang: "sv", Key: "greeting", Check: "variables", Message: "mismatch in variables"},
+		{Lang: "de", Check: "languages", Message: "missing translation file"},
+	}
+	var buf bytes.Buffer
+	if err := reportJenkins(&buf, findings, ruleSeverities{}, dir, map[string]string{"sv": svPath}); err != nil {
+		t.Fatal(err)
+	}
+	var report struct {
+		Issues []jenkinsIssue `json:"issues"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	for i := range report.Issues {
+		if report.Issues[i].Fingerprint == "" {
+			t.Errorf("no fingerprint: %+v", report.Issues[i])
+		}
+		report.Issues[i].Fingerprint = ""
+	}
+	want := []jenkinsIssue{
+		{FileName: dir, Severity: "ERROR", Message: "missing translation file", Category: "languages", Type: "CAT005", ModuleName: "de"},
+		{FileName: svPath, LineStart: 2, LineEnd: 2, Severity: "ERROR", Message: "greeting: mismatch in variables",
+			Category: "variables", Type: "VAR001", ModuleName: "sv"},
+		{FileName: svPath, LineStart: 3, LineEnd: 3, Severity: "NORMAL", Message: "rate: profanity",
+			Category: "profanity", Type: "TXT004", ModuleName: "sv"},
+	}
+	if !reflect.DeepEqual(report.Issues, want) {
+		t.Errorf("want:\n%+v\ngot:\n%+v", want, report.Issues)
+	}
+}
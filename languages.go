@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// countryCodes maps the country codes commonly mistaken for language codes to the country
+// and the language spoken there, like se for Sweden, where Swedish is sv. Some of them are
+// languages too, like se for Northern Sami, but are much less likely in a translation file.
+var countryCodes = map[string]struct{ country, lang string }{
+	"cn": {"China", "zh"},
+	"cz": {"Czechia", "cs"},
+	"dk": {"Denmark", "da"},
+	"ee": {"Estonia", "et"},
+	"gr": {"Greece", "el"},
+	"jp": {"Japan", "ja"},
+	"kr": {"Korea", "ko"},
+	"se": {"Sweden", "sv"},
+	"ua": {"Ukraine", "uk"},
+}
+
+// foundLanguages returns the sorted languages with a translation file, whether it could be
+// loaded into translations or failed with one of loadFindings.
+func foundLanguages(translations map[string]Translation, loadFindings []Finding) []string {
+	var langs []string
+	for lang := range translations {
+		langs = append(langs, lang)
+	}
+	for _, finding := range loadFindings {
+		if finding.Lang != "" {
+			langs = append(langs, finding.Lang)
+		}
+	}
+	slices.Sort(langs)
+	return slices.Compact(langs)
+}
+
+// checkLanguages reports the expected languages which have no translation file among found,
+// and the found ones which aren't expected. The english reference is always expected.
+func checkLanguages(expected, found []string) (result []Finding) {
+	expected = append([]string{"en"}, expected...)
+	for _, lang := range expected {
+		if !slices.Contains(found, lang) && !slices.ContainsFunc(result, func(f Finding) bool { return f.Lang == lang }) {
+			result = append(result, Finding{Lang: lang, Check: "languages", Message: "missing translation file"})
+		}
+	}
+	for _, lang := range found {
+		if !slices.Contains(expected, lang) {
+			result = append(result, Finding{Lang: lang, Check: "languages", Message: "unexpected language, not in the configured languages"})
+		}
+	}
+	return result
+}
+
+// checkLanguageCodes reports the languages which aren't BCP 47 tags of a known ISO 639 language,
+// the deprecated codes, like iw for Hebrew, and the country codes used in place of a language code.
+func checkLanguageCodes(langs []string) (result []Finding) {
+	for _, lang := range langs {
+		report := func(format string, args ...any) {
+			result = append(result, Finding{Lang: lang, Check: "language-codes", Message: fmt.Sprintf(format, args...)})
+		}
+		tag, err := language.Parse(lang)
+		if err != nil {
+			report("%v is not a known language code", lang)
+			continue
+		}
+		base, _ := tag.Base()
+		code := strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+		primary, _, _ := strings.Cut(code, "-")
+		if country, ok := countryCodes[code]; ok {
+			report("%v is the country code of %v, the language code is %v", lang, country.country, country.lang)
+		} else if primary != base.String() {
+			report("%v is a deprecated language code, use %v", lang, base)
+		}
+	}
+	return result
+}
+
+// checkFoundLanguages reports the problems of the languages found in the translation root: their codes,
+// the expected languages of config which are missing, and the missing ones of the release tier, if any.
+// The languages which aren't read aren't missing.
+func checkFoundLanguages(found []string, config Config, tier string, release []string) []Finding {
+	findings := checkLanguageCodes(found)
+	notRead := func(lang string) bool { return !config.Files.wantsLanguage(lang) }
+	if len(config.Languages) > 0 {
+		findings = append(findings, checkLanguages(slices.DeleteFunc(slices.Clone(config.Languages), notRead), found)...)
+	}
+	if tier != "" {
+		findings = append(findings, checkRelease(tier, slices.DeleteFunc(slices.Clone(release), notRead), found)...)
+	}
+	return findings
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckLanguages(t *testing.T) {
+	translations := map[string]Translation{"en": {}, "sv": {}, "xx": {}}
+	loadFindings := []Finding{
+		{Lang: "fi", Check: "load", Message: "fi.json: unexpected end of JSON input"},
+		{Check: "files", Message: "permission denied"},
+	}
+	found := foundLanguages(translations, loadFindings)
+	if want := []string{"en", "fi", "sv", "xx"}; !reflect.DeepEqual(found, want) {
+		t.Errorf("want %v, got %v", want, found)
+	}
+
+	want := []Finding{
+		{Lang: "de", Check: "languages", Message: "missing translation file"},
+		{Lang: "xx", Check: "languages", Message: "unexpected language, not in the configured languages"},
+	}
+	if findings := checkLanguages([]string{"sv", "de", "fi", "en"}, found); !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+	if findings := checkLanguages([]string{"sv", "fi", "xx"}, found); len(findings) != 0 {
+		t.Errorf("unexpected findings: %v", findings)
+	}
+}
+
+func TestCheckLanguageCodes(t *testing.T) {
+	langs := []string{"en", "sv", "pt_BR", "zh-Hant", "xx", "qq", "se", "iw", "en-", "de"}
+	want := []Finding{
+		{Lang: "xx", Check: "language-codes", Message: "xx is not a known language code"},
+		{Lang: "qq", Check: "language-codes", Message: "qq is not a known language code"},
+		{Lang: "se", Check: "language-codes", Message: "se is the country code of Sweden, the language code is sv"},
+		{Lang: "iw", Check: "language-codes", Message: "iw is a deprecated language code, use he"},
+		{Lang: "en-", Check: "language-codes", Message: "en- is not a known language code"},
+	}
+	if findings := checkLanguageCodes(langs); !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// localeFiles are the translations of the reports, locales/<lang>.json, mapping the english format
+// strings of the reports and the messages of the findings to the ones of the language.
+// Their verbs are all %v, in the order of the english ones, whose values are already formatted.
+//
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// locale translates the text reports to a language.
+type locale struct {
+	translations map[string]string
+	// messages match the messages of the findings, the longest formats first.
+	messages []localeMessage
+}
+
+// localeMessage is the translation of the messages of the findings matching rx.
+type localeMessage struct {
+	rx     *regexp.Regexp
+	format string
+}
+
+// reportLocale is the language of the text reports of the current run, nil for english.
+var reportLocale *locale
+
+// formatVerbRx matches the verbs of the english format strings.
+var formatVerbRx = regexp.MustCompile(`%%|%(?:\.[0-9]+)?[vqdf]`)
+
+// loadLocale loads the translation of the reports to lang, nil for english.
+func loadLocale(lang string) (*locale, error) {
+	if lang == "en" {
+		return nil, nil
+	}
+	bs, err := localeFiles.ReadFile("locales/" + lang + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("no %v translation of the reports", lang)
+	}
+	l := &locale{}
+	if err := json.Unmarshal(bs, &l.translations); err != nil {
+		return nil, fmt.Errorf("locales/%v.json: %w", lang, err)
+	}
+	formats := sortedKeys(l.translations)
+	slices.SortStableFunc(formats, func(a, b string) int { return len(b) - len(a) })
+	for _, format := range formats {
+		l.messages = append(l.messages, localeMessage{formatRx(format), l.translations[format]})
+	}
+	return l, nil
+}
+
+// formatRx returns a regular expression matching the strings formatted with format,
+// capturing the values of its verbs.
+func formatRx(format string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("(?s)^")
+	last := 0
+	for _, loc := range formatVerbRx.FindAllStringIndex(format, -1) {
+		b.WriteString(regexp.QuoteMeta(format[last:loc[0]]))
+		if format[loc[0]:loc[1]] == "%%" {
+			b.WriteString("%")
+		} else {
+			b.WriteString("(.*?)")
+		}
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(format[last:]))
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// sprintf formats a string of the reports in the language of l.
+func (l *locale) sprintf(format string, args ...any) string {
+	if l != nil {
+		if translated, ok := l.translations[format]; ok {
+			format = translated
+		}
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// message translates the message of a finding to the language of l.
+// The messages without a translation are left in english.
+func (l *locale) message(message string) string {
+	if l == nil {
+		return message
+	}
+	for _, m := range l.messages {
+		if values := m.rx.FindStringSubmatch(message); values != nil {
+			args := make([]any, len(values)-1)
+			for i, value := range values[1:] {
+				args[i] = value
+			}
+			return fmt.Sprintf(m.format, args...)
+		}
+	}
+	return message
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestLocales(t *testing.T) {
+	// Every translation must format as many values as the english format.
+	paths, _ := fs.Glob(localeFiles, "locales/*.json")
+	for _, path := range paths {
+		lang := strings.TrimSuffix(strings.TrimPrefix(path, "locales/"), ".json")
+		l, err := loadLocale(lang)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for format, translated := range l.translations {
+			count := func(s string) int { return len(formatVerbRx.FindAllString(strings.ReplaceAll(s, "%%", ""), -1)) }
+			if count(format) != count(translated) || strings.Count(translated, "%v") != count(translated) {
+				t.Errorf("%v: %q: the translation %q must have one %%v for every verb", lang, format, translated)
+			}
+		}
+	}
+	if _, err := loadLocale("xx"); err == nil {
+		t.Error("want an error for a language without a translation")
+	}
+}
+
+func TestLocaleMessage(t *testing.T) {
+	sv, err := loadLocale("sv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct{ message, want string }{
+		{"mismatch in variables: Hello $name$ ⇒ Hej $namn$", "variablerna stämmer inte överens: Hello $name$ ⇒ Hej $namn$"},
+		{"97% similar to english: Signed ⇒ Signed.", "97 % likt engelskan: Signed ⇒ Signed."},
+		{`dummy text "asdf": asdf`, `utfyllnadstext "asdf": asdf`},
+		{"missing translation file, required by the tier-1 release", "översättningsfil saknas, krävs för lanseringen tier-1"},
+		{"too formal", "too formal"},
+	} {
+		if got := sv.message(test.message); got != test.want {
+			t.Errorf("%v: want %q, got %q", test.message, test.want, got)
+		}
+	}
+
+	defer func(l *locale) { reportLocale = l }(reportLocale)
+	reportLocale = sv
+	var buf bytes.Buffer
+	reportText(&buf, []Finding{
+		{Lang: "de", Key: "a", Check: "variables", Message: "mismatch in variables: $a$ ⇒ $b$"},
+		{Lang: "de", Key: "b", Check: "variables", Message: "mismatch in variables: $a$ ⇒ $b$"},
+	}, ruleSeverities{})
+	want := "[de]\n    variablerna stämmer inte överens: $a$ ⇒ $b$ (VAR001, 2 gånger)\n        nycklar: a, b\n"
+	if buf.String() != want {
+		t.Errorf("want:\n%v\ngot:\n%v", want, buf.String())
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// lockFile records, for every translated string, the english source it was translated from.
+// It's read from the translation root directory, next to en.json.
+const lockFile = "translations.lock"
+
+// lockEntry is the state of a translated string when it was last changed,
+// as hashes of the english source and of the translation.
+type lockEntry struct {
+	Source      string `json:"source"`
+	Translation string `json:"translation"`
+}
+
+// translationLock maps language -> key -> lockEntry.
+type translationLock map[string]map[string]lockEntry
+
+// hashString returns a short hash identifying s.
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
+
+// loadLock loads the lock file of rootDir, or returns nil if there is none.
+func loadLock(rootDir string) translationLock {
+	path := filepath.Join(rootDir, lockFile)
+	bs, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		log.Fatalf("loadLock: %v: %v", path, err)
+	}
+	lock := translationLock{}
+	if err := json.Unmarshal(bs, &lock); err != nil {
+		log.Fatalf("loadLock: %v: %v", path, err)
+	}
+	return lock
+}
+
+// writeLock writes lock to the lock file of rootDir.
+func writeLock(rootDir string, lock translationLock) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(lock); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(rootDir, lockFile), buf.Bytes(), 0644)
+}
+
+// updateLock returns the lock for the current translations.
+// The strings whose translation changed are recorded with the current english source,
+// while the unchanged ones keep the source they were translated from, so that they stay stale.
+// The languages missing from translations are kept as they are.
+func updateLock(lock translationLock, translations map[string]Translation) translationLock {
+	result := translationLock{}
+	for lang, entries := range lock {
+		result[lang] = entries
+	}
+	for lang, translation := range translations {
+		if lang == "en" {
+			continue
+		}
+		result[lang] = make(map[string]lockEntry)
+		for key, enString := range translations["en"] {
+			if translation[key] == "" {
+				continue
+			}
+			entry, ok := lock[lang][key]
+			translationHash := hashString(translation[key])
+			if !ok || entry.Translation != translationHash {
+				entry = lockEntry{hashString(enString), translationHash}
+			}
+			result[lang][key] = entry
+		}
+	}
+	return result
+}
+
+// checkStale returns a check reporting the translations which haven't changed
+// since the english source they were translated from changed.
+func checkStale(lock translationLock) checkFunc {
+	return func(translations map[string]Translation) (result []Finding) {
+		for lang, entries := range lock {
+			for key, entry := range entries {
+				enString, ok := translations["en"][key]
+				translated := translations[lang][key]
+				if !ok || translated == "" || hashString(enString) == entry.Source ||
+					hashString(translated) != entry.Translation {
+					continue
+				}
+				result = append(result, Finding{
+					Lang:    lang,
+					Key:     key,
+					Check:   "stale",
+					Message: fmt.Sprintf("english source changed since the translation: %v ⇒ %v", enString, translated),
+				})
+			}
+		}
+		return result
+	}
+}
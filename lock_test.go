@@ -0,0 +1,45 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStale(t *testing.T) {
+	dir := t.TempDir()
+	translations := map[string]Translation{
+		"en": {"a": "Save", "b": "Open", "c": "Close"},
+		"sv": {"a": "Spara", "b": "Öppna", "c": "Stäng"},
+	}
+	if err := writeLock(dir, updateLock(loadLock(dir), translations)); err != nil {
+		t.Fatal(err)
+	}
+	lock := loadLock(dir)
+	if findings := checkStale(lock)(translations); len(findings) != 0 {
+		t.Errorf("unexpected findings: %v", findings)
+	}
+
+	// Both sources change, but only one of the translations is updated.
+	translations["en"]["a"] = "Save all"
+	translations["en"]["b"] = "Open file"
+	translations["sv"]["b"] = "Öppna fil"
+	want := []Finding{{
+		Lang:    "sv",
+		Key:     "a",
+		Check:   "stale",
+		Message: "english source changed since the translation: Save all ⇒ Spara",
+	}}
+	if findings := checkStale(lock)(translations); !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+
+	// Updating the lock doesn't forget the stale translation.
+	lock = updateLock(lock, translations)
+	if findings := checkStale(lock)(translations); !reflect.DeepEqual(findings, want) {
+		t.Errorf("after update: want %v, got %v", want, findings)
+	}
+	translations["sv"]["a"] = "Spara alla"
+	if findings := checkStale(updateLock(lock, translations))(translations); len(findings) != 0 {
+		t.Errorf("unexpected findings after translating: %v", findings)
+	}
+}
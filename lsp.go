@@ -0,0 +1,425 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// JSON-RPC error codes used by the language server.
+const (
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// LSP diagnostic severities.
+const (
+	lspSeverityError   = 1
+	lspSeverityWarning = 2
+)
+
+type rpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Code     string   `json:"code,omitempty"`
+	Message  string   `json:"message"`
+}
+
+type lspTextDocument struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type lspDocumentParams struct {
+	TextDocument   lspTextDocument `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+	Position lspPosition `json:"position"`
+}
+
+type lspHover struct {
+	Contents struct {
+		Kind  string `json:"kind"`
+		Value string `json:"value"`
+	} `json:"contents"`
+	Range lspRange `json:"range"`
+}
+
+// lspServer publishes the findings of the checks as diagnostics of the open translation files.
+// The english reference is read from the english translation file in the same directory, preferring
+// its open version over the one on disk, or without one, from the source strings of a PO file.
+type lspServer struct {
+	out    *bufio.Writer
+	checks []check
+	// rules are the configured severities of the rules of the checks.
+	rules ruleSeverities
+	// match matches the names of the translation files, see newFileMatcher.
+	match fileMatcher
+	// arrays is how the arrays of strings are read.
+	arrays string
+	// docs maps the URIs of the open documents to their text.
+	docs map[string]string
+}
+
+// lsp runs the language server on stdin and stdout until the client asks it to exit.
+func lsp(args []string) {
+	flags := flag.NewFlagSet("lsp", flag.ExitOnError)
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v lsp [flags]\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	config := loadConfig(*configPath)
+	match, err := newFileMatcher(config.Files.Pattern)
+	if err != nil {
+		log.Fatalf("lsp: %v", err)
+	}
+	s := newLSPServer(os.Stdout, loadChecks(config), config.Rules, match, config.Files.Arrays)
+	if err := s.run(os.Stdin); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newLSPServer(w io.Writer, checks []check, rules ruleSeverities, match fileMatcher, arrays string) *lspServer {
+	return &lspServer{
+		out:    bufio.NewWriter(w),
+		checks: checks,
+		rules:  rules,
+		match:  match,
+		arrays: arrays,
+		docs:   make(map[string]string),
+	}
+}
+
+// run handles the messages from r until the exit notification or the end of the input.
+func (s *lspServer) run(r io.Reader) error {
+	in := bufio.NewReader(r)
+	for {
+		msg, err := readRPCMessage(in)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		s.handle(msg)
+		if err := s.out.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *lspServer) handle(msg rpcMessage) {
+	var params lspDocumentParams
+	if len(msg.Params) > 0 {
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			s.respondError(msg.ID, rpcInvalidParams, err.Error())
+			return
+		}
+	}
+	uri := params.TextDocument.URI
+
+	switch msg.Method {
+	case "initialize":
+		s.respond(msg.ID, map[string]any{
+			"capabilities": map[string]any{
+				// Full document synchronization.
+				"textDocumentSync": 1,
+				"hoverProvider":    true,
+			},
+			"serverInfo": map[string]string{"name": "check-translations"},
+		})
+	case "shutdown":
+		s.respond(msg.ID, json.RawMessage("null"))
+	case "textDocument/didOpen":
+		s.docs[uri] = params.TextDocument.Text
+		s.publishDir(uri)
+	case "textDocument/didChange":
+		if n := len(params.ContentChanges); n > 0 {
+			s.docs[uri] = params.ContentChanges[n-1].Text
+		}
+		s.publishDir(uri)
+	case "textDocument/didClose":
+		delete(s.docs, uri)
+		s.publish(uri, []lspDiagnostic{})
+		s.publishDir(uri)
+	case "textDocument/hover":
+		s.respond(msg.ID, s.hover(uri, params.Position))
+	default:
+		// Notifications we don't care about are ignored, requests are refused.
+		if msg.ID != nil {
+			s.respondError(msg.ID, rpcMethodNotFound, fmt.Sprintf("method not found: %v", msg.Method))
+		}
+	}
+}
+
+// publishDir publishes the diagnostics of uri, and if it is the english reference,
+// also of all the other open documents in the same directory.
+func (s *lspServer) publishDir(uri string) {
+	if _, ok := s.docs[uri]; ok {
+		s.publish(uri, s.diagnose(uri))
+	}
+	path := uriPath(uri)
+	if lang, _ := s.match(path); lang != "en" {
+		return
+	}
+	for other := range s.docs {
+		if other != uri && filepath.Dir(uriPath(other)) == filepath.Dir(path) {
+			s.publish(other, s.diagnose(other))
+		}
+	}
+}
+
+// diagnose runs the checks on the open document at uri, and reports the values it skipped.
+func (s *lspServer) diagnose(uri string) []lspDiagnostic {
+	diagnostics := []lspDiagnostic{}
+	path := uriPath(uri)
+	lang, ok := s.match(path)
+	if !ok {
+		return diagnostics
+	}
+	text := []byte(s.docs[uri])
+	translation, source, raw, err := parseTranslationFile(path, text, s.arrays)
+	if err != nil {
+		return append(diagnostics, lspDiagnostic{
+			Range:    s.rangeOf(text, span{jsonErrorOffset(err), jsonErrorOffset(err)}),
+			Severity: lspSeverityError,
+			Source:   "check-translations",
+			Message:  err.Error(),
+		})
+	}
+	// The members of a PO file aren't located, its findings are shown at the start of the document.
+	members, _ := locateMembers(text)
+
+	translations := map[string]Translation{lang: translation}
+	if lang != "en" {
+		if reference, ok := s.reference(path); ok {
+			translations["en"] = reference
+		} else if source != nil {
+			translations["en"] = source
+		}
+	}
+	findings := enabledFindings(skippedFindings(lang, raw), s.rules)
+	for _, finding := range append(findings, runChecks(s.checks, translations)...) {
+		if finding.Lang != lang {
+			continue
+		}
+		severity := lspSeverityError
+		if s.rules.isWarning(finding) {
+			severity = lspSeverityWarning
+		}
+		// Findings not tied to a key are shown at the start of the document.
+		diagnostics = append(diagnostics, lspDiagnostic{
+			Range:    s.rangeOf(text, members[finding.Key].value),
+			Severity: severity,
+			Source:   "check-translations",
+			Code:     s.rules.id(finding.Check),
+			Message:  finding.Message,
+		})
+	}
+	return diagnostics
+}
+
+// referencePath returns the path of the english translation file in the directory of path,
+// an open one or else one on disk.
+func (s *lspServer) referencePath(path string) (string, bool) {
+	dir := filepath.Dir(path)
+	for _, uri := range sortedKeys(s.docs) {
+		other := uriPath(uri)
+		if lang, ok := s.match(other); ok && lang == "en" && filepath.Dir(other) == dir {
+			return other, true
+		}
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if lang, ok := s.match(entry.Name()); ok && lang == "en" && !entry.IsDir() {
+			return filepath.Join(dir, entry.Name()), true
+		}
+	}
+	return "", false
+}
+
+// reference returns the english translation next to path.
+func (s *lspServer) reference(path string) (Translation, bool) {
+	enPath, ok := s.referencePath(path)
+	if !ok {
+		return nil, false
+	}
+	var bs []byte
+	if text, ok := s.docs[pathURI(enPath)]; ok {
+		bs = []byte(text)
+	} else {
+		var err error
+		if bs, err = os.ReadFile(enPath); err != nil {
+			return nil, false
+		}
+	}
+	translation, _, _, err := parseTranslationFile(enPath, bs, s.arrays)
+	return translation, err == nil
+}
+
+// hover shows the english source of the string under the cursor.
+func (s *lspServer) hover(uri string, pos lspPosition) any {
+	path := uriPath(uri)
+	text := []byte(s.docs[uri])
+	if lang, ok := s.match(path); !ok || lang == "en" {
+		return json.RawMessage("null")
+	}
+	reference, ok := s.reference(path)
+	if !ok {
+		return json.RawMessage("null")
+	}
+	members, _ := locateMembers(text)
+	offset := offsetOf(text, pos.Line, pos.Character)
+	for key, m := range members {
+		if offset < m.key.start || offset > m.value.end {
+			continue
+		}
+		enString, ok := reference[key]
+		if !ok {
+			return json.RawMessage("null")
+		}
+		var h lspHover
+		h.Contents.Kind = "markdown"
+		h.Contents.Value = fmt.Sprintf("**en**: %v", enString)
+		h.Range = s.rangeOf(text, span{m.key.start, m.value.end})
+		return h
+	}
+	return json.RawMessage("null")
+}
+
+func (s *lspServer) rangeOf(text []byte, sp span) lspRange {
+	startLine, startCol := lineCol(text, sp.start)
+	endLine, endCol := lineCol(text, sp.end)
+	return lspRange{
+		Start: lspPosition{startLine, startCol},
+		End:   lspPosition{endLine, endCol},
+	}
+}
+
+func (s *lspServer) publish(uri string, diagnostics []lspDiagnostic) {
+	s.write(rpcMessage{
+		Method: "textDocument/publishDiagnostics",
+		Params: mustMarshal(map[string]any{"uri": uri, "diagnostics": diagnostics}),
+	})
+}
+
+func (s *lspServer) respond(id json.RawMessage, result any) {
+	s.write(rpcMessage{ID: id, Result: result})
+}
+
+func (s *lspServer) respondError(id json.RawMessage, code int, message string) {
+	s.write(rpcMessage{ID: id, Error: &rpcError{code, message}})
+}
+
+func (s *lspServer) write(msg rpcMessage) {
+	msg.JSONRPC = "2.0"
+	bs := mustMarshal(msg)
+	fmt.Fprintf(s.out, "Content-Length: %v\r\n\r\n", len(bs))
+	s.out.Write(bs)
+}
+
+// readRPCMessage reads one message framed with a Content-Length header.
+func readRPCMessage(r *bufio.Reader) (rpcMessage, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return rpcMessage{}, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		name, value, _ := strings.Cut(line, ":")
+		if strings.EqualFold(name, "Content-Length") {
+			if length, err = strconv.Atoi(strings.TrimSpace(value)); err != nil {
+				return rpcMessage{}, fmt.Errorf("invalid Content-Length: %w", err)
+			}
+		}
+	}
+	if length < 0 {
+		return rpcMessage{}, errors.New("missing Content-Length header")
+	}
+	bs := make([]byte, length)
+	if _, err := io.ReadFull(r, bs); err != nil {
+		return rpcMessage{}, err
+	}
+	var msg rpcMessage
+	err := json.Unmarshal(bs, &msg)
+	return msg, err
+}
+
+// jsonErrorOffset returns the byte offset of a JSON decoding error, or 0 if unknown.
+func jsonErrorOffset(err error) int {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return int(syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		return int(typeErr.Offset)
+	}
+	return 0
+}
+
+func uriPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+func pathURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+func mustMarshal(v any) json.RawMessage {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func rpcInput(msgs ...string) *bytes.Buffer {
+	var buf bytes.Buffer
+	for _, msg := range msgs {
+		fmt.Fprintf(&buf, "Content-Length: %v\r\n\r\n%v", len(msg), msg)
+	}
+	return &buf
+}
+
+// publishedDiagnostics returns the last diagnostics published for every URI in the output of the server.
+func publishedDiagnostics(output *bytes.Buffer) map[string][]lspDiagnostic {
+	diagnostics := make(map[string][]lspDiagnostic)
+	out := bufio.NewReader(output)
+	for {
+		msg, err := readRPCMessage(out)
+		if err != nil {
+			return diagnostics
+		}
+		if msg.Method == "textDocument/publishDiagnostics" {
+			var params struct {
+				URI         string          `json:"uri"`
+				Diagnostics []lspDiagnostic `json:"diagnostics"`
+			}
+			json.Unmarshal(msg.Params, &params)
+			diagnostics[params.URI] = params.Diagnostics
+		}
+	}
+}
+
+func TestLSP(t *testing.T) {
+	dir := t.TempDir()
+	enURI := pathURI(dir + "/en.json")
+	svURI := pathURI(dir + "/sv.json")
+	svText := "{\n  \"greeting\": \"Hej $namn$\",\n  \"bold\": \"<b>fet\"\n}\n"
+	open := func(uri, text string) string {
+		params, _ := json.Marshal(map[string]any{"textDocument": map[string]string{"uri": uri, "text": text}})
+		return fmt.Sprintf(`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":%s}`, params)
+	}
+	input := rpcInput(
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		open(enURI, `{"greeting": "Hello $name$", "bold": "<b>bold</b>"}`),
+		open(svURI, svText),
+		fmt.Sprintf(`{"jsonrpc":"2.0","id":2,"method":"textDocument/hover","params":{"textDocument":{"uri":%q},"position":{"line":1,"character":5}}}`, svURI),
+		`{"jsonrpc":"2.0","id":3,"method":"unknown/method"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	)
+
+	var output bytes.Buffer
+	if err := newLSPServer(&output, builtinChecks, newRuleSeverities(map[string]string{"html": severityWarning}), defaultFileMatcher, arraysUnsupported).run(input); err != nil {
+		t.Fatal(err)
+	}
+
+	var svDiagnostics []lspDiagnostic
+	var hover *lspHover
+	var methodNotFound bool
+	out := bufio.NewReader(&output)
+	for {
+		msg, err := readRPCMessage(out)
+		if err != nil {
+			break
+		}
+		switch {
+		case msg.Method == "textDocument/publishDiagnostics":
+			var params struct {
+				URI         string          `json:"uri"`
+				Diagnostics []lspDiagnostic `json:"diagnostics"`
+			}
+			json.Unmarshal(msg.Params, &params)
+			if params.URI == svURI {
+				svDiagnostics = params.Diagnostics
+			}
+		case string(msg.ID) == "2":
+			bs, _ := json.Marshal(msg.Result)
+			json.Unmarshal(bs, &hover)
+		case string(msg.ID) == "3":
+			methodNotFound = msg.Error != nil && msg.Error.Code == rpcMethodNotFound
+		}
+	}
+
+	want := map[string]lspRange{
+		"VAR001":  {lspPosition{1, 14}, lspPosition{1, 26}},
+		"HTML001": {lspPosition{2, 10}, lspPosition{2, 18}},
+	}
+	if len(svDiagnostics) != len(want) {
+		t.Fatalf("want %v diagnostics, got %v", len(want), svDiagnostics)
+	}
+	severities := map[string]int{"VAR001": lspSeverityError, "HTML001": lspSeverityWarning}
+	for _, d := range svDiagnostics {
+		if d.Range != want[d.Code] {
+			t.Errorf("%v: want range %v, got %v", d.Code, want[d.Code], d.Range)
+		}
+		if d.Severity != severities[d.Code] {
+			t.Errorf("%v: want severity %v, got %v", d.Code, severities[d.Code], d.Severity)
+		}
+	}
+	if hover == nil || hover.Contents.Value != "**en**: Hello $name$" {
+		t.Errorf("unexpected hover: %v", hover)
+	}
+	if !methodNotFound {
+		t.Errorf("want method not found error for unknown requests")
+	}
+}
+
+func TestLSPPattern(t *testing.T) {
+	dir := t.TempDir()
+	// The reference is found on disk through the pattern.
+	if err := os.WriteFile(filepath.Join(dir, "messages.en.json"), []byte(`{"greeting": "Hello $name$", "count": "$n$ files"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	svURI := pathURI(dir + "/messages.sv.json")
+	svText := "{\n  \"greeting\": \"Hej\",\n  \"count\": 3\n}\n"
+	params, _ := json.Marshal(map[string]any{"textDocument": map[string]string{"uri": svURI, "text": svText}})
+	input := rpcInput(
+		fmt.Sprintf(`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":%s}`, params),
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	)
+
+	match, err := newFileMatcher(`^messages\.(\w+)\.json$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output bytes.Buffer
+	if err := newLSPServer(&output, builtinChecks, ruleSeverities{}, match, arraysUnsupported).run(input); err != nil {
+		t.Fatal(err)
+	}
+
+	// The value which isn't a string is reported on its key, along with the other findings.
+	want := map[string]lspRange{
+		"VAR001":  {lspPosition{1, 14}, lspPosition{1, 19}},
+		"FILE004": {lspPosition{2, 11}, lspPosition{2, 12}},
+	}
+	got := publishedDiagnostics(&output)[svURI]
+	if len(got) != len(want) {
+		t.Fatalf("want %v diagnostics, got %v", len(want), got)
+	}
+	for _, d := range got {
+		if d.Range != want[d.Code] {
+			t.Errorf("%v: want range %v, got %v", d.Code, want[d.Code], d.Range)
+		}
+	}
+}
@@ -2,17 +2,17 @@ package main
 
 import (
 	"container/list"
-	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
-	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"golang.org/x/net/html"
 )
@@ -21,33 +21,146 @@ type Translation map[string]string
 
 var variableRx = regexp.MustCompile("\\$[^$]+\\$")
 
-// loadTranslation loads a <lang>.json into a map and returns it.
-func loadTranslation(path string) Translation {
-	bs, err := os.ReadFile(path)
+// loadTranslation loads a <lang>.json into a map and returns it, with the raw values of the members
+// which aren't read as a single string, see readTranslation.
+func loadTranslation(path, arrays string) (Translation, rawValues, error) {
+	file, err := os.Open(path)
 	if err != nil {
-		log.Fatalf("loadTranslation: %v: %v", path, err)
+		return nil, rawValues{}, err
 	}
+	defer file.Close()
+	translation, raw, err := readTranslation(file, arrays)
+	if err != nil {
+		return nil, rawValues{}, fmt.Errorf("%v: %w", path, err)
+	}
+	return translation, raw, nil
+}
 
-	translation := Translation{}
-	err = json.Unmarshal(bs, &translation)
+// writeTranslation writes translation to a <lang>.json, with the raw values read from it, see encodeTranslation.
+func writeTranslation(path string, translation Translation, raw rawValues) error {
+	bs, err := encodeTranslation(translation, raw)
 	if err != nil {
-		log.Fatalf("loadTranslation: %v: %v", path, err)
+		return err
 	}
+	return os.WriteFile(path, bs, 0644)
+}
 
-	return translation
+// parseTranslation parses the contents of a <lang>.json without arrays.
+// Values which aren't supported are an error.
+func parseTranslation(bs []byte) (Translation, error) {
+	translation, raw, err := decodeTranslation(bs, arraysUnsupported)
+	if skipped := raw.skipped(); err == nil && len(skipped) > 0 {
+		err = errors.New(skipped[0].message)
+	}
+	return translation, err
+}
+
+// isTranslationFile reports whether the file name matches the <lang>.json pattern.
+func isTranslationFile(name string) bool {
+	match, _ := filepath.Match("??.json", filepath.Base(name))
+	return match
+}
+
+// translationLang returns the language of a <lang>.json or <lang>.po file.
+func translationLang(name string) string {
+	base := filepath.Base(name)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// findTranslationFiles walks rootDir for <lang>.json and <lang>.po files.
+// The result is a map of language -> path, and the findings of the entries which couldn't be read.
+func findTranslationFiles(rootDir string, files FilesConfig) (map[string]string, []Finding) {
+	match, err := newFileMatcher(files.Pattern)
+	if err != nil {
+		return nil, []Finding{{Check: "files", Message: err.Error()}}
+	}
+	w := translationWalker{FilesConfig: files, match: match, paths: make(map[string]string)}
+	w.walk(rootDir, 1)
+	return w.paths, w.findings
+}
+
+// loadTranslations loads all the <lang>.json files found in rootDir.
+// The result is a map of language -> translation, and the findings of the files which couldn't be found or loaded.
+func loadTranslations(rootDir string, files FilesConfig) (map[string]Translation, []Finding) {
+	paths, walkFindings := findTranslationFiles(rootDir, files)
+	translations, findings := loadTranslationFiles(paths, files.Arrays)
+	return translations, append(walkFindings, findings...)
+}
+
+// checkTranslationRoot loads and checks the translation files of rootDir, for the integrations reporting
+// the findings on the files, as the default check mode does without flags: the languages found are
+// checked against config, and the findings of the rules it turns off are left out. It returns the paths
+// of the files by language, the translations, and all the findings.
+func checkTranslationRoot(rootDir string, config Config) (map[string]string, map[string]Translation, []Finding) {
+	paths, findings := findTranslationFiles(rootDir, config.Files)
+	translations, loadFindings := loadTranslationFiles(paths, config.Files.Arrays)
+	findings = append(findings, loadFindings...)
+	findings = append(findings, checkFoundLanguages(foundLanguages(translations, findings), config, "", nil)...)
+	findings = enabledFindings(findings, config.Rules)
+	return paths, translations, append(findings, runChecks(loadChecks(config), translations)...)
+}
+
+// loadTranslationFiles loads the files of a map of language -> path, reading their arrays as arrays says.
+// Without an en.json, the english reference is made of the source strings of the PO files.
+// The files which can't be loaded are left out and reported as findings instead, as are the values
+// which aren't supported.
+func loadTranslationFiles(paths map[string]string, arrays string) (map[string]Translation, []Finding) {
+	translations := make(map[string]Translation)
+	var findings []Finding
+	loadFailed := func(lang string, err error) {
+		findings = append(findings, Finding{Lang: lang, Check: "load", Message: err.Error()})
+	}
+	for lang, path := range paths {
+		if !isPOPath(path) {
+			translation, raw, err := loadTranslation(path, arrays)
+			if err != nil {
+				loadFailed(lang, err)
+				continue
+			}
+			translations[lang] = translation
+			findings = append(findings, skippedFindings(lang, raw)...)
+			continue
+		}
+		translation, source, err := loadPO(path)
+		if err != nil {
+			loadFailed(lang, err)
+			continue
+		}
+		translations[lang] = translation
+		if _, ok := paths["en"]; !ok {
+			addPOSource(translations, source)
+		}
+	}
+	return translations, findings
+}
+
+// skippedFindings reports the values of the file of lang which aren't supported, of its raw values.
+func skippedFindings(lang string, raw rawValues) (findings []Finding) {
+	for _, skipped := range raw.skipped() {
+		findings = append(findings, Finding{Lang: lang, Key: skipped.key, Check: "value", Message: skipped.message})
+	}
+	return findings
+}
+
+// addPOSource adds the english source strings of a PO file to the english reference,
+// for the translations without an en.json.
+func addPOSource(translations map[string]Translation, source Translation) {
+	if translations["en"] == nil {
+		translations["en"] = Translation{}
+	}
+	for key, enString := range source {
+		translations["en"][key] = enString
+	}
 }
 
 // checkTranslationsVariables checks for changed or missing variables.
 // The reference is the english translations. If there are missing variables on either side,
 // or the variables have been changed (possibly translated), report those as errors.
-// The result is a map of translation[language] -> list of errors for that language.
-// If the resulting map is empty, no errors were found.
-func checkTranslationVariables(translations map[string]Translation) map[string][]string {
-	result := make(map[string][]string)
-
+// If the resulting list is empty, no errors were found.
+func checkTranslationVariables(translations map[string]Translation) (result []Finding) {
+	variables := memoize(sortedVariables)
 	for enKey, enString := range translations["en"] {
-		enMatches := variableRx.FindAllString(enString, -1)
-		slices.Sort(enMatches)
+		enMatches := variables(enString)
 		// Care about empty enMatches. That might mean that there are still variables
 		// in the translation, but not in the original!
 		for lang, translation := range translations {
@@ -55,12 +168,15 @@ func checkTranslationVariables(translations map[string]Translation) map[string][
 			if lang == "en" || translation[enKey] == "" {
 				continue
 			}
-			langMatches := variableRx.FindAllString(translation[enKey], -1)
-			slices.Sort(langMatches)
+			langMatches := variables(translation[enKey])
 			if slices.Compare(enMatches, langMatches) != 0 {
-				result[lang] = append(result[lang],
-					fmt.Sprintf("mismatch in variables: %v ⇒ %v\n",
-						enString, translation[enKey]))
+				result = append(result, Finding{
+					Lang:  lang,
+					Key:   enKey,
+					Check: "variables",
+					Message: fmt.Sprintf("mismatch in variables: %v ⇒ %v",
+						enString, translation[enKey]),
+				})
 			}
 		}
 	}
@@ -119,79 +235,622 @@ Out:
 	return errs
 }
 
-func checkTranslationHTML(translations map[string]Translation) map[string][]string {
-	result := make(map[string][]string)
+// checkTranslationHTML runs checkHTML on all the strings of all the translations.
+func checkTranslationHTML(translations map[string]Translation) (result []Finding) {
+	check := memoize(checkHTML)
 	for lang, translation := range translations {
-		for _, translatedString := range translation {
-			errs := checkHTML(translatedString)
+		for key, translatedString := range translation {
+			errs := check(translatedString)
 			for _, err := range errs {
-				result[lang] = append(result[lang], fmt.Sprintf("%v: %v", err, translatedString))
+				result = append(result, Finding{
+					Lang:    lang,
+					Key:     key,
+					Check:   "html",
+					Message: fmt.Sprintf("%v: %v", err, translatedString),
+				})
 			}
 		}
 	}
 	return result
 }
 
+// check is a check with the name its findings are reported with.
+type check struct {
+	name string
+	run  checkFunc
+}
+
+// builtinChecks lists the checks that are always run.
+var builtinChecks = []check{
+	{"variables", checkTranslationVariables},
+	{"variable-spacing", checkVariableSpacing},
+	{"html", checkTranslationHTML},
+	{"html-tags", checkTranslationHTMLTags},
+	{"html-attributes", checkTranslationHTMLAttributes},
+	{"html-comments", checkHTMLComments},
+	{"html-elements", checkForbiddenElements},
+	{"html-alt", checkImageAlts},
+	{"html-entities", checkHTMLEntities},
+	{"aria", checkARIA},
+	{"translatable-attributes", checkTextAttributes},
+	{"attribute-variables", checkAttributePlaceholders},
+	{"rtl", checkRTL},
+	{"rtl-punctuation", checkRTLPunctuation},
+	{"cjk", checkCJK},
+	{"dates", checkDateFormats(nil)},
+	{"currency", checkCurrency},
+	{"identical", checkIdentical},
+	{"secrets", checkSecrets},
+	{"personal-data", checkPersonalData},
+	{"internal-hosts", checkInternalHosts},
+	{"markers", checkMarkers},
+	{"dummy-text", checkDummyText},
+}
+
+// loadChecks returns the built-in checks followed by the checks of the configured plugins
+// and the configurable checks.
+func loadChecks(config Config) []check {
+	checks := slices.Clone(builtinChecks)
+	for _, path := range config.Plugins {
+		checks = append(checks, loadPlugin(path))
+	}
+	if config.HTML.ContentModel {
+		checks = append(checks, check{"html-content-model", checkTranslationHTMLContentModel})
+	}
+	if len(config.Dates) > 0 {
+		i := slices.IndexFunc(checks, func(c check) bool { return c.name == "dates" })
+		checks[i].run = checkDateFormats(config.Dates)
+	}
+	if len(config.Numbers) > 0 {
+		checks = append(checks, check{"numbers", checkNumbers(config.Numbers)})
+	}
+	if len(config.Profanity) > 0 {
+		lists := make(map[string]*regexp.Regexp)
+		for lang, path := range config.Profanity {
+			words, err := loadWordList(path)
+			if err != nil {
+				log.Fatalf("loadWordList: %v: %v", path, err)
+			}
+			if rx := wordListRx(words); rx != nil {
+				lists[lang] = rx
+			}
+		}
+		checks = append(checks, check{"profanity", checkProfanity(lists)})
+	}
+	if config.DetectLanguage {
+		checks = append(checks, check{"language", checkDetectedLanguage})
+	}
+	if config.Similarity > 0 {
+		checks = append(checks, check{"similarity", checkSimilarity(config.Similarity)})
+	}
+	for _, rule := range config.CustomRules {
+		checks = append(checks, check{rule.Name, checkCustomRule(rule)})
+	}
+	return enabledChecks(checks, config.Rules)
+}
+
+// runChecks runs all the checks on translations and collects their findings,
+// sorted with compareFindings since the checks walk the translations in map order.
+func runChecks(checks []check, translations map[string]Translation) (findings []Finding) {
+	for _, check := range checks {
+		findings = append(findings, check.run(translations)...)
+	}
+	slices.SortStableFunc(findings, compareFindings)
+	return findings
+}
+
+// commands maps subcommand names to their entry points.
+// Without a subcommand, the translations are checked once and reported.
+var commands = map[string]func(args []string){
+	"badge":              badge,
+	"bitbucket-report":   bitbucketReportCommand,
+	"buildkite-annotate": buildkiteAnnotate,
+	"diff":               diff,
+	"explain":            explain,
+	"export":             export,
+	"extract":            extract,
+	"gerrit-review":      gerritReview,
+	"github-check":       githubCheck,
+	"github-review":      githubReview,
+	"install-hook":       installHook,
+	"lsp":                lsp,
+	"merge":              merge,
+	"remote":             remote,
+	"review":             review,
+	"scan-usage":         scanUsageCommand,
+	"schema":             schemaCommand,
+	"serve":              serve,
+	"split":              split,
+	"trend":              trend,
+}
+
 func main() {
-	rootDir := processArgs()
-	translations := make(map[string]Translation)
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			command(os.Args[2:])
+			return
+		}
+	}
+
+	start := time.Now()
+	opts, err := processArgs()
+	if err != nil {
+		log.Fatal(err)
+	}
+	config := loadConfig(opts.configPath)
+	if opts.rootDir == "" && len(config.Projects) == 0 {
+		flag.Usage()
+		os.Exit(1)
+	}
+	if reportLocale, err = loadLocale(opts.reportLang); err != nil {
+		log.Fatal(err)
+	}
+	// The custom rules are only known once the configuration is loaded, and may be the ones of a project.
+	for _, rule := range append(slices.Clone(opts.enable), opts.disable...) {
+		err := config.Rules.validate(rule)
+		for _, project := range config.Projects {
+			if err != nil && project.Rules.validate(rule) == nil {
+				err = nil
+			}
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	stopProfile := func() {}
+	if opts.cpuProfile != "" {
+		if stopProfile, err = startCPUProfile(opts.cpuProfile); err != nil {
+			log.Fatalf("startCPUProfile: %v", err)
+		}
+	}
+
+	var failed bool
+	if opts.rootDir != "" {
+		failed = run(opts.rootDir, opts, config, start)
+	} else {
+		failed = runProjects(config.Projects, opts)
+	}
+	stopProfile()
+	if failed {
+		os.Exit(1)
+	}
+}
 
-	// Build the translation maps.
-	filepath.WalkDir(rootDir, func(path string, d fs.DirEntry, err error) error {
-		base := filepath.Base(path)
-		match, err := filepath.Match("??.json", base)
-		if !match {
-			return nil
+// run checks the translations of rootDir with config and reports the findings,
+// timing the run from start. It returns whether there were any findings besides warnings,
+// or with -release and -ratchet, any blocking the release or any count of errors growing,
+// and whether any language exceeds its budget.
+func run(rootDir string, opts options, config Config, start time.Time) bool {
+	if opts.followSymlinks {
+		config.Files.FollowSymlinks = true
+	}
+	if opts.maxDepth != 0 {
+		config.Files.MaxDepth = opts.maxDepth
+	}
+	if opts.noRecursive {
+		config.Files.MaxDepth = 1
+	}
+	if opts.noIgnore {
+		config.Files.NoIgnore = true
+	}
+	if opts.pattern != "" {
+		config.Files.Pattern = opts.pattern
+	}
+	if len(opts.languages) > 0 {
+		config.Files.IncludeLanguages = opts.languages
+	}
+	if len(opts.excludeLanguages) > 0 {
+		config.Files.ExcludeLanguages = opts.excludeLanguages
+	}
+	config.Rules = config.Rules.clone()
+	for _, rule := range opts.enable {
+		if severity, _ := config.Rules.configured(config.Rules.check(rule)); severity == severityOff {
+			delete(config.Rules.severities, config.Rules.check(rule))
 		}
+	}
+	for _, rule := range opts.disable {
+		config.Rules.set(config.Rules.check(rule), severityOff)
+	}
+
+	var translations map[string]Translation
+	var loadFindings []Finding
+	// found are the languages with a translation file, even if it's not checked or couldn't be loaded.
+	var found []string
+	// dir holds the context and lock files: the root directory, or the one of a workbook or an archive.
+	// The catalogs of a URL or a bucket have none.
+	dir := rootDir
+	if isRootFile(rootDir) {
+		dir = filepath.Dir(rootDir)
+		if isRemoteRoot(rootDir) {
+			dir = ""
+		}
+		translations, loadFindings = loadRoot(rootDir, config.Files)
+		found = foundLanguages(translations, loadFindings)
+	} else if opts.gitRef != "" {
+		// The context and lock files of the working tree don't describe the revision.
+		dir = ""
+		var err error
+		translations, loadFindings, err = loadRevisionTranslations(rootDir, opts.gitRef, config.Files)
+		if err != nil {
+			log.Fatal(err)
+		}
+		found = foundLanguages(translations, loadFindings)
+	} else if opts.staged {
+		var langs []string
+		var err error
+		translations, loadFindings, langs, err = loadStagedTranslations(rootDir, config.Files)
 		if err != nil {
-			return err
-		}
-		base, _ = strings.CutSuffix(base, ".json")
-		translations[base] = loadTranslation(path)
-
-		return nil
-	})
-
-	// Run the checks.
-	variableErrors := checkTranslationVariables(translations)
-	htmlErrors := checkTranslationHTML(translations)
-	for lang, _ := range translations {
-		if len(variableErrors[lang]) > 0 || len(htmlErrors[lang]) > 0 {
-			fmt.Fprintf(os.Stderr, "[%v]\n", lang)
-			for _, error := range variableErrors[lang] {
-				fmt.Fprintf(os.Stderr, "    %v\n", error)
+			log.Fatal(err)
+		}
+		if len(langs) == 0 {
+			return false
+		}
+		found = foundLanguages(translations, loadFindings)
+		// A changed english reference may break any language, otherwise only the staged ones are reported.
+		if !slices.Contains(langs, "en") {
+			for lang := range translations {
+				if lang != "en" && !slices.Contains(langs, lang) {
+					delete(translations, lang)
+				}
 			}
-			for _, error := range htmlErrors[lang] {
-				fmt.Fprintf(os.Stderr, "    %v\n", error)
+			loadFindings = slices.DeleteFunc(loadFindings, func(f Finding) bool {
+				return f.Lang != "" && f.Lang != "en" && !slices.Contains(langs, f.Lang)
+			})
+		}
+	} else if opts.strict {
+		paths, walkFindings := findTranslationFiles(rootDir, config.Files)
+		translations, loadFindings = loadStrictTranslations(paths, config.Files.Arrays)
+		loadFindings = append(walkFindings, loadFindings...)
+		found = foundLanguages(translations, loadFindings)
+	} else {
+		translations, loadFindings = loadTranslations(rootDir, config.Files)
+		found = foundLanguages(translations, loadFindings)
+	}
+	var release []string
+	if opts.release != "" {
+		var ok bool
+		if release, ok = config.Tiers[opts.release]; !ok {
+			log.Fatalf("run: no %q tier in the configuration", opts.release)
+		}
+	}
+	loadFindings = append(loadFindings, checkFoundLanguages(found, config, opts.release, release)...)
+
+	checks := loadChecks(config)
+	var context Translation
+	if dir != "" {
+		context = loadContext(dir)
+	}
+	if context != nil {
+		checks = append(checks, check{"context", checkContext(context)})
+	}
+	// The fuzzy entries and the schema are checked in the files of the working tree, and reported as skipped
+	// for the other sources, whose files may not be the same.
+	workingTree := !isRootFile(rootDir) && opts.gitRef == "" && !opts.staged
+	for _, skipped := range []struct {
+		name       string
+		configured bool
+	}{{"-fail-on-fuzzy", len(opts.failOnFuzzy) > 0}, {"the schema", config.Schema != ""}} {
+		if skipped.configured && !workingTree {
+			fmt.Fprintf(os.Stderr, "%v: %v is not checked, the translation files aren't the ones of the working tree\n", rootDir, skipped.name)
+		}
+	}
+	if len(opts.failOnFuzzy) > 0 && workingTree {
+		paths, _ := findTranslationFiles(rootDir, config.Files)
+		checks = append(checks, check{"fuzzy", checkFuzzy(paths, opts.failOnFuzzy)})
+	}
+	if config.Schema != "" && workingTree {
+		schema, err := loadSchema(config.Schema)
+		if err != nil {
+			log.Fatalf("loadSchema: %v", err)
+		}
+		paths, _ := findTranslationFiles(rootDir, config.Files)
+		checks = append(checks, check{"schema", checkSchema(schema, paths)})
+	}
+	var lock translationLock
+	if dir != "" {
+		lock = loadLock(dir)
+	}
+	if lock != nil {
+		checks = append(checks, check{"stale", checkStale(lock)})
+	}
+	checks = enabledChecks(checks, config.Rules)
+	var checkFindings []Finding
+	var checkTimings []timing
+	if opts.profile {
+		checkFindings, checkTimings = runTimedChecks(checks, translations)
+	} else {
+		checkFindings = runChecks(checks, translations)
+	}
+	findings := append(enabledFindings(loadFindings, config.Rules), checkFindings...)
+	if opts.newSince != "" {
+		base, baseFindings, err := loadRevisionTranslations(rootDir, opts.newSince, config.Files)
+		if err != nil {
+			log.Fatal(err)
+		}
+		baseFindings = append(baseFindings, checkFoundLanguages(foundLanguages(base, baseFindings), config, opts.release, release)...)
+		previous := append(enabledFindings(baseFindings, config.Rules), runChecks(checks, base)...)
+		findings = newFindings(previous, findings)
+	}
+	findings = newFindings(loadBaseline(baselinePath(opts.baselinePath, config)), findings)
+	addContext(findings, context)
+	if config.Owners != "" {
+		owners, err := loadOwners(config.Owners)
+		if err != nil {
+			log.Fatalf("loadOwners: %v", err)
+		}
+		addOwners(findings, owners)
+	}
+	limits := reportLimits{opts.maxErrors, opts.maxLangErrors}
+	switch {
+	case opts.format == formatCompact:
+		paths, _ := findTranslationFiles(rootDir, config.Files)
+		reportCompact(os.Stdout, findings, config.Rules, rootDir, paths, limits)
+	case opts.format == formatAzure:
+		paths, _ := findTranslationFiles(rootDir, config.Files)
+		reportAzure(os.Stdout, findings, config.Rules, paths, limits)
+	case opts.format == formatTeamCity:
+		paths, _ := findTranslationFiles(rootDir, config.Files)
+		reportTeamCity(os.Stdout, findings, config.Rules, sortedKeys(translations), paths, languageBudgets(config), limits)
+	case opts.format == formatJenkins:
+		paths, _ := findTranslationFiles(rootDir, config.Files)
+		if err := reportJenkins(os.Stdout, findings, config.Rules, rootDir, paths); err != nil {
+			log.Fatalf("reportJenkins: %v", err)
+		}
+	case config.Owners != "":
+		reportByOwner(os.Stderr, findings, config.Rules, limits)
+	default:
+		reportTextLimited(os.Stderr, findings, config.Rules, limits)
+	}
+	reportSummary(os.Stderr, translations, findings, config.Rules, time.Since(start))
+	if opts.profile {
+		reportProfile(os.Stderr, checkTimings, timeLanguages(checks, translations))
+	}
+	budgets := languageBudgets(config)
+	exceeded := overBudget(findings, config.Rules, budgets)
+	reportBudgets(os.Stderr, exceeded)
+	failed := failsBudgets(findings, config.Rules, budgets)
+	// Instead of the errors, -release fails on the findings blocking the release and -ratchet on the
+	// counts which grow, either of them failing the run when both are given, as the exceeded budgets do.
+	if opts.release != "" || opts.ratchet != "" {
+		failed = len(exceeded) > 0
+	}
+	if opts.release != "" {
+		blocking := blockingFindings(findings, config.Rules, release)
+		reportRelease(os.Stderr, opts.release, release, findings, blocking)
+		failed = failed || len(blocking) > 0
+	}
+	if opts.ratchet != "" {
+		// The first run records the current counts.
+		current := countErrors(findings, config.Rules)
+		recorded := loadRatchet(opts.ratchet)
+		previous := recorded
+		if previous == nil {
+			previous = current
+		}
+		increases, tightened := ratchet(previous, current)
+		reportRatchet(os.Stderr, opts.ratchet, increases)
+		failed = failed || len(increases) > 0
+		if recorded == nil || !equalCounts(tightened, recorded) {
+			if err := writeRatchet(opts.ratchet, tightened); err != nil {
+				log.Fatalf("writeRatchet: %v", err)
 			}
 		}
 	}
 
-	if len(variableErrors) > 0 || len(htmlErrors) > 0 {
-		os.Exit(1)
+	if opts.history != "" {
+		// Outside of a git repository, the runs are only known by their time.
+		commit, _ := gitOutput("rev-parse", "HEAD")
+		record := summarizeRun(translations, findings, config.Rules, config.Fallbacks, start, strings.TrimSpace(string(commit)))
+		if err := appendHistory(opts.history, record); err != nil {
+			log.Fatalf("appendHistory: %v", err)
+		}
+	}
+
+	if opts.suggestPatch != "" {
+		paths, _ := findTranslationFiles(rootDir, config.Files)
+		patch, fixed, err := suggestPatch(translations, findings, paths)
+		if err != nil {
+			log.Fatalf("suggestPatch: %v", err)
+		}
+		if err := os.WriteFile(opts.suggestPatch, []byte(patch), 0644); err != nil {
+			log.Fatalf("suggestPatch: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "%v: fixes of %v strings\n", opts.suggestPatch, fixed)
 	}
+
+	if opts.updateLock && dir != "" {
+		if err := writeLock(dir, updateLock(lock, translations)); err != nil {
+			log.Fatalf("writeLock: %v", err)
+		}
+	}
+
+	if opts.notifyWebhook != "" {
+		if err := notify(opts.notifyWebhook, opts.notifyFormat, rootDir, findings, config.Rules, config.Files.Arrays); err != nil {
+			log.Printf("notify: %v", err)
+		}
+	}
+
+	return failed
 }
 
-func processArgs() string {
-	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "usage:\n    %v <translation-root-dir>\n", os.Args[0])
-		os.Exit(1)
+// The formats of the report of the default check mode.
+const (
+	formatText     = "text"
+	formatCompact  = "compact"
+	formatAzure    = "azure"
+	formatTeamCity = "teamcity"
+	formatJenkins  = "jenkins"
+)
+
+// formats are the formats of the report of the default check mode.
+var formats = []string{formatText, formatCompact, formatAzure, formatTeamCity, formatJenkins}
+
+// options are the command line options of the default check mode.
+type options struct {
+	rootDir    string
+	configPath string
+	// baselinePath is the file of accepted findings, which are not reported.
+	baselinePath string
+	// suggestPatch is the file the unified diffs fixing the findings with a mechanical fix are written to.
+	suggestPatch string
+	// updateLock records the current translations in the lock file used to detect stale ones.
+	updateLock bool
+	// failOnFuzzy lists the languages whose fuzzy PO entries are reported.
+	failOnFuzzy listFlag
+	// strict validates the syntax of the translation files, reporting the problems instead of stopping.
+	strict bool
+	// followSymlinks walks the linked directories of rootDir too.
+	followSymlinks bool
+	// maxDepth limits how deep the translation files are looked for, noRecursive to rootDir itself.
+	maxDepth    int
+	noRecursive bool
+	// noIgnore reads the translation files ignored by the ignore files too.
+	noIgnore bool
+	// pattern matches the names of the translation files, capturing their language.
+	pattern string
+	// languages restricts the checked languages to these ones, and english.
+	languages listFlag
+	// excludeLanguages are the languages which aren't checked.
+	excludeLanguages listFlag
+	// enable and disable turn the rules, by identifier or name, on and off over the configured ones.
+	enable  listFlag
+	disable listFlag
+	// release is the tier of languages being released, whose findings only fail the run.
+	release string
+	// staged reads the translations from the git index and only reports the staged languages.
+	staged bool
+	// gitRef is the git revision the translations are read from, instead of the working tree.
+	gitRef string
+	// newSince is the git revision whose findings aren't reported, only the new ones.
+	newSince string
+	// history is the file the summaries of the runs are appended to, shown by the trend command.
+	history string
+	// ratchet is the file of the counts of errors by language and rule, which fail the run only if they grow.
+	ratchet string
+	// reportLang is the language the findings are reported in, with a translation in locales.
+	reportLang string
+	// format is how the findings are reported, one of formats.
+	format string
+	// maxErrors limits the number of findings reported, overall and per language.
+	maxErrors     int
+	maxLangErrors int
+	// profile reports how long the checks took, and cpuProfile is a file the pprof CPU profile is written to.
+	profile    bool
+	cpuProfile string
+	// notifyWebhook is the URL the summary of the findings is posted to, in the notifyFormat.
+	notifyWebhook string
+	notifyFormat  string
+}
+
+func processArgs() (opts options, err error) {
+	flag.StringVar(&opts.configPath, "config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flag.StringVar(&opts.baselinePath, "baseline", "",
+		fmt.Sprintf("file of accepted findings, which are not reported (default %v, if it exists)", defaultBaselineFile))
+	flag.BoolVar(&opts.updateLock, "update-lock", false,
+		fmt.Sprintf("record the current translations in %v, to report the stale ones later", lockFile))
+	flag.StringVar(&opts.suggestPatch, "suggest-patch", "",
+		"write unified diffs of the translation files fixing the findings with a mechanical fix to this file")
+	flag.Var(&opts.failOnFuzzy, "fail-on-fuzzy", "report the fuzzy entries of the PO files of these comma separated languages, can be repeated")
+	flag.BoolVar(&opts.strict, "strict", false,
+		"strictly validate the translation files, reporting their problems with their line and column")
+	flag.BoolVar(&opts.followSymlinks, "follow-symlinks", false,
+		"walk the linked directories and read the linked translation files of the translation root dir")
+	flag.IntVar(&opts.maxDepth, "max-depth", 0,
+		"only look for translation files this many levels deep, 1 being the translation root dir itself (default unlimited)")
+	flag.BoolVar(&opts.noRecursive, "no-recursive", false,
+		"only look for translation files in the translation root dir itself, same as -max-depth 1")
+	flag.BoolVar(&opts.noIgnore, "no-ignore", false,
+		"read the translation files ignored by .gitignore and .check-translationsignore files too")
+	flag.StringVar(&opts.pattern, "pattern", "",
+		"glob or regular expression matching the translation file names, with the language as the first * or group "+
+			"(default ??.json and ??.po)")
+	flag.Var(&opts.languages, "languages", "only check these comma separated languages, and english, can be repeated")
+	flag.Var(&opts.excludeLanguages, "exclude-languages", "don't check these comma separated languages, can be repeated")
+	flag.Var(&opts.enable, "enable", "check these comma separated rules, turned off in the configuration, can be repeated")
+	flag.Var(&opts.disable, "disable", "don't check these comma separated rules, can be repeated")
+	flag.StringVar(&opts.release, "release", "",
+		"tier of the configuration being released: only its languages must pass, the others only warn")
+	flag.BoolVar(&opts.staged, "staged", false,
+		"check the translation files staged in git, reporting only the changed languages")
+	flag.StringVar(&opts.gitRef, "git-ref", "",
+		"check the translation files of this git revision, like a tag, instead of the ones of the working tree")
+	flag.StringVar(&opts.newSince, "new-since", "",
+		"only report the findings which the translation files of this git revision, like origin/main, don't have")
+	flag.StringVar(&opts.format, "format", formatText,
+		fmt.Sprintf("format of the report: %v, %v for one file:line:col: finding per line on standard output, "+
+			"%v for Azure Pipelines logging commands, %v for TeamCity service messages "+
+			"or %v for the JSON issues of the Jenkins Warnings plugin on standard output",
+			formatText, formatCompact, formatAzure, formatTeamCity, formatJenkins))
+	flag.StringVar(&opts.history, "history", "",
+		"append the counts of findings and the coverage by language of the run to this JSON Lines file, shown by the trend command")
+	flag.StringVar(&opts.ratchet, "ratchet", "",
+		"only fail if the counts of errors by language and rule recorded in this file grow, recording them when they shrink")
+	flag.StringVar(&opts.reportLang, "lang", "en", "language of the report: en or sv")
+	flag.IntVar(&opts.maxErrors, "max-errors", 0, "only report this many findings (default unlimited)")
+	flag.IntVar(&opts.maxLangErrors, "max-errors-per-lang", 0, "only report this many findings of each language (default unlimited)")
+	flag.BoolVar(&opts.profile, "profile", false, "report how long every check and the checks of every language took")
+	flag.StringVar(&opts.cpuProfile, "cpuprofile", "", "write a pprof CPU profile of the run to this file")
+	flag.StringVar(&opts.notifyWebhook, "notify-webhook", "", "post a summary of the findings to this webhook URL")
+	flag.StringVar(&opts.notifyFormat, "notify-format", notifySlack,
+		fmt.Sprintf("payload format of the webhook: %v or %v", notifySlack, notifyJSON))
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v [flags] <translation-root-dir>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "    %v [flags], with projects in the configuration file\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if !slices.Contains(formats, opts.format) {
+		return opts, fmt.Errorf("unknown format %q", opts.format)
+	}
+	if flag.NArg() < 1 {
+		return opts, nil
+	}
+
+	opts.rootDir = flag.Arg(0)
+	if opts.newSince != "" && isRootFile(opts.rootDir) {
+		return opts, errors.New("-new-since compares a translation root dir of the repository, not a file")
+	}
+	if opts.gitRef != "" {
+		if opts.staged || isRootFile(opts.rootDir) {
+			return opts, errors.New("-git-ref reads a translation root dir of the repository, not the staged files or a file")
+		}
+		// The directory may not exist in the working tree anymore.
+		return opts, nil
+	}
+	return opts, checkRoot(opts.rootDir)
+}
+
+// listFlag is a flag of comma separated values, which can be repeated, collecting all the values.
+type listFlag []string
+
+func (f *listFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *listFlag) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*f = append(*f, v)
+		}
 	}
+	return nil
+}
 
-	rootDir := os.Args[1]
+// checkRootDir returns an error unless rootDir is a readable directory.
+func checkRootDir(rootDir string) error {
 	file, err := os.Open(rootDir)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	defer file.Close()
 
 	info, err := file.Stat()
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	if !info.IsDir() {
-		log.Fatal("must exist and be a readable directory: ", rootDir)
+		return fmt.Errorf("must exist and be a readable directory: %v", rootDir)
 	}
-
-	return os.Args[1]
+	return nil
 }
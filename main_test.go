@@ -1,8 +1,12 @@
 package main
 
 import (
+	"maps"
+	"os"
+	"path/filepath"
 	"slices"
 	"testing"
+	"time"
 )
 
 func TestCheckHTML(t *testing.T) {
@@ -29,3 +33,114 @@ func TestCheckHTML(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadTranslationsErrors(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{"a": "A"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "sv.json"), []byte(`{"a": `), 0644)
+	os.WriteFile(filepath.Join(dir, "de.json"), []byte(`{"a": "A"}`), 0644)
+
+	translations, findings := loadTranslations(dir, FilesConfig{})
+	if len(translations) != 2 || translations["de"]["a"] != "A" {
+		t.Errorf("unexpected translations: %v", translations)
+	}
+	if len(findings) != 1 || findings[0].Lang != "sv" || findings[0].Check != "load" {
+		t.Errorf("unexpected findings: %v", findings)
+	}
+}
+
+func TestLoadTranslationsSkippedValues(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{"a": "A"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "sv.json"), []byte(`{"a": "A", "meta": {"v": 1}}`), 0644)
+
+	translations, findings := loadTranslations(dir, FilesConfig{})
+	if !maps.Equal(translations["sv"], Translation{"a": "A"}) {
+		t.Errorf("unexpected translation: %v", translations["sv"])
+	}
+	if len(findings) != 1 || findings[0].Lang != "sv" || findings[0].Key != "meta" || findings[0].Check != "value" {
+		t.Fatalf("unexpected findings: %v", findings)
+	}
+	if !(ruleSeverities{}).isWarning(findings[0]) {
+		t.Errorf("want the skipped value to be a warning by default")
+	}
+}
+
+func TestCheckTranslationRoot(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{"a": "Hello $name$", "b": "<b>bold</b>"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "sv.json"), []byte(`{"a": "Hej", "b": "<b>fet"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "de.json"), []byte(`{"a": `), 0644)
+	rules, err := resolveRules(map[string]string{"FILE002": "off", "VAR001": "warning"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := Config{Rules: rules}
+
+	paths, translations, findings := checkTranslationRoot(dir, config)
+	if len(paths) != 3 || len(translations) != 2 {
+		t.Errorf("want 3 files and 2 translations, got %v and %v", paths, translations)
+	}
+	checks := make(map[string]bool)
+	for _, finding := range findings {
+		checks[finding.Check] = config.Rules.isWarning(finding)
+	}
+	if want := map[string]bool{"variables": true, "html": false}; !maps.Equal(checks, want) {
+		t.Errorf("want checks and their warnings %v, got %v", want, checks)
+	}
+	if !failsBudgets(findings, config.Rules, nil) {
+		t.Error("want the error to fail the run")
+	}
+	var warnings []Finding
+	for _, finding := range findings {
+		if finding.Check == "variables" {
+			warnings = append(warnings, finding)
+		}
+	}
+	if failsBudgets(warnings, config.Rules, nil) {
+		t.Errorf("want the warnings not to fail the run: %v", warnings)
+	}
+}
+
+func TestRunReleaseRatchet(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{"a": "Hello $name$"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "sv.json"), []byte(`{"a": "Hej"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "de.json"), []byte(`{"a": "Hallo $name$"}`), 0644)
+	ratchetPath := filepath.Join(t.TempDir(), "counts.json")
+	// The error of sv is recorded, so it doesn't fail the ratchet.
+	os.WriteFile(ratchetPath, []byte(`{"sv": {"VAR001": 1}}`), 0644)
+	opts := options{baselinePath: filepath.Join(dir, "baseline.json"), ratchet: ratchetPath}
+	config := Config{Tiers: map[string][]string{"tier-1": {"sv"}, "tier-2": {"de"}}}
+
+	if run(dir, opts, config, time.Now()) {
+		t.Error("-ratchet: want the recorded error not to fail the run")
+	}
+	opts.release = "tier-2"
+	if run(dir, opts, config, time.Now()) {
+		t.Error("-ratchet -release tier-2: want the error of another tier not to fail the run")
+	}
+	opts.release = "tier-1"
+	if !run(dir, opts, config, time.Now()) {
+		t.Error("-ratchet -release tier-1: want the error blocking the release to fail the run")
+	}
+}
+
+func TestRunBudgets(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{"a": "Hello $name$"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "sv.json"), []byte(`{"a": "Hej $name$"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "de.json"), []byte(`{"a": "Hallo"}`), 0644)
+	ratchetPath := filepath.Join(t.TempDir(), "counts.json")
+	os.WriteFile(ratchetPath, []byte(`{"de": {"VAR001": 1}}`), 0644)
+	config := Config{
+		Tiers:   map[string][]string{"tier-1": {"sv"}},
+		Budgets: map[string]Budget{"de": {Errors: 0}},
+	}
+	for _, opts := range []options{{ratchet: ratchetPath}, {release: "tier-1"}, {release: "tier-1", ratchet: ratchetPath}} {
+		opts.baselinePath = filepath.Join(dir, "baseline.json")
+		if !run(dir, opts, config, time.Now()) {
+			t.Errorf("-release %q -ratchet %q: want the exceeded budget of de to fail the run", opts.release, opts.ratchet)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// markerRx matches the markers of unfinished texts: TODO, FIXME, XXX, TRANSLATE ME, and the [##]
+// draft markers of vendors. The words are matched in capitals only, since todo is a Spanish word.
+var markerRx = regexp.MustCompile(`\b(?:TODO|FIXME|XXX)\b|(?i:\btranslate me\b)|\[##\]`)
+
+// checkMarkers reports the texts with markers of unfinished work, which must not be shipped.
+// The markers copied from the english text are only reported in english.
+func checkMarkers(translations map[string]Translation) (result []Finding) {
+	for lang, translation := range translations {
+		for key, translated := range translation {
+			for _, marker := range markerRx.FindAllString(translated, -1) {
+				if lang != "en" && strings.Contains(translations["en"][key], marker) {
+					continue
+				}
+				result = append(result, Finding{
+					Lang:    lang,
+					Key:     key,
+					Check:   "markers",
+					Message: fmt.Sprintf("unfinished, marked %v: %v", marker, translated),
+				})
+			}
+		}
+	}
+	return result
+}
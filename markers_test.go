@@ -0,0 +1,26 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCheckMarkers(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {"a": "Sign TODO", "b": "Save", "c": "Card ending in XXXX", "d": "Done"},
+		"es": {"a": "Firmar TODO", "b": "Guardar todo", "c": "Tarjeta terminada en XXXX", "d": "[##] Hecho"},
+		"sv": {"b": "Translate me", "d": "Klar FIXME: kolla med juristerna"},
+	}
+	want := []Finding{
+		{Lang: "en", Key: "a", Check: "markers", Message: "unfinished, marked TODO: Sign TODO"},
+		{Lang: "es", Key: "d", Check: "markers", Message: "unfinished, marked [##]: [##] Hecho"},
+		{Lang: "sv", Key: "b", Check: "markers", Message: "unfinished, marked Translate me: Translate me"},
+		{Lang: "sv", Key: "d", Check: "markers", Message: "unfinished, marked FIXME: Klar FIXME: kolla med juristerna"},
+	}
+	findings := checkMarkers(translations)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+}
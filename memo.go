@@ -0,0 +1,26 @@
+package main
+
+import "slices"
+
+// memoize returns f caching its results by argument, so that the strings repeated under many keys
+// and languages, like the labels of buttons, are only processed once. The cache lives as long as
+// the returned function, usually a single run of a check, and isn't safe for concurrent use.
+// The results are shared and must not be modified.
+func memoize[T any](f func(string) T) func(string) T {
+	results := make(map[string]T)
+	return func(s string) T {
+		result, ok := results[s]
+		if !ok {
+			result = f(s)
+			results[s] = result
+		}
+		return result
+	}
+}
+
+// sortedVariables returns the sorted variables of s, e.g. [$count$ $name$].
+func sortedVariables(s string) []string {
+	variables := variableRx.FindAllString(s, -1)
+	slices.Sort(variables)
+	return variables
+}
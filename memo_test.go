@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestMemoize(t *testing.T) {
+	calls := 0
+	tags := memoize(func(s string) []string {
+		calls++
+		return htmlTags(s)
+	})
+	for _, s := range []string{"<b>Save</b>", "Open", "<b>Save</b>", "<b>Save</b>", "Open"} {
+		tags(s)
+	}
+	if calls != 2 {
+		t.Errorf("want 2 calls, got %v", calls)
+	}
+	if got := tags("<b>Save</b>"); len(got) != 1 || got[0] != "b" {
+		t.Errorf("unexpected cached result: %v", got)
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// mergeDelivery merges the strings of a vendor delivery into the catalog of lang, against the english
+// reference en. The delivered strings are first run through the checks, and the ones with findings
+// are rejected, as well as the ones of keys missing from en. A translation of the catalog is only
+// overwritten with force, or when lock shows it went stale since it was translated.
+// It returns the merged catalog, the keys which changed, and the findings of the rejected strings.
+func mergeDelivery(lang string, en, catalog, delivery Translation, checks []check, lock translationLock, force bool) (Translation, []string, []Finding) {
+	var findings []Finding
+	reject := func(key, format string, args ...any) {
+		findings = append(findings, Finding{Lang: lang, Key: key, Check: "merge", Message: fmt.Sprintf(format, args...)})
+	}
+
+	// The checks only see the delivered strings, so that the ones missing from the delivery don't count.
+	enDelivered := Translation{}
+	delivered := Translation{}
+	for key, translated := range delivery {
+		if enString, ok := en[key]; ok {
+			enDelivered[key] = enString
+			delivered[key] = translated
+		}
+	}
+	checkFindings := runChecks(checks, map[string]Translation{"en": enDelivered, lang: delivered})
+	invalid := make(map[string]bool)
+	for _, finding := range checkFindings {
+		if finding.Lang == lang && finding.Key != "" {
+			invalid[finding.Key] = true
+			findings = append(findings, finding)
+		}
+	}
+
+	merged := maps.Clone(catalog)
+	if merged == nil {
+		merged = Translation{}
+	}
+	var changed []string
+	for _, key := range sortedKeys(delivery) {
+		translated := delivery[key]
+		existing := catalog[key]
+		_, known := en[key]
+		entry, locked := lock[lang][key]
+		stale := locked && entry.Translation == hashString(existing) && entry.Source != hashString(en[key])
+		switch {
+		case !known:
+			reject(key, "unknown key, not in the english reference: %v", translated)
+		case invalid[key], translated == existing:
+		case existing != "" && !force && !stale:
+			reject(key, "not overwriting the current translation without -force: %v ⇒ %v", existing, translated)
+		default:
+			merged[key] = translated
+			changed = append(changed, key)
+		}
+	}
+	return merged, changed, findings
+}
+
+// merge merges a partial translation file delivered by a vendor into a <lang>.json.
+func merge(args []string) {
+	flags := flag.NewFlagSet("merge", flag.ExitOnError)
+	into := flags.String("into", "", "translation file the delivery is merged into, like de.json")
+	enPath := flags.String("en", "", "english reference (default en.json next to the -into file)")
+	force := flags.Bool("force", false, "overwrite the current translations with the delivered ones")
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v merge [flags] <delivery.json|delivery.xlsx> -into <lang>.json\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	// The flags may follow the delivery too.
+	deliveryPath := flags.Arg(0)
+	flags.Parse(flags.Args()[1:])
+	if *into == "" || flags.NArg() > 0 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	if *enPath == "" {
+		*enPath = filepath.Join(filepath.Dir(*into), "en.json")
+	}
+
+	config := loadConfig(*configPath)
+	match, err := newFileMatcher(config.Files.Pattern)
+	if err != nil {
+		log.Fatalf("merge: %v", err)
+	}
+	lang, ok := match(*into)
+	if !ok {
+		lang = translationLang(*into)
+	}
+	en, _, err := loadTranslation(*enPath, config.Files.Arrays)
+	if err != nil {
+		log.Fatalf("merge: %v", err)
+	}
+	var delivery Translation
+	if isWorkbookPath(deliveryPath) {
+		workbook, findings := loadWorkbook(deliveryPath)
+		if len(findings) > 0 {
+			reportText(os.Stderr, findings, config.Rules)
+			os.Exit(1)
+		}
+		if delivery, ok = workbook[lang]; !ok {
+			log.Fatalf("merge: %v: no %v column", deliveryPath, lang)
+		}
+	} else if delivery, _, err = loadTranslation(deliveryPath, config.Files.Arrays); err != nil {
+		log.Fatalf("merge: %v", err)
+	}
+	catalog, raw, err := loadTranslation(*into, config.Files.Arrays)
+	if err != nil && !os.IsNotExist(err) {
+		log.Fatalf("merge: %v", err)
+	}
+
+	lock := loadLock(filepath.Dir(*enPath))
+	merged, changed, findings := mergeDelivery(lang, en, catalog, delivery, loadChecks(config), lock, *force)
+	if len(changed) > 0 {
+		if err := writeTranslation(*into, merged, raw); err != nil {
+			log.Fatalf("merge: %v: %v", *into, err)
+		}
+	}
+	reportText(os.Stderr, findings, config.Rules)
+	var rejected []string
+	for _, finding := range findings {
+		rejected = append(rejected, finding.Key)
+	}
+	slices.Sort(rejected)
+	fmt.Printf("%v: %v strings merged, %v rejected\n", *into, len(changed), len(slices.Compact(rejected)))
+	if len(findings) > 0 {
+		os.Exit(1)
+	}
+}
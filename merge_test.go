@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMergeDelivery(t *testing.T) {
+	en := Translation{
+		"save":   "Save",
+		"open":   "Open $file$",
+		"close":  "Close",
+		"delete": "Delete",
+		"bold":   "<b>Bold</b>",
+	}
+	catalog := Translation{"save": "Speichern", "close": "Schließen", "delete": "Löschen"}
+	delivery := Translation{
+		"save":    "Speichern",     // unchanged
+		"open":    "Öffne $datei$", // broken variable
+		"close":   "Zumachen",      // overwrites a current translation
+		"delete":  "Entfernen",     // overwrites a stale translation
+		"bold":    "<b>Fett</b>",
+		"removed": "Entfernt",
+	}
+	// delete was translated from an older english source.
+	lock := translationLock{"de": {
+		"close":  {hashString("Close"), hashString("Schließen")},
+		"delete": {hashString("Remove"), hashString("Löschen")},
+	}}
+
+	merged, changed, findings := mergeDelivery("de", en, catalog, delivery, builtinChecks, lock, false)
+	want := Translation{"save": "Speichern", "close": "Schließen", "delete": "Entfernen", "bold": "<b>Fett</b>"}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("want %v, got %v", want, merged)
+	}
+	if want := []string{"bold", "delete"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("want changed %v, got %v", want, changed)
+	}
+	var rejected []string
+	for _, finding := range findings {
+		rejected = append(rejected, finding.Check+" "+finding.Key)
+	}
+	if want := []string{"variables open", "merge close", "merge removed"}; !reflect.DeepEqual(rejected, want) {
+		t.Errorf("want rejected %v, got %v", want, rejected)
+	}
+	if catalog["delete"] != "Löschen" {
+		t.Error("the catalog was modified")
+	}
+
+	merged, _, _ = mergeDelivery("de", en, catalog, delivery, builtinChecks, nil, true)
+	if merged["close"] != "Zumachen" {
+		t.Errorf("forced: want Zumachen, got %v", merged["close"])
+	}
+}
+
+func TestMergeKeepsOtherValues(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{"save": "Save", "open": "Open"}`), 0644)
+	into := filepath.Join(dir, "de.json")
+	os.WriteFile(into, []byte(`{"save": "Speichern", "meta": {"v": 1}, "n": 3}`), 0644)
+	deliveryPath := filepath.Join(t.TempDir(), "delivery.json")
+	os.WriteFile(deliveryPath, []byte(`{"open": "Öffnen"}`), 0644)
+
+	merge([]string{deliveryPath, "-into", into})
+	bs, err := os.ReadFile(into)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(bs, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"save": "Speichern", "open": "Öffnen", "meta": map[string]any{"v": 1.0}, "n": 3.0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// catalogMetrics runs the checks on translations and describes the results in the
+// Prometheus text exposition format, counting the errors of the severities of rules only.
+// The coverage follows fallbacks, see translationCoverage.
+func catalogMetrics(checks []check, translations map[string]Translation, rules ruleSeverities, fallbacks map[string]string) []byte {
+	var langs []string
+	for lang := range translations {
+		langs = append(langs, lang)
+	}
+	slices.Sort(langs)
+
+	counts := make(map[string]int)
+	durations := make([]time.Duration, len(checks))
+	for i, check := range checks {
+		start := time.Now()
+		for _, finding := range check.run(translations) {
+			if !rules.isWarning(finding) {
+				counts[finding.Lang]++
+			}
+		}
+		durations[i] = time.Since(start)
+	}
+
+	var buf bytes.Buffer
+	metric := func(name, help string) {
+		fmt.Fprintf(&buf, "# HELP %v %v\n# TYPE %v gauge\n", name, help, name)
+	}
+
+	metric("check_translations_languages", "Number of languages in the catalog.")
+	fmt.Fprintf(&buf, "check_translations_languages %v\n", len(langs))
+
+	metric("check_translations_keys", "Number of keys per language.")
+	for _, lang := range langs {
+		fmt.Fprintf(&buf, "check_translations_keys{lang=%v} %v\n", labelValue(lang), len(translations[lang]))
+	}
+
+	metric("check_translations_findings", "Number of errors found per language.")
+	for _, lang := range langs {
+		fmt.Fprintf(&buf, "check_translations_findings{lang=%v} %v\n", labelValue(lang), counts[lang])
+	}
+
+	metric("check_translations_coverage_percent", "Percentage of the english keys translated per language.")
+	coverage := translationCoverage(translations, fallbacks)
+	for _, lang := range sortedKeys(coverage) {
+		fmt.Fprintf(&buf, "check_translations_coverage_percent{lang=%v} %v\n", labelValue(lang), coverage[lang])
+	}
+
+	metric("check_translations_check_duration_seconds", "Time taken by each check on the whole catalog.")
+	for i, check := range checks {
+		fmt.Fprintf(&buf, "check_translations_check_duration_seconds{check=%v} %v\n",
+			labelValue(check.name), durations[i].Seconds())
+	}
+	return buf.Bytes()
+}
+
+// translationCoverage returns the percentage of the english keys translated by every language but english,
+// in the language itself or in one of the languages it falls back to, following fallbacks.
+func translationCoverage(translations map[string]Translation, fallbacks map[string]string) map[string]float64 {
+	coverage := make(map[string]float64)
+	en := translations["en"]
+	if len(en) == 0 {
+		return coverage
+	}
+	for lang := range translations {
+		if lang == "en" {
+			continue
+		}
+		translated := 0
+		for key := range en {
+			if isTranslated(translations, fallbacks, lang, key) {
+				translated++
+			}
+		}
+		coverage[lang] = 100 * float64(translated) / float64(len(en))
+	}
+	return coverage
+}
+
+// labelValue quotes s as a Prometheus label value.
+func labelValue(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
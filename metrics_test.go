@@ -0,0 +1,48 @@
+package main
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestCatalogMetrics(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {"a": "$x$ items", "b": "<b>bold</b>", "c": "text", "d": "more"},
+		"sv": {"a": "$y$ saker", "b": "<b>fet"},
+		"de": {"a": "$x$ Dinge"},
+	}
+	metrics := string(catalogMetrics(builtinChecks, translations, newRuleSeverities(map[string]string{"html": severityWarning}), nil))
+	for _, want := range []string{
+		"check_translations_languages 3\n",
+		`check_translations_keys{lang="sv"} 2` + "\n",
+		`check_translations_findings{lang="de"} 0` + "\n",
+		`check_translations_findings{lang="sv"} 1` + "\n",
+		`check_translations_coverage_percent{lang="sv"} 50` + "\n",
+		`check_translations_coverage_percent{lang="de"} 25` + "\n",
+		`check_translations_check_duration_seconds{check="html"} `,
+		"# TYPE check_translations_findings gauge\n",
+	} {
+		if !strings.Contains(metrics, want) {
+			t.Errorf("want %q in:\n%v", want, metrics)
+		}
+	}
+	if strings.Contains(metrics, `check_translations_coverage_percent{lang="en"}`) {
+		t.Errorf("want no coverage for the reference:\n%v", metrics)
+	}
+}
+
+func TestTranslationCoverage(t *testing.T) {
+	translations := map[string]Translation{
+		"en":    {"a": "Color", "b": "Save", "c": "Open", "d": "Close"},
+		"pt":    {"b": "Salvar", "c": "Abrir"},
+		"pt-BR": {"a": "Cor"},
+	}
+	want := map[string]float64{"pt": 50, "pt-BR": 75}
+	if got := translationCoverage(translations, map[string]string{"pt-BR": "pt"}); !maps.Equal(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if got := translationCoverage(translations, nil)["pt-BR"]; got != 25 {
+		t.Errorf("without fallbacks: want 25, got %v", got)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Formats of the notification payloads.
+const (
+	// notifySlack is a Slack incoming webhook message, also understood by Mattermost and others.
+	notifySlack = "slack"
+	// notifyJSON is a generic JSON summary with all the findings.
+	notifyJSON = "json"
+)
+
+// notification is the payload of the generic JSON format.
+type notification struct {
+	Source    string         `json:"source"`
+	Total     int            `json:"total"`
+	Languages map[string]int `json:"languages"`
+	// Owners counts the findings of every owner, if the keys have owners, the unowned ones being under "".
+	Owners   map[string]int `json:"owners,omitempty"`
+	Findings []jsonFinding  `json:"findings"`
+}
+
+// notify posts a summary of the errors among findings in source, e.g. the checked directory, to a webhook,
+// with the severities of rules and the pointers of the arrays read as arrays says. The warnings are left out,
+// and nothing is posted if there are no errors.
+func notify(url, format, source string, findings []Finding, rules ruleSeverities, arrays string) error {
+	findings = slices.DeleteFunc(slices.Clone(findings), rules.isWarning)
+	if len(findings) == 0 {
+		return nil
+	}
+	counts := make(map[string]int)
+	for _, finding := range findings {
+		counts[finding.Lang]++
+	}
+	// The owners are only listed when the keys have some.
+	var owners map[string]int
+	if slices.ContainsFunc(findings, func(f Finding) bool { return f.Owner != "" }) {
+		owners = make(map[string]int)
+		for _, finding := range findings {
+			owners[finding.Owner]++
+		}
+	}
+
+	var payload any
+	switch format {
+	case notifySlack:
+		var langs []string
+		for lang := range counts {
+			langs = append(langs, lang)
+		}
+		slices.Sort(langs)
+		var text strings.Builder
+		fmt.Fprintf(&text, "check-translations found %v errors in %v:", len(findings), source)
+		for _, lang := range langs {
+			fmt.Fprintf(&text, "\n• %v: %v", lang, counts[lang])
+		}
+		if owners != nil {
+			text.WriteString("\nby owner:")
+			for _, owner := range sortedKeys(owners) {
+				name := owner
+				if owner == "" {
+					name = "unowned"
+				}
+				fmt.Fprintf(&text, "\n• %v: %v", name, owners[owner])
+			}
+		}
+		payload = map[string]string{"text": text.String()}
+	case notifyJSON:
+		payload = notification{Source: source, Total: len(findings), Languages: counts, Owners: owners, Findings: jsonFindings(findings, rules, arrays)}
+	default:
+		return fmt.Errorf("unknown notification format: %v", format)
+	}
+
+	client := &apiClient{base: url}
+	return client.do(http.MethodPost, "", payload, nil)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNotify(t *testing.T) {
+	var payloads []map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		json.NewDecoder(r.Body).Decode(&payload)
+		payloads = append(payloads, payload)
+	}))
+	defer ts.Close()
+
+	findings := []Finding{
+		{Lang: "sv", Key: "a", Check: "html", Message: "one"},
+		{Lang: "de", Key: "a", Check: "html", Message: "two"},
+		{Lang: "sv", Key: "b", Check: "variables", Message: "three"},
+		{Lang: "de", Key: "b", Check: "identical", Message: "a warning"},
+	}
+	if err := notify(ts.URL, notifySlack, "locales", findings, ruleSeverities{}, arraysUnsupported); err != nil {
+		t.Fatal(err)
+	}
+	if err := notify(ts.URL, notifyJSON, "locales", findings, ruleSeverities{}, arraysUnsupported); err != nil {
+		t.Fatal(err)
+	}
+	if err := notify(ts.URL, notifyJSON, "locales", nil, ruleSeverities{}, arraysUnsupported); err != nil {
+		t.Fatal(err)
+	}
+	if err := notify(ts.URL, notifyJSON, "locales", findings[3:], ruleSeverities{}, arraysUnsupported); err != nil {
+		t.Fatal(err)
+	}
+	if err := notify(ts.URL, "xml", "locales", findings, ruleSeverities{}, arraysUnsupported); err == nil {
+		t.Errorf("want error for an unknown format")
+	}
+
+	if len(payloads) != 2 {
+		t.Fatalf("want 2 payloads, got %v", payloads)
+	}
+	if want := "check-translations found 3 errors in locales:\n• de: 1\n• sv: 2"; payloads[0]["text"] != want {
+		t.Errorf("slack: want %q, got %q", want, payloads[0]["text"])
+	}
+	if payloads[1]["total"] != 3.0 || payloads[1]["languages"].(map[string]any)["sv"] != 2.0 || payloads[1]["owners"] != nil ||
+		len(payloads[1]["findings"].([]any)) != 3 {
+		t.Errorf("json: unexpected payload %v", payloads[1])
+	}
+
+	findings[0].Owner = "@shop-team"
+	if err := notify(ts.URL, notifySlack, "locales", findings, ruleSeverities{}, arraysUnsupported); err != nil {
+		t.Fatal(err)
+	}
+	if err := notify(ts.URL, notifyJSON, "locales", findings, ruleSeverities{}, arraysUnsupported); err != nil {
+		t.Fatal(err)
+	}
+	if want := "check-translations found 3 errors in locales:\n• de: 1\n• sv: 2\nby owner:\n• unowned: 2\n• @shop-team: 1"; payloads[2]["text"] != want {
+		t.Errorf("slack: want %q, got %q", want, payloads[2]["text"])
+	}
+	if owners := payloads[3]["owners"].(map[string]any); owners["@shop-team"] != 1.0 || owners[""] != 2.0 {
+		t.Errorf("json: unexpected owners %v", owners)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// NumberFormat are the separators a language writes numbers with.
+type NumberFormat struct {
+	// Decimal separates the integer part from the fraction, e.g. "," in 3,14.
+	Decimal string `json:"decimal"`
+	// Group separates the thousands, e.g. " " in 10 000. Any kind of space matches a space.
+	Group string `json:"group"`
+}
+
+// numberRx matches number literals with separators, like 1,000, 3.14 or 10 000,50.
+var numberRx = regexp.MustCompile(`\d+(?:[.,'\x{00a0}\x{202f} ]\d+)+`)
+
+// normalizeSeparator makes all the kinds of spaces the same separator.
+func normalizeSeparator(sep string) string {
+	switch sep {
+	case "\u00a0", "\u202f":
+		return " "
+	}
+	return sep
+}
+
+// isDecimalSeparator reports whether sep can separate the fraction of a number.
+func isDecimalSeparator(sep string) bool {
+	return sep == "." || sep == ","
+}
+
+// parseNumber returns the group and decimal separators of a number literal matched by numberRx,
+// or ok false if it doesn't look like a number, e.g. a date like 12.05.2024.
+// A single separator followed by three digits, like in 1,000, could be either;
+// it's then returned as both.
+func parseNumber(literal string) (group, decimal string, ok bool) {
+	var seps, parts []string
+	last := 0
+	for i, r := range literal {
+		if r < '0' || r > '9' {
+			seps = append(seps, normalizeSeparator(string(r)))
+			parts = append(parts, literal[last:i])
+			last = i + len(string(r))
+		}
+	}
+	parts = append(parts, literal[last:])
+
+	if len(seps) == 1 {
+		switch {
+		case len(parts[1]) == 3 && len(parts[0]) <= 3 && isDecimalSeparator(seps[0]):
+			return seps[0], seps[0], true
+		case len(parts[1]) == 3 && len(parts[0]) <= 3:
+			return seps[0], "", true
+		case isDecimalSeparator(seps[0]):
+			return "", seps[0], true
+		}
+		return "", "", false
+	}
+
+	// All the separators but a different last one group the thousands.
+	groups := len(seps)
+	if seps[len(seps)-1] != seps[0] {
+		decimal = seps[len(seps)-1]
+		groups--
+	}
+	if len(parts[0]) > 3 || decimal != "" && !isDecimalSeparator(decimal) {
+		return "", "", false
+	}
+	for i := 1; i <= groups; i++ {
+		if seps[i-1] != seps[0] || len(parts[i]) != 3 {
+			return "", "", false
+		}
+	}
+	return seps[0], decimal, true
+}
+
+// checkNumbers returns a check reporting the number literals of the configured languages
+// which don't use their separators. The ambiguous literals copied from the english source,
+// like 1,000, are read the english way.
+func checkNumbers(formats map[string]NumberFormat) checkFunc {
+	return func(translations map[string]Translation) (result []Finding) {
+		for lang, format := range formats {
+			for key, translated := range translations[lang] {
+				for _, loc := range numberRx.FindAllStringIndex(translated, -1) {
+					literal := translated[loc[0]:loc[1]]
+					group, decimal, ok := parseNumber(literal)
+					if !ok {
+						continue
+					}
+					if group != "" && group == decimal && strings.Contains(translations["en"][key], literal) {
+						// Copied from the english source, so it means what it does in english.
+						if group == "," {
+							decimal = ""
+						} else {
+							group = ""
+						}
+					}
+					if group != "" && group == decimal {
+						ok = group == normalizeSeparator(format.Group) || decimal == format.Decimal
+					} else {
+						ok = (group == "" || group == normalizeSeparator(format.Group)) &&
+							(decimal == "" || decimal == format.Decimal)
+					}
+					if !ok {
+						result = append(result, Finding{
+							Lang:  lang,
+							Key:   key,
+							Check: "numbers",
+							Message: fmt.Sprintf("number %v doesn't use the decimal %q and group %q separators: %v",
+								literal, format.Decimal, format.Group, translated),
+						})
+					}
+				}
+			}
+		}
+		return result
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import "testing"
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		literal, group, decimal string
+		ok                      bool
+	}{
+		{"3.14", "", ".", true},
+		{"1234,5", "", ",", true},
+		{"1,000", ",", ",", true},
+		{"10 000", " ", "", true},
+		{"10 000", " ", "", true},
+		{"1,234,567", ",", "", true},
+		{"1.234.567,89", ".", ",", true},
+		{"1 234.5", " ", ".", true},
+		{"12.05.2024", "", "", false},
+		{"2024 10", "", "", false},
+		{"1,2,3", "", "", false},
+	}
+	for _, test := range tests {
+		group, decimal, ok := parseNumber(test.literal)
+		if group != test.group || decimal != test.decimal || ok != test.ok {
+			t.Errorf("%q: want %q %q %v, got %q %q %v",
+				test.literal, test.group, test.decimal, test.ok, group, decimal, ok)
+		}
+	}
+}
+
+func TestCheckNumbers(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {"a": "Up to 1,000 files", "b": "Costs 9.99", "c": "Released on 12.05.2024"},
+		"de": {"a": "Bis zu 1,000 Dateien", "b": "Kostet 9,99", "c": "Veröffentlicht am 12.05.2024"},
+		"sv": {"a": "Upp till 1 000 filer", "b": "Kostar 9.99", "c": "Släppt 12.05.2024"},
+		"fr": {"a": "Jusqu'à 1,000 fichiers"},
+	}
+	formats := map[string]NumberFormat{
+		"de": {Decimal: ",", Group: "."},
+		"sv": {Decimal: ",", Group: " "},
+	}
+	keys := map[string]bool{}
+	for _, finding := range checkNumbers(formats)(translations) {
+		keys[finding.Lang+":"+finding.Key] = true
+	}
+	if len(keys) != 2 || !keys["de:a"] || !keys["sv:b"] {
+		t.Errorf("unexpected findings: %v", keys)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// keyOwner is a line of an ownership file: the owners of the keys matching a pattern.
+type keyOwner struct {
+	rx     *regexp.Regexp
+	owners []string
+}
+
+// keyOwners are the lines of an ownership file, in order.
+type keyOwners []keyOwner
+
+// loadOwners loads the ownership file at path.
+func loadOwners(path string) (keyOwners, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	owners, err := parseOwners(file)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return owners, nil
+}
+
+// parseOwners parses an ownership file, with the syntax of the CODEOWNERS files of GitHub but key
+// patterns instead of paths: every line is a pattern followed by its owners, like "checkout @shop-team".
+// A pattern matches the keys under it, the segments of the keys being separated by dots; a * matches
+// a segment, or a part of one, and a ** any number of them. A pattern without owners leaves its keys unowned.
+func parseOwners(r io.Reader) (keyOwners, error) {
+	var owners keyOwners
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		rx, err := regexp.Compile("^" + keyPatternRegexp(fields[0]) + `(?:\..*)?$`)
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %w", line, err)
+		}
+		owner := keyOwner{rx: rx}
+		for _, field := range fields[1:] {
+			if strings.HasPrefix(field, "#") {
+				break
+			}
+			owner.owners = append(owner.owners, field)
+		}
+		owners = append(owners, owner)
+	}
+	return owners, scanner.Err()
+}
+
+// keyPatternRegexp converts a key pattern of an ownership file to a regular expression.
+func keyPatternRegexp(pattern string) string {
+	var expr strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString(`[^.]*`)
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	return expr.String()
+}
+
+// of returns the owners of key, from the last matching line as in the CODEOWNERS files.
+func (owners keyOwners) of(key string) []string {
+	for i := len(owners) - 1; i >= 0; i-- {
+		if owners[i].rx.MatchString(key) {
+			return owners[i].owners
+		}
+	}
+	return nil
+}
+
+// addOwners sets the owners of the keys of findings. The findings without a key have none.
+func addOwners(findings []Finding, owners keyOwners) {
+	for i := range findings {
+		if findings[i].Key != "" {
+			findings[i].Owner = strings.Join(owners.of(findings[i].Key), " ")
+		}
+	}
+}
+
+// findingsByOwner groups findings by their owners, the unowned ones being under "".
+func findingsByOwner(findings []Finding) map[string][]Finding {
+	result := make(map[string][]Finding)
+	for _, finding := range findings {
+		result[finding.Owner] = append(result[finding.Owner], finding)
+	}
+	return result
+}
+
+// reportByOwner writes the report of findings one section per owner, each like reportTextLimited.
+// The unowned findings come last.
+func reportByOwner(w io.Writer, findings []Finding, rules ruleSeverities, limits reportLimits) {
+	byOwner := findingsByOwner(findings)
+	owners := sortedKeys(byOwner)
+	if len(owners) > 0 && owners[0] == "" {
+		owners = append(owners[1:], "")
+	}
+	for _, owner := range owners {
+		name := owner
+		if owner == "" {
+			name = reportLocale.sprintf("unowned")
+		}
+		fmt.Fprintf(w, "=== %v\n", name)
+		reportTextLimited(w, byOwner[owner], rules, limits)
+	}
+}
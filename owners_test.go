@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKeyOwners(t *testing.T) {
+	owners, err := parseOwners(strings.NewReader(`# Product areas
+* @l10n
+checkout @shop-team @payments
+checkout.legal
+settings.*.title @settings-team # titles only
+**.error @platform
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string][]string{
+		"home":                 {"@l10n"},
+		"checkout":             {"@shop-team", "@payments"},
+		"checkout.pay.button":  {"@shop-team", "@payments"},
+		"checkoutbutton":       {"@l10n"},
+		"checkout.legal.terms": nil,
+		"settings.email.title": {"@settings-team"},
+		"settings.email.hint":  {"@l10n"},
+		"checkout.card.error":  {"@platform"},
+	} {
+		if got := owners.of(key); !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: want %v, got %v", key, want, got)
+		}
+	}
+}
+
+func TestReportByOwner(t *testing.T) {
+	owners, _ := parseOwners(strings.NewReader("checkout @shop-team\n"))
+	findings := []Finding{
+		{Lang: "sv", Key: "home", Check: "variables", Message: "one"},
+		{Lang: "sv", Key: "checkout.pay", Check: "variables", Message: "two"},
+		{Lang: "sv", Check: "load", Message: "three"},
+	}
+	addOwners(findings, owners)
+	var buf bytes.Buffer
+	reportByOwner(&buf, findings, ruleSeverities{}, reportLimits{})
+	want := `=== @shop-team
+[sv]
+    two (VAR001)
+=== unowned
+[sv]
+    three (FILE002)
+    one (VAR001)
+`
+	if buf.String() != want {
+		t.Errorf("want:\n%v\ngot:\n%v", want, buf.String())
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around the changes of a unified diff.
+const diffContext = 3
+
+// fixTranslationFile returns the translation file bs with the mechanical fixes of findings, of its
+// language lang, applied to the string values, and the number of strings fixed.
+func fixTranslationFile(bs []byte, lang string, translations map[string]Translation, findings []Finding) ([]byte, int, error) {
+	members, err := locateMembers(bs)
+	if err != nil {
+		return nil, 0, err
+	}
+	fixed := make(map[string]string)
+	for _, finding := range findings {
+		if finding.Lang != lang || finding.Key == "" {
+			continue
+		}
+		translated, ok := fixed[finding.Key]
+		if !ok {
+			translated = translations[lang][finding.Key]
+		}
+		if translated, ok := fixFinding(finding, translations["en"][finding.Key], translated); ok {
+			fixed[finding.Key] = translated
+		}
+	}
+
+	keys := make([]string, 0, len(fixed))
+	for key := range fixed {
+		// The arrays read as strings can't be written back as strings.
+		if m, ok := members[key]; ok && bs[m.value.start] == '"' {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b string) int { return members[a].value.start - members[b].value.start })
+	var out bytes.Buffer
+	written := 0
+	for _, key := range keys {
+		m := members[key]
+		out.Write(bs[written:m.value.start])
+		out.WriteString(encodeJSONString(fixed[key]))
+		written = m.value.end
+	}
+	out.Write(bs[written:])
+	return out.Bytes(), len(keys), nil
+}
+
+// unifiedDiff returns the unified diff of the file at path from old to new, which must have
+// the same number of lines, as the string values of translation files are on a single line.
+func unifiedDiff(path string, old, new []byte) string {
+	oldLines := strings.Split(string(old), "\n")
+	newLines := strings.Split(string(new), "\n")
+	if len(oldLines) != len(newLines) {
+		return ""
+	}
+	// A final newline doesn't start another line.
+	noFinalNewline := !bytes.HasSuffix(old, []byte("\n"))
+	if !noFinalNewline {
+		oldLines, newLines = oldLines[:len(oldLines)-1], newLines[:len(newLines)-1]
+	}
+
+	var changed []int
+	for i := range oldLines {
+		if oldLines[i] != newLines[i] {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%v\n+++ b/%v\n", path, path)
+	writeLine := func(prefix, line string, i int) {
+		b.WriteString(prefix + line + "\n")
+		if noFinalNewline && i == len(oldLines)-1 {
+			b.WriteString("\\ No newline at end of file\n")
+		}
+	}
+	for len(changed) > 0 {
+		// A hunk holds the changes separated by less than twice the context.
+		n := 1
+		for n < len(changed) && changed[n]-changed[n-1] <= 2*diffContext {
+			n++
+		}
+		first, last := changed[0], changed[n-1]
+		start := max(0, first-diffContext)
+		end := min(len(oldLines), last+diffContext+1)
+		fmt.Fprintf(&b, "@@ -%v,%v +%v,%v @@\n", start+1, end-start, start+1, end-start)
+		for i := start; i < end; {
+			if !slices.Contains(changed[:n], i) {
+				writeLine(" ", oldLines[i], i)
+				i++
+				continue
+			}
+			// The consecutive changed lines are removed, then added, together.
+			j := i
+			for j < end && slices.Contains(changed[:n], j) {
+				j++
+			}
+			for k := i; k < j; k++ {
+				writeLine("-", oldLines[k], k)
+			}
+			for k := i; k < j; k++ {
+				writeLine("+", newLines[k], k)
+			}
+			i = j
+		}
+		changed = changed[n:]
+	}
+	return b.String()
+}
+
+// suggestPatch returns the unified diffs of the JSON translation files at paths fixing the findings
+// with an obvious mechanical fix, and the number of strings fixed.
+func suggestPatch(translations map[string]Translation, findings []Finding, paths map[string]string) (string, int, error) {
+	var patch strings.Builder
+	total := 0
+	for _, lang := range sortedKeys(paths) {
+		path := paths[lang]
+		if lang == "en" || isPOPath(path) {
+			continue
+		}
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			return "", 0, err
+		}
+		fixed, n, err := fixTranslationFile(bs, lang, translations, findings)
+		if err != nil {
+			// The files which can't be parsed are reported while loading them.
+			continue
+		}
+		// The patch applies to the working directory, with patch -p1 or git apply.
+		if wd, err := os.Getwd(); err == nil && filepath.IsAbs(path) {
+			if rel, err := filepath.Rel(wd, path); err == nil {
+				path = rel
+			}
+		}
+		patch.WriteString(unifiedDiff(filepath.ToSlash(path), bs, fixed))
+		total += n
+	}
+	return patch.String(), total, nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSuggestPatch(t *testing.T) {
+	dir := t.TempDir()
+	sv := filepath.Join(dir, "sv.json")
+	os.WriteFile(sv, []byte(`{
+    "a": "Hej $namn$",
+    "b": "Spara",
+    "c": "Öppna",
+    "d": "Stäng",
+    "e": "Ny",
+    "f": "Gammal",
+    "g": "Ta bort",
+    "h": "<b>Sparat",
+    "i": "$x$ <i>saker"
+}`), 0644)
+	translations := map[string]Translation{
+		"en": {"a": "Hello $name$", "h": "<b>Saved</b>", "i": "$n$ <i>items</i>"},
+		"sv": {"a": "Hej $namn$", "h": "<b>Sparat", "i": "$x$ <i>saker"},
+	}
+	findings := runChecks(builtinChecks, translations)
+	bs, _ := os.ReadFile(sv)
+	fixedFile, fixed, err := fixTranslationFile(bs, "sv", translations, findings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	patch := unifiedDiff("locales/sv.json", bs, fixedFile)
+	want := `--- a/locales/sv.json
++++ b/locales/sv.json
+@@ -1,5 +1,5 @@
+ {
+-    "a": "Hej $namn$",
++    "a": "Hej $name$",
+     "b": "Spara",
+     "c": "Öppna",
+     "d": "Stäng",
+@@ -6,6 +6,6 @@
+     "e": "Ny",
+     "f": "Gammal",
+     "g": "Ta bort",
+-    "h": "<b>Sparat",
+-    "i": "$x$ <i>saker"
++    "h": "<b>Sparat</b>",
++    "i": "$n$ <i>saker</i>"
+ }
+\ No newline at end of file
+`
+	if patch != want || fixed != 3 {
+		t.Errorf("want %v fixes:\n%v\ngot %v:\n%v", 3, want, fixed, patch)
+	}
+
+	if patch, fixed, err := suggestPatch(translations, findings, map[string]string{"sv": sv}); err != nil || fixed != 3 || !strings.HasSuffix(patch, want[strings.Index(want, "@@"):]) {
+		t.Errorf("suggestPatch: unexpected %v fixes, %v:\n%v", fixed, err, patch)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"log"
+	"path/filepath"
+	"plugin"
+	"strings"
+)
+
+// checkFunc is the signature shared by all the checks.
+// It receives all the loaded translations and returns the problems found in them.
+type checkFunc func(translations map[string]Translation) []Finding
+
+// pluginCheckSymbol is the name of the function a plugin must export.
+// Its signature must be
+//
+//	func Check(translations map[string]map[string]string) map[string][]string
+//
+// It receives the translations indexed by language and returns a map of language -> list of errors.
+// Only built-in types are used, so that plugins don't need to import anything from this program.
+const pluginCheckSymbol = "Check"
+
+// loadPlugin opens the Go plugin at path and returns its check,
+// named after the plugin file.
+func loadPlugin(path string) check {
+	p, err := plugin.Open(path)
+	if err != nil {
+		log.Fatalf("loadPlugin: %v: %v", path, err)
+	}
+	sym, err := p.Lookup(pluginCheckSymbol)
+	if err != nil {
+		log.Fatalf("loadPlugin: %v: %v", path, err)
+	}
+	pluginCheck, ok := sym.(func(map[string]map[string]string) map[string][]string)
+	if !ok {
+		log.Fatalf("loadPlugin: %v: %v has the wrong signature: %T", path, pluginCheckSymbol, sym)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	return check{name, func(translations map[string]Translation) (result []Finding) {
+		plain := make(map[string]map[string]string, len(translations))
+		for lang, translation := range translations {
+			plain[lang] = translation
+		}
+		for lang, errs := range pluginCheck(plain) {
+			for _, err := range errs {
+				result = append(result, Finding{Lang: lang, Check: name, Message: err})
+			}
+		}
+		return result
+	}}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// poEntry is a singular message of a gettext PO file.
+type poEntry struct {
+	context, id, str string
+	fuzzy            bool
+}
+
+// key returns the translation key of the entry: its msgid, prefixed with its msgctxt if it has one.
+func (e poEntry) key() string {
+	if e.context != "" {
+		return e.context + "|" + e.id
+	}
+	return e.id
+}
+
+// isPOFile reports whether the file name matches the <lang>.po pattern.
+func isPOFile(name string) bool {
+	match, _ := filepath.Match("??.po", filepath.Base(name))
+	return match
+}
+
+// isPOPath reports whether the translation file at path is a PO file rather than a JSON one,
+// whatever its naming scheme.
+func isPOPath(path string) bool {
+	return filepath.Ext(path) == ".po"
+}
+
+// parsePO parses the singular messages of a PO file.
+// The header and the plural messages are skipped, as are the obsolete #~ entries.
+func parsePO(bs []byte) ([]poEntry, error) {
+	var entries []poEntry
+	var entry poEntry
+	var field *string
+	plural := false
+	flush := func() {
+		if entry.id != "" && !plural {
+			entries = append(entries, entry)
+		}
+		entry, field, plural = poEntry{}, nil, false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(bs))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		keyword, value, _ := strings.Cut(line, " ")
+		switch {
+		case line == "":
+			flush()
+			continue
+		case strings.HasPrefix(line, "#,"):
+			if field != nil {
+				flush()
+			}
+			for _, flag := range strings.Split(line[2:], ",") {
+				if strings.TrimSpace(flag) == "fuzzy" {
+					entry.fuzzy = true
+				}
+			}
+			continue
+		case strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, `"`):
+			value = line
+		case keyword == "msgctxt":
+			if field != nil {
+				flush()
+			}
+			field = &entry.context
+		case keyword == "msgid":
+			if field != nil && field != &entry.context {
+				flush()
+			}
+			field = &entry.id
+		case keyword == "msgstr" || keyword == "msgstr[0]":
+			field = &entry.str
+		case keyword == "msgid_plural":
+			plural = true
+			field = nil
+		case strings.HasPrefix(keyword, "msgstr["):
+			field = nil
+		default:
+			return nil, fmt.Errorf("line %v: unexpected %q", n, line)
+		}
+		s, err := strconv.Unquote(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %v", n, err)
+		}
+		if field != nil {
+			*field += s
+		}
+	}
+	flush()
+	return entries, scanner.Err()
+}
+
+// loadPO loads a <lang>.po and returns its translation and the english source strings.
+// Fuzzy entries are left untranslated.
+func loadPO(path string) (translation, source Translation, err error) {
+	entries, err := readPO(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	translation, source = poTranslation(entries)
+	return translation, source, nil
+}
+
+// poTranslation returns the translation of the entries of a PO file and their english source strings.
+// Fuzzy entries are left untranslated.
+func poTranslation(entries []poEntry) (translation, source Translation) {
+	translation, source = Translation{}, Translation{}
+	for _, entry := range entries {
+		source[entry.key()] = entry.id
+		if !entry.fuzzy {
+			translation[entry.key()] = entry.str
+		}
+	}
+	return translation, source
+}
+
+// readPO reads the entries of the PO file at path.
+func readPO(path string) ([]poEntry, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := parsePO(bs)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return entries, nil
+}
+
+// checkFuzzy returns a check reporting the fuzzy entries of the PO files of langs,
+// out of a map of language -> path. The files which can't be read are reported when loading them.
+func checkFuzzy(paths map[string]string, langs []string) checkFunc {
+	return func(map[string]Translation) (result []Finding) {
+		for lang, path := range paths {
+			if !isPOPath(path) || !slices.Contains(langs, lang) {
+				continue
+			}
+			entries, _ := readPO(path)
+			for _, entry := range entries {
+				if entry.fuzzy {
+					result = append(result, Finding{
+						Lang:    lang,
+						Key:     entry.key(),
+						Check:   "fuzzy",
+						Message: fmt.Sprintf("fuzzy translation: %v ⇒ %v", entry.id, entry.str),
+					})
+				}
+			}
+		}
+		return result
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testPO = `# Swedish translations.
+msgid ""
+msgstr ""
+"Language: sv\n"
+
+#: src/app.js:10
+msgid "Save"
+msgstr "Spara"
+
+msgctxt "menu"
+msgid "Open"
+msgstr ""
+"Öpp"
+"na"
+
+#, fuzzy, javascript-format
+msgid "$count$ documents"
+msgstr "$count$ dokument"
+
+msgid "One file"
+msgid_plural "$count$ files"
+msgstr[0] "En fil"
+msgstr[1] "$count$ filer"
+#~ msgid "Old"
+#~ msgstr "Gammal"
+`
+
+func TestParsePO(t *testing.T) {
+	entries, err := parsePO([]byte(testPO))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []poEntry{
+		{id: "Save", str: "Spara"},
+		{context: "menu", id: "Open", str: "Öppna"},
+		{id: "$count$ documents", str: "$count$ dokument", fuzzy: true},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("want %+v, got %+v", want, entries)
+	}
+}
+
+func TestLoadPOFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sv.po")
+	os.WriteFile(path, []byte(testPO), 0644)
+
+	translations, _ := loadTranslations(dir, FilesConfig{})
+	want := map[string]Translation{
+		"en": {"Save": "Save", "menu|Open": "Open", "$count$ documents": "$count$ documents"},
+		"sv": {"Save": "Spara", "menu|Open": "Öppna"},
+	}
+	if !reflect.DeepEqual(translations, want) {
+		t.Errorf("want %v, got %v", want, translations)
+	}
+
+	paths := map[string]string{"sv": path}
+	if findings := checkFuzzy(paths, []string{"de"})(translations); len(findings) != 0 {
+		t.Errorf("unexpected findings: %v", findings)
+	}
+	findings := checkFuzzy(paths, []string{"sv"})(translations)
+	if len(findings) != 1 || findings[0].Key != "$count$ documents" {
+		t.Errorf("unexpected findings: %v", findings)
+	}
+}
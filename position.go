@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"unicode/utf8"
+)
+
+// span is a range of byte offsets into a translation file.
+type span struct {
+	start, end int
+}
+
+// member is the location of a key and its value in a translation file.
+type member struct {
+	key, value span
+}
+
+// locateMembers returns the location of all the members of the top-level object of a translation file.
+func locateMembers(bs []byte) (map[string]member, error) {
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("expected an object, got %v", tok)
+	}
+
+	members := make(map[string]member)
+	for dec.More() {
+		offset := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		keyEnd := int(dec.InputOffset())
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		members[key] = member{
+			key:   span{skipSeparators(bs, offset), keyEnd},
+			value: span{skipSeparators(bs, keyEnd), int(dec.InputOffset())},
+		}
+	}
+	return members, nil
+}
+
+// skipSeparators returns the offset of the first byte at or after offset which isn't
+// white space or one of the ',' and ':' separators.
+func skipSeparators(bs []byte, offset int) int {
+	for offset < len(bs) {
+		switch bs[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// lineCol converts a byte offset to a zero-based line and column.
+// The column is counted in UTF-16 code units, as editors speaking LSP expect.
+func lineCol(bs []byte, offset int) (line, col int) {
+	offset = min(offset, len(bs))
+	lineStart := bytes.LastIndexByte(bs[:offset], '\n') + 1
+	line = bytes.Count(bs[:lineStart], []byte("\n"))
+	for _, r := range string(bs[lineStart:offset]) {
+		col += utf16Len(r)
+	}
+	return line, col
+}
+
+// offsetOf converts a zero-based line and UTF-16 column to a byte offset.
+// Positions past the end of a line are clamped to the end of the line.
+func offsetOf(bs []byte, line, col int) int {
+	offset := 0
+	for ; line > 0; line-- {
+		i := bytes.IndexByte(bs[offset:], '\n')
+		if i < 0 {
+			return len(bs)
+		}
+		offset += i + 1
+	}
+	for col > 0 && offset < len(bs) && bs[offset] != '\n' {
+		r, size := utf8.DecodeRune(bs[offset:])
+		col -= utf16Len(r)
+		offset += size
+	}
+	return offset
+}
+
+// utf16Len returns the number of UTF-16 code units needed to encode r.
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+// locatedFinding is a finding in the translation file of its language, on the lines of the member
+// of its key, or the first line of the file for the findings without a key. The lines start at 1.
+type locatedFinding struct {
+	Finding
+	path       string
+	start, end int
+}
+
+// locateFindings locates findings in the translation files of paths. The findings of the languages
+// without a translation file are returned apart.
+func locateFindings(findings []Finding, paths map[string]string) (located []locatedFinding, outside []Finding) {
+	contents := make(map[string][]byte)
+	members := make(map[string]map[string]member)
+	for _, finding := range findings {
+		path, ok := paths[finding.Lang]
+		if !ok {
+			outside = append(outside, finding)
+			continue
+		}
+		if _, ok := contents[path]; !ok {
+			// The files which can't be read or parsed, like the PO ones, are located at their first line.
+			contents[path], _ = os.ReadFile(path)
+			members[path], _ = locateMembers(contents[path])
+		}
+		start, end := 0, 0
+		if m, ok := members[path][finding.Key]; ok && finding.Key != "" {
+			start, _ = lineCol(contents[path], m.key.start)
+			end, _ = lineCol(contents[path], m.value.end)
+		}
+		located = append(located, locatedFinding{finding, path, start + 1, end + 1})
+	}
+	return located, outside
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestLocateMembers(t *testing.T) {
+	input := []byte("{\n    \"a\": \"one\",\n    \"b\" : {\"nested\": 1},\"c\":\"ü\"\n}\n")
+	members, err := locateMembers(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][2]string{
+		"a": {`"a"`, `"one"`},
+		"b": {`"b"`, `{"nested": 1}`},
+		"c": {`"c"`, `"ü"`},
+	}
+	if len(members) != len(want) {
+		t.Errorf("want %v members, got %v", len(want), members)
+	}
+	for key, w := range want {
+		m := members[key]
+		if got := string(input[m.key.start:m.key.end]); got != w[0] {
+			t.Errorf("%v: want key %q, got %q", key, w[0], got)
+		}
+		if got := string(input[m.value.start:m.value.end]); got != w[1] {
+			t.Errorf("%v: want value %q, got %q", key, w[1], got)
+		}
+	}
+
+	if _, err := locateMembers([]byte(`["not", "an", "object"]`)); err == nil {
+		t.Errorf("want error for a top-level array")
+	}
+}
+
+func TestLineCol(t *testing.T) {
+	input := []byte("ab\nüx😀y\n")
+	var tests = []struct {
+		offset, line, col int
+	}{
+		{0, 0, 0},
+		{2, 0, 2},
+		{3, 1, 0},
+		{5, 1, 1},  // after ü, two bytes
+		{6, 1, 2},  // after x
+		{10, 1, 4}, // after 😀, two UTF-16 code units
+		{11, 1, 5},
+		{12, 2, 0},
+	}
+	for _, test := range tests {
+		line, col := lineCol(input, test.offset)
+		if line != test.line || col != test.col {
+			t.Errorf("lineCol(%v): want %v:%v, got %v:%v", test.offset, test.line, test.col, line, col)
+		}
+		if offset := offsetOf(input, test.line, test.col); offset != test.offset {
+			t.Errorf("offsetOf(%v, %v): want %v, got %v", test.line, test.col, test.offset, offset)
+		}
+	}
+}
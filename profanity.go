@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// loadWordList loads the words and phrases of a word list file, one per line.
+// The empty lines and the ones starting with # are left out.
+func loadWordList(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word != "" && !strings.HasPrefix(word, "#") {
+			words = append(words, word)
+		}
+	}
+	return words, scanner.Err()
+}
+
+// wordListRx returns a regular expression matching the words of a word list as whole words,
+// ignoring the case, or nil if the list is empty.
+func wordListRx(words []string) *regexp.Regexp {
+	if len(words) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = regexp.QuoteMeta(word)
+	}
+	// \b only knows of ASCII letters.
+	return regexp.MustCompile(`(?i)(?:^|[^\p{L}\p{N}])(` + strings.Join(quoted, "|") + `)(?:$|[^\p{L}\p{N}])`)
+}
+
+// checkProfanity returns a check reporting the texts of every language with a term of its word list,
+// which maps languages to the regular expressions of their lists.
+func checkProfanity(lists map[string]*regexp.Regexp) checkFunc {
+	return func(translations map[string]Translation) (result []Finding) {
+		for lang, rx := range lists {
+			for key, translated := range translations[lang] {
+				for _, match := range rx.FindAllStringSubmatch(translated, -1) {
+					result = append(result, Finding{
+						Lang:    lang,
+						Key:     key,
+						Check:   "profanity",
+						Message: fmt.Sprintf("offensive term %q: %v", match[1], translated),
+					})
+				}
+			}
+		}
+		return result
+	}
+}
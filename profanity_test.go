@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestCheckProfanity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sv.txt")
+	os.WriteFile(path, []byte("# Swedish\nfan\n\njävla skit\n"), 0644)
+	words, err := loadWordList(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"fan", "jävla skit"}; !reflect.DeepEqual(words, want) {
+		t.Errorf("want %v, got %v", want, words)
+	}
+
+	translations := map[string]Translation{
+		"en": {"a": "Fan out the documents", "b": "Save", "c": "Done"},
+		"sv": {"a": "Fanns inte", "b": "Spara, för FAN!", "c": "Jävla skit"},
+	}
+	want := []Finding{
+		{Lang: "sv", Key: "b", Check: "profanity", Message: `offensive term "FAN": Spara, för FAN!`},
+		{Lang: "sv", Key: "c", Check: "profanity", Message: `offensive term "Jävla skit": Jävla skit`},
+	}
+	findings := checkProfanity(map[string]*regexp.Regexp{"sv": wordListRx(words)})(translations)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+	if !(ruleSeverities{}).isWarning(findings[0]) {
+		t.Errorf("%v is not a warning", findings[0])
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"runtime/pprof"
+	"slices"
+	"time"
+)
+
+// timing is how long something named took.
+type timing struct {
+	name    string
+	elapsed time.Duration
+}
+
+// sortTimings sorts timings from the slowest, by name for the same time.
+func sortTimings(timings []timing) {
+	slices.SortFunc(timings, func(a, b timing) int {
+		if c := cmp.Compare(b.elapsed, a.elapsed); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.name, b.name)
+	})
+}
+
+// runTimedChecks is runChecks timing every check.
+func runTimedChecks(checks []check, translations map[string]Translation) ([]Finding, []timing) {
+	var findings []Finding
+	var timings []timing
+	for _, check := range checks {
+		start := time.Now()
+		findings = append(findings, check.run(translations)...)
+		timings = append(timings, timing{check.name, time.Since(start)})
+	}
+	slices.SortStableFunc(findings, compareFindings)
+	sortTimings(timings)
+	return findings, timings
+}
+
+// timeLanguages times all the checks on every language on its own, with the english reference.
+// The findings are not kept, the checks are run again for the timing only.
+func timeLanguages(checks []check, translations map[string]Translation) []timing {
+	var timings []timing
+	for lang, translation := range translations {
+		subset := map[string]Translation{"en": translations["en"], lang: translation}
+		start := time.Now()
+		for _, check := range checks {
+			check.run(subset)
+		}
+		timings = append(timings, timing{lang, time.Since(start)})
+	}
+	sortTimings(timings)
+	return timings
+}
+
+// reportProfile writes how long every check and the checks of every language took to w.
+func reportProfile(w io.Writer, checkTimings, langTimings []timing) {
+	fmt.Fprintln(w, "time per check:")
+	for _, t := range checkTimings {
+		fmt.Fprintf(w, "    %v: %v\n", t.name, t.elapsed.Round(time.Microsecond))
+	}
+	fmt.Fprintln(w, "time per language:")
+	for _, t := range langTimings {
+		fmt.Fprintf(w, "    %v: %v\n", t.name, t.elapsed.Round(time.Microsecond))
+	}
+}
+
+// startCPUProfile writes a pprof CPU profile to path until the returned function is called.
+func startCPUProfile(path string) (func(), error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := pprof.StartCPUProfile(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return func() {
+		pprof.StopCPUProfile()
+		file.Close()
+	}, nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRunTimedChecks(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {"greeting": "Hello $name$", "bold": "<b>Bold</b>"},
+		"sv": {"greeting": "Hej $namn$", "bold": "<b>Fet"},
+	}
+	findings, timings := runTimedChecks(builtinChecks, translations)
+	if want := runChecks(builtinChecks, translations); !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+	if len(timings) != len(builtinChecks) {
+		t.Errorf("want a timing per check, got %v", timings)
+	}
+	if langs := timeLanguages(builtinChecks, translations); len(langs) != 2 {
+		t.Errorf("want a timing per language, got %v", langs)
+	}
+}
+
+func TestReportProfile(t *testing.T) {
+	timings := []timing{{"variables", time.Millisecond}, {"html", 3 * time.Millisecond}, {"cjk", time.Millisecond}}
+	sortTimings(timings)
+	var buf bytes.Buffer
+	reportProfile(&buf, timings, []timing{{"sv", 2 * time.Millisecond}})
+	want := `time per check:
+    html: 3ms
+    cjk: 1ms
+    variables: 1ms
+time per language:
+    sv: 2ms
+`
+	if buf.String() != want {
+		t.Errorf("want\n%v\ngot\n%v", want, buf.String())
+	}
+}
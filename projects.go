@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// runProjects checks and reports every project in turn, each in its own section,
+// and returns whether any of them had findings.
+func runProjects(projects []ProjectConfig, opts options) bool {
+	var failed []string
+	for _, project := range projects {
+		fmt.Fprintf(os.Stderr, "=== %v\n", project.Name)
+		if err := checkRootDir(project.Root); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			failed = append(failed, project.Name)
+			continue
+		}
+		if run(project.Root, opts, project.Config, time.Now()) {
+			failed = append(failed, project.Name)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "=== %v projects checked, %v with findings\n", len(projects), len(failed))
+	for _, name := range failed {
+		fmt.Fprintf(os.Stderr, "    %v\n", name)
+	}
+	return len(failed) > 0
+}
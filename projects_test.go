@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadProjects(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "monorepo.json")
+	os.WriteFile(path, []byte(`{
+		"languages": ["sv", "de"],
+		"numbers": {"de": {"decimal": ",", "group": "."}},
+		"baseline": "baseline.json",
+		"projects": [
+			{"name": "web", "root": "web/locales"},
+			{"root": "emails", "languages": ["sv"], "numbers": {"sv": {"decimal": ",", "group": " "}}}
+		]
+	}`), 0644)
+
+	config := loadConfig(path)
+	if len(config.Projects) != 2 {
+		t.Fatalf("unexpected projects: %v", config.Projects)
+	}
+	web, emails := config.Projects[0], config.Projects[1]
+	if web.Name != "web" || web.Root != filepath.Join(dir, "web/locales") {
+		t.Errorf("unexpected web project: %v %v", web.Name, web.Root)
+	}
+	if emails.Name != filepath.Join(dir, "emails") {
+		t.Errorf("want the root as name, got %v", emails.Name)
+	}
+	if !reflect.DeepEqual(web.Languages, []string{"sv", "de"}) || !reflect.DeepEqual(emails.Languages, []string{"sv"}) {
+		t.Errorf("unexpected languages: %v %v", web.Languages, emails.Languages)
+	}
+	if web.Baseline != filepath.Join(dir, "baseline.json") || emails.Baseline != web.Baseline {
+		t.Errorf("unexpected baselines: %v %v", web.Baseline, emails.Baseline)
+	}
+	if len(config.Numbers) != 1 || len(web.Numbers) != 1 || len(emails.Numbers) != 2 {
+		t.Errorf("unexpected numbers: %v %v %v", config.Numbers, web.Numbers, emails.Numbers)
+	}
+}
+
+func TestRunProjects(t *testing.T) {
+	dir := t.TempDir()
+	for _, project := range []string{"web", "emails"} {
+		os.MkdirAll(filepath.Join(dir, project), 0755)
+		os.WriteFile(filepath.Join(dir, project, "en.json"), []byte(`{"greeting": "Hello $name$"}`), 0644)
+	}
+	os.WriteFile(filepath.Join(dir, "web", "sv.json"), []byte(`{"greeting": "Hej $name$"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "emails", "sv.json"), []byte(`{"greeting": "Hej $namn$"}`), 0644)
+
+	web := ProjectConfig{Name: "web", Root: filepath.Join(dir, "web")}
+	emails := ProjectConfig{Name: "emails", Root: filepath.Join(dir, "emails")}
+	if runProjects([]ProjectConfig{web}, options{}) {
+		t.Error("web: want no findings")
+	}
+	if !runProjects([]ProjectConfig{web, emails}, options{}) {
+		t.Error("emails: want findings")
+	}
+}
+
+func TestProjectCustomRules(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "monorepo.json")
+	os.WriteFile(path, []byte(`{
+		"projects": [
+			{"root": "web", "customRules": [{"id": "WEB001", "name": "brand", "pattern": "Scrive", "message": "m"}]},
+			{"root": "emails", "customRules": [{"id": "MAIL001", "name": "brand", "pattern": "Scrive", "message": "m"}],
+			 "rules": {"MAIL001": "warning"}}
+		]
+	}`), 0644)
+
+	config := loadConfig(path)
+	web, emails := config.Projects[0], config.Projects[1]
+	if got := web.Rules.id("brand"); got != "WEB001" {
+		t.Errorf("web: want WEB001, got %v", got)
+	}
+	if got := emails.Rules.id("brand"); got != "MAIL001" {
+		t.Errorf("emails: want MAIL001, got %v", got)
+	}
+	if got := config.Rules.id("brand"); got != "brand" {
+		t.Errorf("want the custom rules of the projects to be their own, got %v", got)
+	}
+	finding := Finding{Lang: "sv", Key: "a", Check: "brand"}
+	if web.Rules.isWarning(finding) || !emails.Rules.isWarning(finding) {
+		t.Errorf("unexpected severities: %v %v", web.Rules.of("brand"), emails.Rules.of("brand"))
+	}
+	if err := web.Rules.validate("MAIL001"); err == nil {
+		t.Error("web: want MAIL001 to be unknown")
+	}
+}
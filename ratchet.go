@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+)
+
+// ratchetCounts maps language -> rule identifier -> number of errors, the findings of the files
+// being under the language "".
+type ratchetCounts map[string]map[string]int
+
+// ratchetIncrease is a count of errors which grew over the recorded one.
+type ratchetIncrease struct {
+	lang, rule      string
+	recorded, count int
+}
+
+// countErrors counts the findings which aren't warnings by language and rule.
+func countErrors(findings []Finding, rules ruleSeverities) ratchetCounts {
+	counts := ratchetCounts{}
+	for _, finding := range findings {
+		if rules.isWarning(finding) {
+			continue
+		}
+		if counts[finding.Lang] == nil {
+			counts[finding.Lang] = make(map[string]int)
+		}
+		counts[finding.Lang][rules.id(finding.Check)]++
+	}
+	return counts
+}
+
+// loadRatchet loads the recorded counts of the ratchet file at path, or returns nil if there is none.
+func loadRatchet(path string) ratchetCounts {
+	bs, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		log.Fatalf("loadRatchet: %v: %v", path, err)
+	}
+	counts := ratchetCounts{}
+	if err := json.Unmarshal(bs, &counts); err != nil {
+		log.Fatalf("loadRatchet: %v: %v", path, err)
+	}
+	return counts
+}
+
+// writeRatchet writes counts to the ratchet file at path.
+func writeRatchet(path string, counts ratchetCounts) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(counts); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// ratchet compares the current counts to the recorded ones. It returns the counts which grew, sorted,
+// and the counts to record: the recorded ones, lowered to the current ones which shrank.
+func ratchet(recorded, current ratchetCounts) (increases []ratchetIncrease, tightened ratchetCounts) {
+	tightened = ratchetCounts{}
+	for _, lang := range sortedKeys(recorded) {
+		for _, rule := range sortedKeys(recorded[lang]) {
+			if count := min(recorded[lang][rule], current[lang][rule]); count > 0 {
+				if tightened[lang] == nil {
+					tightened[lang] = make(map[string]int)
+				}
+				tightened[lang][rule] = count
+			}
+		}
+	}
+	for _, lang := range sortedKeys(current) {
+		for _, rule := range sortedKeys(current[lang]) {
+			if count := current[lang][rule]; count > recorded[lang][rule] {
+				increases = append(increases, ratchetIncrease{lang, rule, recorded[lang][rule], count})
+			}
+		}
+	}
+	return increases, tightened
+}
+
+// equalCounts reports whether two ratchets record the same counts.
+func equalCounts(a, b ratchetCounts) bool {
+	return maps.EqualFunc(a, b, func(a, b map[string]int) bool { return maps.Equal(a, b) })
+}
+
+// reportRatchet writes the counts of errors of the ratchet file path which grew.
+func reportRatchet(w io.Writer, path string, increases []ratchetIncrease) {
+	for _, increase := range increases {
+		lang := increase.lang
+		if lang == "" {
+			lang = reportLocale.sprintf("files")
+		}
+		fmt.Fprintln(w, reportLocale.sprintf("ratchet %v: %v errors of %v in %v, up from %v",
+			path, increase.count, increase.rule, lang, increase.recorded))
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCountErrors(t *testing.T) {
+	want := ratchetCounts{"sv": {"VAR001": 2, "HTML001": 1}, "": {"FILE002": 1}}
+	got := countErrors([]Finding{
+		{Lang: "sv", Key: "a", Check: "variables"},
+		{Lang: "sv", Key: "b", Check: "variables"},
+		{Lang: "sv", Key: "b", Check: "html"},
+		{Lang: "sv", Key: "c", Check: "profanity"},
+		{Check: "load"},
+	}, ruleSeverities{})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestRatchet(t *testing.T) {
+	recorded := ratchetCounts{"sv": {"VAR001": 3, "HTML001": 1}, "de": {"VAR001": 2}}
+	current := ratchetCounts{"sv": {"VAR001": 1, "HTML001": 2}, "fi": {"TXT001": 1}}
+	increases, tightened := ratchet(recorded, current)
+	wantIncreases := []ratchetIncrease{{"fi", "TXT001", 0, 1}, {"sv", "HTML001", 1, 2}}
+	if !reflect.DeepEqual(increases, wantIncreases) {
+		t.Errorf("want the increases %v, got %v", wantIncreases, increases)
+	}
+	// The grown counts aren't raised, the shrunk ones are lowered and the fixed ones removed.
+	wantTightened := ratchetCounts{"sv": {"VAR001": 1, "HTML001": 1}}
+	if !reflect.DeepEqual(tightened, wantTightened) {
+		t.Errorf("want the counts %v, got %v", wantTightened, tightened)
+	}
+
+	path := filepath.Join(t.TempDir(), "counts.json")
+	if loadRatchet(path) != nil {
+		t.Error("want no counts without a file")
+	}
+	if err := writeRatchet(path, tightened); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadRatchet(path); !equalCounts(got, tightened) {
+		t.Errorf("want %v, got %v", tightened, got)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// checkRelease reports the languages of the release tier which have no translation file among found.
+func checkRelease(tier string, langs, found []string) (result []Finding) {
+	for _, lang := range langs {
+		if !slices.Contains(found, lang) {
+			result = append(result, Finding{Lang: lang, Check: "release", Message: fmt.Sprintf("missing translation file, required by the %v release", tier)})
+		}
+	}
+	return result
+}
+
+// blockingFindings returns the findings which block a release of the languages langs:
+// theirs, the ones of the english reference and the ones of the files, besides the warnings.
+// The findings of the other languages are only warnings.
+func blockingFindings(findings []Finding, rules ruleSeverities, langs []string) (blocking []Finding) {
+	for _, finding := range findings {
+		if rules.isWarning(finding) {
+			continue
+		}
+		if finding.Lang == "" || finding.Lang == "en" || slices.Contains(langs, finding.Lang) {
+			blocking = append(blocking, finding)
+		}
+	}
+	return blocking
+}
+
+// reportRelease writes whether the release tier, of the languages langs, is blocked by findings.
+func reportRelease(w io.Writer, tier string, langs []string, findings, blocking []Finding) {
+	fmt.Fprintln(w, reportLocale.sprintf("release %v (%v): %v blocking findings, %v warnings",
+		tier, strings.Join(langs, ", "), len(blocking), len(findings)-len(blocking)))
+}
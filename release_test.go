@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestCheckRelease(t *testing.T) {
+	want := []Finding{
+		{Lang: "fi", Check: "release", Message: "missing translation file, required by the tier-1 release"},
+	}
+	if findings := checkRelease("tier-1", []string{"de", "fi", "sv"}, []string{"de", "en", "is", "sv"}); !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+}
+
+func TestBlockingFindings(t *testing.T) {
+	findings := []Finding{
+		{Check: "files", Message: "permission denied"},
+		{Lang: "de", Key: "a", Check: "variables", Message: "missing $x$"},
+		{Lang: "en", Key: "b", Check: "html", Message: "unclosed <b>"},
+		{Lang: "is", Key: "a", Check: "variables", Message: "missing $x$"},
+		{Lang: "sv", Key: "a", Check: "html", Message: "unclosed <i>"},
+	}
+	want := []Finding{findings[0], findings[1], findings[2], findings[4]}
+	blocking := blockingFindings(findings, ruleSeverities{}, []string{"de", "sv"})
+	if !reflect.DeepEqual(blocking, want) {
+		t.Errorf("want %v, got %v", want, blocking)
+	}
+
+	var buf bytes.Buffer
+	reportRelease(&buf, "tier-1", []string{"de", "sv"}, findings, blocking)
+	if want := "release tier-1 (de, sv): 4 blocking findings, 1 warnings\n"; buf.String() != want {
+		t.Errorf("want %q, got %q", want, buf.String())
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// provider is a translation management system the translations are read from,
+// instead of a local export.
+type provider interface {
+	// fetch downloads the current translations, indexed by language.
+	// The source strings are returned as the "en" translation.
+	fetch() (map[string]Translation, error)
+	// report pushes the findings back to the provider, so translators see them in its UI.
+	report(findings []Finding) error
+}
+
+// placeholderMapper is implemented by the providers whose placeholders the checks don't recognize.
+// The placeholders are only mapped on the translations that are checked, the pulled files keep them.
+type placeholderMapper interface {
+	mapPlaceholders(s string) string
+}
+
+// mapTranslations returns a copy of translations with every value passed through mapValue.
+func mapTranslations(translations map[string]Translation, mapValue func(string) string) map[string]Translation {
+	mapped := make(map[string]Translation, len(translations))
+	for lang, translation := range translations {
+		mapped[lang] = make(Translation, len(translation))
+		for key, value := range translation {
+			mapped[lang][key] = mapValue(value)
+		}
+	}
+	return mapped
+}
+
+// validPullLanguage reports whether the language code lang can be used as a file name in the pull directory.
+func validPullLanguage(lang string) bool {
+	return lang != "" && lang != "." && !strings.Contains(lang, "..") && !strings.ContainsAny(lang, `/\`)
+}
+
+// providers maps provider names to their constructors.
+// A constructor registers the provider settings in flags, which are parsed before it is used.
+var providers = map[string]func(flags *flag.FlagSet) provider{
+	"crowdin":   newCrowdin,
+	"lokalise":  newLokalise,
+	"phrase":    newPhrase,
+	"poeditor":  newPOEditor,
+	"smartling": newSmartling,
+	"transifex": newTransifex,
+	"weblate":   newWeblate,
+}
+
+// remote checks the translations of a provider.
+func remote(args []string) {
+	var names []string
+	for name := range providers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	if len(args) < 1 || providers[args[0]] == nil {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v remote <provider> [flags]\nproviders: %v\n",
+			os.Args[0], strings.Join(names, ", "))
+		os.Exit(1)
+	}
+
+	name := args[0]
+	flags := flag.NewFlagSet("remote "+name, flag.ExitOnError)
+	p := providers[name](flags)
+	report := flags.Bool("report", false, fmt.Sprintf("push the findings back to %v", name))
+	pull := flags.String("pull", "", "write the translations as <lang>.json files to this directory if there are no errors")
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v remote %v [flags]\n", os.Args[0], name)
+		flags.PrintDefaults()
+	}
+	flags.Parse(args[1:])
+
+	config := loadConfig(*configPath)
+	translations, err := p.fetch()
+	if err != nil {
+		log.Fatalf("remote %v: %v", name, err)
+	}
+	checked := translations
+	if m, ok := p.(placeholderMapper); ok {
+		checked = mapTranslations(translations, m.mapPlaceholders)
+	}
+	findings := runChecks(loadChecks(config), checked)
+	reportText(os.Stderr, findings, config.Rules)
+
+	if *report && len(findings) > 0 {
+		if err := p.report(findings); err != nil {
+			log.Fatalf("remote %v: %v", name, err)
+		}
+	}
+	// The warnings are reported, but neither fail the run nor block the pull.
+	if failsBudgets(findings, config.Rules, languageBudgets(config)) {
+		os.Exit(1)
+	}
+
+	if *pull != "" {
+		for lang := range translations {
+			if !validPullLanguage(lang) {
+				log.Fatalf("remote %v: invalid language code %q", name, lang)
+			}
+		}
+		for lang, translation := range translations {
+			path := filepath.Join(*pull, lang+".json")
+			if err := writeTranslation(path, translation, rawValues{}); err != nil {
+				log.Fatalf("remote %v: %v", name, err)
+			}
+		}
+	}
+}
+
+// envFlag registers a string flag in flags defaulting to the value of the environment variable env.
+func envFlag(flags *flag.FlagSet, p *string, name, env, usage string) {
+	flags.StringVar(p, name, os.Getenv(env), fmt.Sprintf("%v (default $%v)", usage, env))
+}
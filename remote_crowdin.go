@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+)
+
+// crowdinPageSize is the maximum page size of the Crowdin API.
+const crowdinPageSize = 500
+
+// crowdin reads the translations of a Crowdin project, using the string identifiers as keys.
+// See https://developer.crowdin.com/api/v2/
+type crowdin struct {
+	api     string
+	project string
+	token   string
+
+	client *apiClient
+	// stringIDs maps keys to the ids of the strings, filled by fetch.
+	stringIDs map[string]int
+}
+
+func newCrowdin(flags *flag.FlagSet) provider {
+	c := &crowdin{}
+	flags.StringVar(&c.api, "api", "https://api.crowdin.com/api/v2",
+		"base URL of the Crowdin API, https://<organization>.api.crowdin.com/api/v2 for Crowdin Enterprise")
+	envFlag(flags, &c.project, "project", "CROWDIN_PROJECT_ID", "Crowdin project id")
+	envFlag(flags, &c.token, "token", "CROWDIN_TOKEN", "Crowdin personal access token")
+	return c
+}
+
+// crowdinPage is a page of a Crowdin list response, whose items are wrapped in a data object.
+type crowdinPage[T any] struct {
+	Data []struct {
+		Data T `json:"data"`
+	} `json:"data"`
+}
+
+// crowdinList collects all the items of a paginated list endpoint.
+func crowdinList[T any](client *apiClient, path string) ([]T, error) {
+	var items []T
+	for offset := 0; ; offset += crowdinPageSize {
+		var page crowdinPage[T]
+		err := client.do(http.MethodGet, fmt.Sprintf("%v?limit=%v&offset=%v", path, crowdinPageSize, offset), nil, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Data {
+			items = append(items, item.Data)
+		}
+		if len(page.Data) < crowdinPageSize {
+			return items, nil
+		}
+	}
+}
+
+func (c *crowdin) fetch() (map[string]Translation, error) {
+	if c.project == "" || c.token == "" {
+		return nil, errors.New("the project id and token are required")
+	}
+	c.client = &apiClient{
+		base:   c.api,
+		header: http.Header{"Authorization": {"Bearer " + c.token}},
+	}
+
+	var project struct {
+		Data struct {
+			TargetLanguageIDs []string `json:"targetLanguageIds"`
+		} `json:"data"`
+	}
+	if err := c.client.do(http.MethodGet, "/projects/"+c.project, nil, &project); err != nil {
+		return nil, err
+	}
+
+	type crowdinString struct {
+		ID         int    `json:"id"`
+		Identifier string `json:"identifier"`
+		// Text is an object for plural strings, which are skipped.
+		Text json.RawMessage `json:"text"`
+	}
+	sourceStrings, err := crowdinList[crowdinString](c.client, fmt.Sprintf("/projects/%v/strings", c.project))
+	if err != nil {
+		return nil, err
+	}
+	translations := map[string]Translation{"en": {}}
+	keys := make(map[int]string)
+	c.stringIDs = make(map[string]int)
+	for _, s := range sourceStrings {
+		var text string
+		if json.Unmarshal(s.Text, &text) != nil {
+			continue
+		}
+		translations["en"][s.Identifier] = text
+		keys[s.ID] = s.Identifier
+		c.stringIDs[s.Identifier] = s.ID
+	}
+
+	type crowdinTranslation struct {
+		StringID int    `json:"stringId"`
+		Text     string `json:"text"`
+	}
+	for _, lang := range project.Data.TargetLanguageIDs {
+		path := fmt.Sprintf("/projects/%v/languages/%v/translations", c.project, lang)
+		langTranslations, err := crowdinList[crowdinTranslation](c.client, path)
+		if err != nil {
+			return nil, err
+		}
+		translation := Translation{}
+		for _, t := range langTranslations {
+			if key, ok := keys[t.StringID]; ok {
+				translation[key] = t.Text
+			}
+		}
+		translations[lang] = translation
+	}
+	return translations, nil
+}
+
+// report adds every finding as a translation mistake issue on its string.
+// Findings in the source strings are skipped, as those are fixed where the source files come from.
+func (c *crowdin) report(findings []Finding) error {
+	for _, finding := range findings {
+		id, ok := c.stringIDs[finding.Key]
+		if !ok || finding.Lang == "en" {
+			continue
+		}
+		comment := map[string]any{
+			"stringId":         id,
+			"targetLanguageId": finding.Lang,
+			"text":             fmt.Sprintf("check-translations: %v", finding.Message),
+			"type":             "issue",
+			"issueType":        "translation_mistake",
+		}
+		err := c.client.do(http.MethodPost, fmt.Sprintf("/projects/%v/comments", c.project), comment, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCrowdin(t *testing.T) {
+	var comments []map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/7", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"targetLanguageIds": ["sv"]}}`))
+	})
+	mux.HandleFunc("/projects/7/strings", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": [
+			{"data": {"id": 1, "identifier": "greeting", "text": "Hello $name$"}},
+			{"data": {"id": 2, "identifier": "items", "text": {"one": "$n$ item", "other": "$n$ items"}}}
+		]}`))
+	})
+	mux.HandleFunc("/projects/7/languages/sv/translations", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": [{"data": {"stringId": 1, "text": "Hej $namn$"}}]}`))
+	})
+	mux.HandleFunc("/projects/7/comments", func(w http.ResponseWriter, r *http.Request) {
+		var comment map[string]any
+		json.NewDecoder(r.Body).Decode(&comment)
+		comments = append(comments, comment)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := &crowdin{api: ts.URL, project: "7", token: "secret"}
+	translations, err := c.fetch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if translations["en"]["greeting"] != "Hello $name$" || translations["sv"]["greeting"] != "Hej $namn$" {
+		t.Errorf("unexpected translations: %v", translations)
+	}
+	if _, ok := translations["en"]["items"]; ok {
+		t.Errorf("plural strings must be skipped: %v", translations)
+	}
+
+	findings := runChecks(builtinChecks, translations)
+	if err := c.report(findings); err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 1 || comments[0]["stringId"] != 1.0 || comments[0]["targetLanguageId"] != "sv" {
+		t.Errorf("unexpected comments: %v", comments)
+	}
+}
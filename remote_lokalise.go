@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// lokalisePageSize is the number of keys requested per page.
+const lokalisePageSize = 500
+
+// lokaliseTag is added to the keys with findings when reporting.
+const lokaliseTag = "check-translations"
+
+// lokalisePlaceholderRx matches Lokalise universal placeholders, e.g. [%s], [%1$s], [%s:name].
+var lokalisePlaceholderRx = regexp.MustCompile(`\[%(?:(\d+)\$)?[a-zA-Z@]+(?::([^\]]+))?\]`)
+
+// lokalise reads the translations of a Lokalise project.
+// See https://developers.lokalise.com/reference/lokalise-rest-api
+type lokalise struct {
+	api      string
+	project  string
+	token    string
+	platform string
+
+	client *apiClient
+	// keyIDs maps keys to their ids, filled by fetch.
+	keyIDs map[string]int
+}
+
+func newLokalise(flags *flag.FlagSet) provider {
+	l := &lokalise{}
+	flags.StringVar(&l.api, "api", "https://api.lokalise.com/api2", "base URL of the Lokalise API")
+	envFlag(flags, &l.project, "project", "LOKALISE_PROJECT_ID", "Lokalise project id")
+	envFlag(flags, &l.token, "token", "LOKALISE_API_TOKEN", "Lokalise API token")
+	flags.StringVar(&l.platform, "platform", "web", "platform whose key names are used: ios, android, web or other")
+	return l
+}
+
+// mapLokalisePlaceholders rewrites universal placeholders to $variable$ ones, so that the variable check
+// compares them: [%s:name] becomes $name$, [%1$s] becomes $1$ and [%s] becomes $s$.
+func mapLokalisePlaceholders(s string) string {
+	return lokalisePlaceholderRx.ReplaceAllStringFunc(s, func(placeholder string) string {
+		m := lokalisePlaceholderRx.FindStringSubmatch(placeholder)
+		switch {
+		case m[2] != "":
+			return "$" + m[2] + "$"
+		case m[1] != "":
+			return "$" + m[1] + "$"
+		}
+		format := strings.TrimPrefix(strings.TrimSuffix(placeholder, "]"), "[%")
+		return "$" + format + "$"
+	})
+}
+
+func (l *lokalise) mapPlaceholders(s string) string { return mapLokalisePlaceholders(s) }
+
+func (l *lokalise) fetch() (map[string]Translation, error) {
+	if l.project == "" || l.token == "" {
+		return nil, errors.New("the project id and token are required")
+	}
+	l.client = &apiClient{
+		base:   fmt.Sprintf("%v/projects/%v", strings.TrimSuffix(l.api, "/"), l.project),
+		header: http.Header{"X-Api-Token": {l.token}},
+	}
+
+	var project struct {
+		BaseLanguageISO string `json:"base_language_iso"`
+	}
+	if err := l.client.do(http.MethodGet, "", nil, &project); err != nil {
+		return nil, err
+	}
+
+	translations := make(map[string]Translation)
+	l.keyIDs = make(map[string]int)
+	for page := 1; ; page++ {
+		var resp struct {
+			Keys []struct {
+				KeyID        int               `json:"key_id"`
+				KeyName      map[string]string `json:"key_name"`
+				Translations []struct {
+					LanguageISO string `json:"language_iso"`
+					Translation string `json:"translation"`
+				} `json:"translations"`
+			} `json:"keys"`
+		}
+		path := fmt.Sprintf("/keys?include_translations=1&limit=%v&page=%v", lokalisePageSize, page)
+		if err := l.client.do(http.MethodGet, path, nil, &resp); err != nil {
+			return nil, err
+		}
+		for _, k := range resp.Keys {
+			key := k.KeyName[l.platform]
+			if key == "" {
+				key = k.KeyName["other"]
+			}
+			if key == "" {
+				continue
+			}
+			l.keyIDs[key] = k.KeyID
+			for _, t := range k.Translations {
+				if t.Translation == "" {
+					continue
+				}
+				lang := t.LanguageISO
+				if lang == project.BaseLanguageISO {
+					lang = "en"
+				}
+				if translations[lang] == nil {
+					translations[lang] = Translation{}
+				}
+				translations[lang][key] = t.Translation
+			}
+		}
+		if len(resp.Keys) < lokalisePageSize {
+			return translations, nil
+		}
+	}
+}
+
+// report adds every finding as a comment on its key, and tags the keys with findings.
+func (l *lokalise) report(findings []Finding) error {
+	comments := make(map[int][]map[string]string)
+	var ids []int
+	for _, finding := range findings {
+		id, ok := l.keyIDs[finding.Key]
+		if !ok {
+			continue
+		}
+		if comments[id] == nil {
+			ids = append(ids, id)
+		}
+		comments[id] = append(comments[id], map[string]string{
+			"comment": fmt.Sprintf("check-translations [%v]: %v", finding.Lang, finding.Message),
+		})
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var keys []map[string]any
+	for _, id := range ids {
+		err := l.client.do(http.MethodPost, fmt.Sprintf("/keys/%v/comments", id),
+			map[string]any{"comments": comments[id]}, nil)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, map[string]any{"key_id": id, "tags": []string{lokaliseTag}, "merge_tags": true})
+	}
+	return l.client.do(http.MethodPut, "/keys", map[string]any{"keys": keys}, nil)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestMapLokalisePlaceholders(t *testing.T) {
+	var tests = []struct {
+		input, want string
+	}{
+		{"Hello [%s:name]!", "Hello $name$!"},
+		{"[%1$s] of [%2$d]", "$1$ of $2$"},
+		{"[%s] and [%d]", "$s$ and $d$"},
+		{"[not a placeholder] $kept$", "[not a placeholder] $kept$"},
+	}
+	for _, test := range tests {
+		if got := mapLokalisePlaceholders(test.input); got != test.want {
+			t.Errorf("%q: want %q, got %q", test.input, test.want, got)
+		}
+	}
+}
+
+// lokaliseServer serves a Lokalise project p1 with the keys JSON array.
+func lokaliseServer(keys string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/p1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"base_language_iso": "en_US"}`))
+	})
+	mux.HandleFunc("/projects/p1/keys", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Token") != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"keys": ` + keys + `}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestLokalise(t *testing.T) {
+	ts := lokaliseServer(`[{"key_id": 3, "key_name": {"web": "greeting", "ios": "Greeting"}, "translations": [
+		{"language_iso": "en_US", "translation": "Hello [%s:name]"},
+		{"language_iso": "sv", "translation": "Hej [%s:namn]"},
+		{"language_iso": "de", "translation": ""}
+	]}]`)
+	defer ts.Close()
+
+	l := &lokalise{api: ts.URL, project: "p1", token: "secret", platform: "web"}
+	translations, err := l.fetch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The placeholders are kept as they are, they are only mapped on the translations which are checked.
+	if translations["en"]["greeting"] != "Hello [%s:name]" || translations["sv"]["greeting"] != "Hej [%s:namn]" {
+		t.Errorf("unexpected translations: %v", translations)
+	}
+	if _, ok := translations["de"]; ok {
+		t.Errorf("empty translations must be skipped: %v", translations)
+	}
+	if l.keyIDs["greeting"] != 3 {
+		t.Errorf("unexpected key ids: %v", l.keyIDs)
+	}
+}
+
+func TestLokalisePull(t *testing.T) {
+	ts := lokaliseServer(`[{"key_id": 3, "key_name": {"web": "greeting"}, "translations": [
+		{"language_iso": "en", "translation": "Hello [%s:name]"},
+		{"language_iso": "sv", "translation": "Hej [%s:name]"}
+	]}, {"key_id": 4, "key_name": {"web": "count"}, "translations": [
+		{"language_iso": "en", "translation": "[%s] files"},
+		{"language_iso": "sv", "translation": "[%s] filer"}
+	]}]`)
+	defer ts.Close()
+	providers["lokalise-test"] = func(flags *flag.FlagSet) provider {
+		return &lokalise{api: ts.URL, project: "p1", token: "secret", platform: "web"}
+	}
+	defer delete(providers, "lokalise-test")
+
+	dir := t.TempDir()
+	remote([]string{"lokalise-test", "-pull", dir})
+	translation, _, err := loadTranslation(filepath.Join(dir, "sv.json"), arraysUnsupported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if translation["greeting"] != "Hej [%s:name]" || translation["count"] != "[%s] filer" {
+		t.Errorf("want the placeholders to be pulled unchanged, got %v", translation)
+	}
+}
+
+func TestValidPullLanguage(t *testing.T) {
+	for lang, want := range map[string]bool{"sv": true, "pt-BR": true, "": false, ".": false, "..": false,
+		"../sv": false, "a/b": false, `a\b`: false} {
+		if got := validPullLanguage(lang); got != want {
+			t.Errorf("%q: want %v, got %v", lang, want, got)
+		}
+	}
+}
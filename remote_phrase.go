@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// phrasePageSize is the maximum page size of the Phrase API.
+const phrasePageSize = 100
+
+// phrase reads the translations of a Phrase Strings project.
+// See https://developers.phrase.com/api/
+type phrase struct {
+	api     string
+	project string
+	token   string
+
+	client *apiClient
+	// localeIDs maps languages to the ids of their locales, filled by fetch.
+	localeIDs map[string]string
+}
+
+func newPhrase(flags *flag.FlagSet) provider {
+	p := &phrase{}
+	flags.StringVar(&p.api, "api", "https://api.phrase.com/v2",
+		"base URL of the Phrase API, https://api.us.app.phrase.com/v2 for the US data center")
+	envFlag(flags, &p.project, "project", "PHRASE_PROJECT_ID", "Phrase project id")
+	envFlag(flags, &p.token, "token", "PHRASE_ACCESS_TOKEN", "Phrase access token")
+	return p
+}
+
+// phraseList collects all the items of a paginated list endpoint.
+func phraseList[T any](client *apiClient, path string) ([]T, error) {
+	var items []T
+	for page := 1; ; page++ {
+		var resp []T
+		err := client.do(http.MethodGet, fmt.Sprintf("%v?per_page=%v&page=%v", path, phrasePageSize, page), nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, resp...)
+		if len(resp) < phrasePageSize {
+			return items, nil
+		}
+	}
+}
+
+func (p *phrase) fetch() (map[string]Translation, error) {
+	if p.project == "" || p.token == "" {
+		return nil, errors.New("the project id and token are required")
+	}
+	p.client = &apiClient{
+		base:   fmt.Sprintf("%v/projects/%v", strings.TrimSuffix(p.api, "/"), p.project),
+		header: http.Header{"Authorization": {"token " + p.token}},
+	}
+
+	type phraseLocale struct {
+		ID      string `json:"id"`
+		Code    string `json:"code"`
+		Default bool   `json:"default"`
+	}
+	locales, err := phraseList[phraseLocale](p.client, "/locales")
+	if err != nil {
+		return nil, err
+	}
+
+	translations := make(map[string]Translation)
+	p.localeIDs = make(map[string]string)
+	for _, locale := range locales {
+		lang := locale.Code
+		if locale.Default {
+			lang = "en"
+		}
+		// The simple JSON format is a flat object of key -> translation, just like the local files.
+		var translation Translation
+		path := fmt.Sprintf("/locales/%v/download?file_format=simple_json", locale.ID)
+		if err := p.client.do(http.MethodGet, path, nil, &translation); err != nil {
+			return nil, fmt.Errorf("%v: %w", locale.Code, err)
+		}
+		translations[lang] = translation
+		p.localeIDs[lang] = locale.ID
+	}
+	return translations, nil
+}
+
+// report marks the translations with findings as unverified, so they re-enter the review queue.
+// Findings in the default locale are skipped, as its translations are the source of the others.
+func (p *phrase) report(findings []Finding) error {
+	keys := make(map[string]map[string]bool)
+	for _, finding := range findings {
+		if finding.Lang == "en" || finding.Key == "" {
+			continue
+		}
+		if keys[finding.Lang] == nil {
+			keys[finding.Lang] = make(map[string]bool)
+		}
+		keys[finding.Lang][finding.Key] = true
+	}
+
+	type phraseTranslation struct {
+		ID  string `json:"id"`
+		Key struct {
+			Name string `json:"name"`
+		} `json:"key"`
+	}
+	for lang, langKeys := range keys {
+		localeID, ok := p.localeIDs[lang]
+		if !ok {
+			continue
+		}
+		localeTranslations, err := phraseList[phraseTranslation](p.client, fmt.Sprintf("/locales/%v/translations", localeID))
+		if err != nil {
+			return err
+		}
+		for _, t := range localeTranslations {
+			if !langKeys[t.Key.Name] {
+				continue
+			}
+			err := p.client.do(http.MethodPatch, fmt.Sprintf("/translations/%v/unverify", t.ID), map[string]any{}, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPhrase(t *testing.T) {
+	var unverified []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/p1/locales", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": "l-en", "code": "en-US", "default": true}, {"id": "l-sv", "code": "sv"}]`))
+	})
+	mux.HandleFunc("/projects/p1/locales/l-en/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"greeting": "Hello $name$", "bye": "Bye"}`))
+	})
+	mux.HandleFunc("/projects/p1/locales/l-sv/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"greeting": "Hej $namn$", "bye": "Hej då"}`))
+	})
+	mux.HandleFunc("/projects/p1/locales/l-sv/translations", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": "t1", "key": {"name": "greeting"}}, {"id": "t2", "key": {"name": "bye"}}]`))
+	})
+	mux.HandleFunc("/projects/p1/translations/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			unverified = append(unverified, r.URL.Path)
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	p := &phrase{api: ts.URL, project: "p1", token: "secret"}
+	translations, err := p.fetch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if translations["en"]["greeting"] != "Hello $name$" || translations["sv"]["bye"] != "Hej då" {
+		t.Errorf("unexpected translations: %v", translations)
+	}
+	if err := p.report(runChecks(builtinChecks, translations)); err != nil {
+		t.Fatal(err)
+	}
+	if len(unverified) != 1 || unverified[0] != "/projects/p1/translations/t1/unverify" {
+		t.Errorf("unexpected unverified translations: %v", unverified)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+)
+
+// poeditor reads the translations of a POEditor project, using the terms as keys.
+// See https://poeditor.com/docs/api
+type poeditor struct {
+	api     string
+	project string
+	token   string
+
+	client *apiClient
+	// reference is the code of the language used as the english reference, filled by fetch.
+	reference string
+	// contexts maps terms to their contexts, which identify terms together with them, filled by fetch.
+	contexts map[string]string
+}
+
+func newPOEditor(flags *flag.FlagSet) provider {
+	p := &poeditor{}
+	flags.StringVar(&p.api, "api", "https://api.poeditor.com/v2", "base URL of the POEditor API")
+	envFlag(flags, &p.project, "project", "POEDITOR_PROJECT_ID", "POEditor project id")
+	envFlag(flags, &p.token, "token", "POEDITOR_API_TOKEN", "POEditor API token")
+	return p
+}
+
+// call posts to an API endpoint and decodes its result into out.
+// POEditor reports failures in the response body, with a successful HTTP status.
+func (p *poeditor) call(path string, form url.Values, out any) error {
+	form.Set("api_token", p.token)
+	form.Set("id", p.project)
+	var resp struct {
+		Response struct {
+			Status  string `json:"status"`
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"response"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := p.client.postForm(path, form, &resp); err != nil {
+		return err
+	}
+	if resp.Response.Status != "success" {
+		return fmt.Errorf("%v: %v %v", path, resp.Response.Code, resp.Response.Message)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, out)
+}
+
+func (p *poeditor) fetch() (map[string]Translation, error) {
+	if p.project == "" || p.token == "" {
+		return nil, errors.New("the project id and token are required")
+	}
+	p.client = &apiClient{base: p.api}
+
+	var project struct {
+		Project struct {
+			ReferenceLanguage string `json:"reference_language"`
+		} `json:"project"`
+	}
+	if err := p.call("/projects/view", url.Values{}, &project); err != nil {
+		return nil, err
+	}
+	p.reference = project.Project.ReferenceLanguage
+	if p.reference == "" {
+		p.reference = "en"
+	}
+
+	var languages struct {
+		Languages []struct {
+			Code string `json:"code"`
+		} `json:"languages"`
+	}
+	if err := p.call("/languages/list", url.Values{}, &languages); err != nil {
+		return nil, err
+	}
+
+	translations := make(map[string]Translation)
+	p.contexts = make(map[string]string)
+	for _, language := range languages.Languages {
+		var terms struct {
+			Terms []struct {
+				Term        string `json:"term"`
+				Context     string `json:"context"`
+				Translation struct {
+					// Content is an object for plural terms, which are skipped.
+					Content json.RawMessage `json:"content"`
+				} `json:"translation"`
+			} `json:"terms"`
+		}
+		if err := p.call("/terms/list", url.Values{"language": {language.Code}}, &terms); err != nil {
+			return nil, err
+		}
+		lang := language.Code
+		if lang == p.reference {
+			lang = "en"
+		}
+		translation := Translation{}
+		for _, term := range terms.Terms {
+			var content string
+			if json.Unmarshal(term.Translation.Content, &content) != nil || content == "" {
+				continue
+			}
+			translation[term.Term] = content
+			p.contexts[term.Term] = term.Context
+		}
+		translations[lang] = translation
+	}
+	return translations, nil
+}
+
+// report adds every finding as a comment on its term.
+func (p *poeditor) report(findings []Finding) error {
+	var comments []map[string]string
+	for _, finding := range findings {
+		context, ok := p.contexts[finding.Key]
+		if !ok {
+			continue
+		}
+		comments = append(comments, map[string]string{
+			"term":    finding.Key,
+			"context": context,
+			"comment": fmt.Sprintf("check-translations [%v]: %v", finding.Lang, finding.Message),
+		})
+	}
+	if len(comments) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(comments)
+	if err != nil {
+		return err
+	}
+	return p.call("/terms/add_comment", url.Values{"data": {string(data)}}, nil)
+}
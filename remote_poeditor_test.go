@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPOEditor(t *testing.T) {
+	var comments []map[string]string
+	terms := map[string]string{
+		"en": `[{"term": "greeting", "context": "", "translation": {"content": "Hello $name$"}},
+			{"term": "items", "context": "", "translation": {"content": {"one": "$n$ item", "other": "$n$ items"}}}]`,
+		"sv": `[{"term": "greeting", "context": "", "translation": {"content": "Hej $namn$"}}]`,
+	}
+	results := map[string]func(r *http.Request) string{
+		"/projects/view": func(r *http.Request) string { return `{"project": {"reference_language": "en"}}` },
+		"/languages/list": func(r *http.Request) string {
+			return `{"languages": [{"code": "en"}, {"code": "sv"}]}`
+		},
+		"/terms/list": func(r *http.Request) string {
+			return fmt.Sprintf(`{"terms": %v}`, terms[r.FormValue("language")])
+		},
+		"/terms/add_comment": func(r *http.Request) string {
+			json.Unmarshal([]byte(r.FormValue("data")), &comments)
+			return `{}`
+		},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result, ok := results[r.URL.Path]
+		if !ok || r.FormValue("api_token") != "secret" || r.FormValue("id") != "42" {
+			w.Write([]byte(`{"response": {"status": "fail", "code": "4011", "message": "Invalid API Token"}}`))
+			return
+		}
+		fmt.Fprintf(w, `{"response": {"status": "success", "code": "200"}, "result": %v}`, result(r))
+	}))
+	defer ts.Close()
+
+	p := &poeditor{api: ts.URL, project: "42", token: "secret"}
+	translations, err := p.fetch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if translations["en"]["greeting"] != "Hello $name$" || translations["sv"]["greeting"] != "Hej $namn$" {
+		t.Errorf("unexpected translations: %v", translations)
+	}
+	if _, ok := translations["en"]["items"]; ok {
+		t.Errorf("plural terms must be skipped: %v", translations)
+	}
+	if err := p.report(runChecks(builtinChecks, translations)); err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 1 || comments[0]["term"] != "greeting" {
+		t.Errorf("unexpected comments: %v", comments)
+	}
+
+	p = &poeditor{api: ts.URL, project: "42", token: "wrong"}
+	if _, err := p.fetch(); err == nil {
+		t.Errorf("want error for a failed response")
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// smartlingMaxFile limits the size of the files downloaded from Smartling.
+const smartlingMaxFile = 256 << 20
+
+// smartlingPageSize is the number of source strings listed per request, the most the API allows.
+const smartlingPageSize = 500
+
+// smartling reads the translations of a JSON file uploaded to a Smartling project.
+// See https://api-reference.smartling.com
+type smartling struct {
+	api       string
+	project   string
+	file      string
+	userID    string
+	secret    string
+	retrieval string
+
+	client *apiClient
+	// locales maps languages to the Smartling locale ids, filled by fetch.
+	locales map[string]string
+}
+
+func newSmartling(flags *flag.FlagSet) provider {
+	s := &smartling{}
+	flags.StringVar(&s.api, "api", "https://api.smartling.com", "base URL of the Smartling API")
+	envFlag(flags, &s.project, "project", "SMARTLING_PROJECT_ID", "Smartling project id")
+	flags.StringVar(&s.file, "file", "", "URI of the file in the project, e.g. /locales/en.json")
+	envFlag(flags, &s.userID, "user", "SMARTLING_USER_IDENTIFIER", "Smartling API user identifier")
+	envFlag(flags, &s.secret, "secret", "SMARTLING_USER_SECRET", "Smartling API user secret")
+	flags.StringVar(&s.retrieval, "retrieval", "pending",
+		"translations to download: published, or pending to include those still in progress")
+	return s
+}
+
+// smartlingResponse is the envelope of all the Smartling API responses.
+type smartlingResponse[T any] struct {
+	Response struct {
+		Code string `json:"code"`
+		Data T      `json:"data"`
+	} `json:"response"`
+}
+
+// authenticate exchanges the user identifier and secret for an access token.
+func (s *smartling) authenticate() error {
+	var auth smartlingResponse[struct {
+		AccessToken string `json:"accessToken"`
+	}]
+	body := map[string]string{"userIdentifier": s.userID, "userSecret": s.secret}
+	anonymous := &apiClient{base: s.api}
+	if err := anonymous.do(http.MethodPost, "/auth-api/v2/authenticate", body, &auth); err != nil {
+		return err
+	}
+	s.client = &apiClient{
+		base:   s.api,
+		header: http.Header{"Authorization": {"Bearer " + auth.Response.Data.AccessToken}},
+	}
+	return nil
+}
+
+// download fetches a file from the files API and parses it as a translation.
+func (s *smartling) download(path string) (Translation, error) {
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(s.api, "/")+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = s.client.header.Clone()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	bs, err := io.ReadAll(io.LimitReader(resp.Body, smartlingMaxFile+1))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %v: %v: %s", req.URL, resp.Status, bytes.TrimSpace(bs[:min(len(bs), 4096)]))
+	}
+	if len(bs) > smartlingMaxFile {
+		return nil, fmt.Errorf("GET %v: the file is bigger than %v MiB", req.URL, smartlingMaxFile>>20)
+	}
+	return parseTranslation(bs)
+}
+
+func (s *smartling) fetch() (map[string]Translation, error) {
+	if s.project == "" || s.file == "" || s.userID == "" || s.secret == "" {
+		return nil, errors.New("the project id, file URI, user identifier and secret are required")
+	}
+	if err := s.authenticate(); err != nil {
+		return nil, err
+	}
+
+	var project smartlingResponse[struct {
+		SourceLocaleID string `json:"sourceLocaleId"`
+		TargetLocales  []struct {
+			LocaleID string `json:"localeId"`
+			Enabled  bool   `json:"enabled"`
+		} `json:"targetLocales"`
+	}]
+	if err := s.client.do(http.MethodGet, "/projects-api/v2/projects/"+url.PathEscape(s.project), nil, &project); err != nil {
+		return nil, err
+	}
+
+	fileURI := url.QueryEscape(s.file)
+	source, err := s.download(fmt.Sprintf("/files-api/v2/projects/%v/file?fileUri=%v", url.PathEscape(s.project), fileURI))
+	if err != nil {
+		return nil, err
+	}
+	translations := map[string]Translation{"en": source}
+	s.locales = map[string]string{"en": project.Response.Data.SourceLocaleID}
+	for _, locale := range project.Response.Data.TargetLocales {
+		if !locale.Enabled {
+			continue
+		}
+		// Without the original strings, untranslated strings are downloaded empty.
+		path := fmt.Sprintf("/files-api/v2/projects/%v/locales/%v/file?fileUri=%v&retrievalType=%v&includeOriginalStrings=false",
+			url.PathEscape(s.project), url.PathEscape(locale.LocaleID), fileURI, url.QueryEscape(s.retrieval))
+		translation, err := s.download(path)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", locale.LocaleID, err)
+		}
+		translations[locale.LocaleID] = translation
+		s.locales[locale.LocaleID] = locale.LocaleID
+	}
+	return translations, nil
+}
+
+// hashcodes returns the hashcodes of the source strings of the file, by key, listed page by page.
+func (s *smartling) hashcodes() (map[string]string, error) {
+	hashcodes := make(map[string]string)
+	for offset := 0; ; {
+		var sourceStrings smartlingResponse[struct {
+			TotalCount int `json:"totalCount"`
+			Items      []struct {
+				Hashcode string `json:"hashcode"`
+				Keys     []struct {
+					Key string `json:"key"`
+				} `json:"keys"`
+			} `json:"items"`
+		}]
+		path := fmt.Sprintf("/strings-api/v2/projects/%v/source-strings?fileUri=%v&limit=%v&offset=%v",
+			url.PathEscape(s.project), url.QueryEscape(s.file), smartlingPageSize, offset)
+		if err := s.client.do(http.MethodGet, path, nil, &sourceStrings); err != nil {
+			return nil, err
+		}
+		data := sourceStrings.Response.Data
+		for _, item := range data.Items {
+			for _, key := range item.Keys {
+				hashcodes[key.Key] = item.Hashcode
+			}
+		}
+		offset += len(data.Items)
+		if len(data.Items) == 0 || offset >= data.TotalCount {
+			return hashcodes, nil
+		}
+	}
+}
+
+// report opens a translation issue on the string of every finding, in the locale of the finding.
+// Findings in the source strings are opened as source issues.
+func (s *smartling) report(findings []Finding) error {
+	hashcodes, err := s.hashcodes()
+	if err != nil {
+		return err
+	}
+
+	for _, finding := range findings {
+		hashcode, ok := hashcodes[finding.Key]
+		if !ok {
+			continue
+		}
+		issue := map[string]any{
+			"issueText":        fmt.Sprintf("check-translations: %v", finding.Message),
+			"issueTypeCode":    "TRANSLATION",
+			"issueSubTypeCode": "POOR_TRANSLATION",
+			"string":           map[string]string{"hashcode": hashcode, "localeId": s.locales[finding.Lang]},
+		}
+		if finding.Lang == "en" {
+			issue["issueTypeCode"] = "SOURCE"
+			issue["issueSubTypeCode"] = "CLARIFICATION"
+		}
+		var resp smartlingResponse[json.RawMessage]
+		path := fmt.Sprintf("/issues-api/v2/projects/%v/issues", url.PathEscape(s.project))
+		if err := s.client.do(http.MethodPost, path, issue, &resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
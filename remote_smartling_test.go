@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestSmartling(t *testing.T) {
+	var issues []map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth-api/v2/authenticate", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response": {"code": "SUCCESS", "data": {"accessToken": "token"}}}`))
+	})
+	authorized := func(h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			h(w, r)
+		}
+	}
+	mux.HandleFunc("/projects-api/v2/projects/p1", authorized(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response": {"code": "SUCCESS", "data": {"sourceLocaleId": "en-US",
+			"targetLocales": [{"localeId": "sv-SE", "enabled": true}, {"localeId": "de-DE", "enabled": false}]}}}`))
+	}))
+	mux.HandleFunc("/files-api/v2/projects/p1/file", authorized(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"greeting": "Hello $name$", "farewell": "Bye $name$"}`))
+	}))
+	mux.HandleFunc("/files-api/v2/projects/p1/locales/sv-SE/file", authorized(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("fileUri") != "/locales/en.json" || r.URL.Query().Get("retrievalType") != "pending" {
+			http.Error(w, "unexpected query", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"greeting": "Hej $namn$", "farewell": "Hejdå $namn$"}`))
+	}))
+	mux.HandleFunc("/strings-api/v2/projects/p1/source-strings", authorized(func(w http.ResponseWriter, r *http.Request) {
+		// The strings are listed a page of one at a time.
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			w.Write([]byte(`{"response": {"code": "SUCCESS", "data": {"totalCount": 2, "items": [{"hashcode": "h1", "keys": [{"key": "greeting"}]}]}}}`))
+		case "1":
+			w.Write([]byte(`{"response": {"code": "SUCCESS", "data": {"totalCount": 2, "items": [{"hashcode": "h2", "keys": [{"key": "farewell"}]}]}}}`))
+		default:
+			http.Error(w, "unexpected offset", http.StatusBadRequest)
+		}
+	}))
+	mux.HandleFunc("/issues-api/v2/projects/p1/issues", authorized(func(w http.ResponseWriter, r *http.Request) {
+		var issue map[string]any
+		json.NewDecoder(r.Body).Decode(&issue)
+		issues = append(issues, issue)
+		w.Write([]byte(`{"response": {"code": "SUCCESS", "data": {}}}`))
+	}))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	s := &smartling{api: ts.URL, project: "p1", file: "/locales/en.json", userID: "u", secret: "s", retrieval: "pending"}
+	translations, err := s.fetch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(translations) != 2 || translations["en"]["greeting"] != "Hello $name$" || translations["sv-SE"]["farewell"] != "Hejdå $namn$" {
+		t.Errorf("unexpected translations: %v", translations)
+	}
+	if err := s.report(runChecks(builtinChecks, translations)); err != nil {
+		t.Fatal(err)
+	}
+	var hashcodes []string
+	for _, issue := range issues {
+		hashcodes = append(hashcodes, issue["string"].(map[string]any)["hashcode"].(string))
+	}
+	slices.Sort(hashcodes)
+	if !slices.Equal(hashcodes, []string{"h1", "h2"}) {
+		t.Errorf("unexpected issues: %v", issues)
+	}
+}
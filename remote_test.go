@@ -0,0 +1,29 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// staticProvider is a provider of fixed translations.
+type staticProvider map[string]Translation
+
+func (p staticProvider) fetch() (map[string]Translation, error) { return p, nil }
+
+func (p staticProvider) report(findings []Finding) error { return nil }
+
+func TestRemoteWarnings(t *testing.T) {
+	// The email address is only a warning, which doesn't block the pull.
+	providers["static"] = func(flags *flag.FlagSet) provider {
+		return staticProvider{"en": {"a": "Contact us"}, "sv": {"a": "Kontakta support@example.com"}}
+	}
+	defer delete(providers, "static")
+
+	dir := t.TempDir()
+	remote([]string{"static", "-pull", dir})
+	if _, err := os.Stat(filepath.Join(dir, "sv.json")); err != nil {
+		t.Errorf("want sv.json to be pulled: %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// transifex reads the translations of a Transifex resource.
+// See https://developers.transifex.com/reference/api-introduction
+type transifex struct {
+	api      string
+	resource string
+	token    string
+
+	client *apiClient
+	// stringIDs maps keys to the ids of the resource strings, filled by fetch.
+	stringIDs map[string]string
+	// languageIDs maps languages to the Transifex language ids, filled by fetch.
+	languageIDs map[string]string
+}
+
+func newTransifex(flags *flag.FlagSet) provider {
+	t := &transifex{}
+	flags.StringVar(&t.api, "api", "https://rest.api.transifex.com", "base URL of the Transifex API")
+	envFlag(flags, &t.resource, "resource", "TRANSIFEX_RESOURCE", "Transifex resource id, o:<organization>:p:<project>:r:<resource>")
+	envFlag(flags, &t.token, "token", "TX_TOKEN", "Transifex API token")
+	return t
+}
+
+// transifexObject is a JSON:API resource object as returned by the Transifex API.
+type transifexObject struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Code    string            `json:"code"`
+		Key     string            `json:"key"`
+		Strings map[string]string `json:"strings"`
+	} `json:"attributes"`
+	Relationships map[string]struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	} `json:"relationships"`
+}
+
+// transifexList collects all the objects of a paginated collection, and the objects included with them.
+func transifexList(client *apiClient, path string) (data, included []transifexObject, err error) {
+	for path != "" {
+		var page struct {
+			Data     []transifexObject `json:"data"`
+			Included []transifexObject `json:"included"`
+			Links    struct {
+				Next string `json:"next"`
+			} `json:"links"`
+		}
+		if err := client.do(http.MethodGet, path, nil, &page); err != nil {
+			return nil, nil, err
+		}
+		data = append(data, page.Data...)
+		included = append(included, page.Included...)
+		path = page.Links.Next
+	}
+	return data, included, nil
+}
+
+func (t *transifex) fetch() (map[string]Translation, error) {
+	project, _, ok := strings.Cut(t.resource, ":r:")
+	if !ok || t.token == "" {
+		return nil, errors.New("the resource id, in the o:<organization>:p:<project>:r:<resource> form, and token are required")
+	}
+	t.client = &apiClient{
+		base: t.api,
+		header: http.Header{
+			"Authorization": {"Bearer " + t.token},
+			"Accept":        {"application/vnd.api+json"},
+			"Content-Type":  {"application/vnd.api+json"},
+		},
+	}
+
+	var projectResp struct {
+		Data transifexObject `json:"data"`
+	}
+	if err := t.client.do(http.MethodGet, "/projects/"+url.PathEscape(project), nil, &projectResp); err != nil {
+		return nil, err
+	}
+	sourceLanguage := projectResp.Data.Relationships["source_language"].Data.ID
+
+	sourceStrings, _, err := transifexList(t.client, "/resource_strings?filter[resource]="+url.QueryEscape(t.resource))
+	if err != nil {
+		return nil, err
+	}
+	translations := map[string]Translation{"en": {}}
+	keys := make(map[string]string)
+	t.stringIDs = make(map[string]string)
+	t.languageIDs = map[string]string{"en": sourceLanguage}
+	for _, s := range sourceStrings {
+		// Plural strings have several forms, only the singular ones are checked.
+		if len(s.Attributes.Strings) != 1 {
+			continue
+		}
+		translations["en"][s.Attributes.Key] = s.Attributes.Strings["other"]
+		keys[s.ID] = s.Attributes.Key
+		t.stringIDs[s.Attributes.Key] = s.ID
+	}
+
+	languages, _, err := transifexList(t.client, fmt.Sprintf("/projects/%v/languages", url.PathEscape(project)))
+	if err != nil {
+		return nil, err
+	}
+	for _, language := range languages {
+		path := fmt.Sprintf("/resource_translations?filter[resource]=%v&filter[language]=%v",
+			url.QueryEscape(t.resource), url.QueryEscape(language.ID))
+		resourceTranslations, _, err := transifexList(t.client, path)
+		if err != nil {
+			return nil, err
+		}
+		translation := Translation{}
+		for _, rt := range resourceTranslations {
+			key, ok := keys[rt.Relationships["resource_string"].Data.ID]
+			if ok && rt.Attributes.Strings["other"] != "" {
+				translation[key] = rt.Attributes.Strings["other"]
+			}
+		}
+		translations[language.Attributes.Code] = translation
+		t.languageIDs[language.Attributes.Code] = language.ID
+	}
+	return translations, nil
+}
+
+// report opens an issue on the resource string of every finding, in the language of the finding.
+func (t *transifex) report(findings []Finding) error {
+	for _, finding := range findings {
+		id, ok := t.stringIDs[finding.Key]
+		if !ok {
+			continue
+		}
+		comment := map[string]any{
+			"data": map[string]any{
+				"type": "resource_string_comments",
+				"attributes": map[string]any{
+					"message":  fmt.Sprintf("check-translations: %v", finding.Message),
+					"type":     "issue",
+					"priority": "normal",
+				},
+				"relationships": map[string]any{
+					"resource_string": map[string]any{"data": map[string]string{"type": "resource_strings", "id": id}},
+					"language":        map[string]any{"data": map[string]string{"type": "languages", "id": t.languageIDs[finding.Lang]}},
+				},
+			},
+		}
+		if err := t.client.do(http.MethodPost, "/resource_string_comments", comment, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
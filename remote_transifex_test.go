@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransifex(t *testing.T) {
+	var comments []map[string]any
+	mux := http.NewServeMux()
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	mux.HandleFunc("/projects/o:acme:p:web", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"id": "o:acme:p:web", "relationships": {"source_language": {"data": {"id": "l:en"}}}}}`))
+	})
+	mux.HandleFunc("/projects/o:acme:p:web/languages", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": [{"id": "l:sv", "attributes": {"code": "sv"}}]}`))
+	})
+	mux.HandleFunc("/resource_strings", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			fmt.Fprintf(w, `{"data": [{"id": "s1", "attributes": {"key": "greeting", "strings": {"other": "Hello $name$"}}}],
+				"links": {"next": "%v/resource_strings?page=2"}}`, ts.URL)
+			return
+		}
+		w.Write([]byte(`{"data": [{"id": "s2", "attributes": {"key": "items", "strings": {"one": "$n$ item", "other": "$n$ items"}}}]}`))
+	})
+	mux.HandleFunc("/resource_translations", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("filter[language]") != "l:sv" {
+			http.Error(w, "unexpected language", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"data": [{"id": "t1", "attributes": {"strings": {"other": "Hej $namn$"}},
+			"relationships": {"resource_string": {"data": {"id": "s1"}}}}]}`))
+	})
+	mux.HandleFunc("/resource_string_comments", func(w http.ResponseWriter, r *http.Request) {
+		var comment map[string]any
+		json.NewDecoder(r.Body).Decode(&comment)
+		comments = append(comments, comment)
+	})
+
+	tx := &transifex{api: ts.URL, resource: "o:acme:p:web:r:strings", token: "secret"}
+	translations, err := tx.fetch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if translations["en"]["greeting"] != "Hello $name$" || translations["sv"]["greeting"] != "Hej $namn$" {
+		t.Errorf("unexpected translations: %v", translations)
+	}
+	if _, ok := translations["en"]["items"]; ok {
+		t.Errorf("plural strings must be skipped: %v", translations)
+	}
+	if err := tx.report(runChecks(builtinChecks, translations)); err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 1 {
+		t.Fatalf("want 1 comment, got %v", comments)
+	}
+	language := comments[0]["data"].(map[string]any)["relationships"].(map[string]any)["language"]
+	if id := language.(map[string]any)["data"].(map[string]any)["id"]; id != "l:sv" {
+		t.Errorf("want comment in l:sv, got %v", id)
+	}
+}
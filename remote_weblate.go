@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// weblateStateNeedsEditing is the state of units translators are asked to revisit.
+const weblateStateNeedsEditing = 10
+
+// weblate reads the translations of a Weblate component, using the unit contexts as keys,
+// as Weblate does for monolingual JSON files.
+// See https://docs.weblate.org/en/latest/api.html
+type weblate struct {
+	api       string
+	project   string
+	component string
+	token     string
+
+	client *apiClient
+	// unitIDs maps languages and keys to the ids of the units, filled by fetch.
+	unitIDs map[string]map[string]int
+}
+
+func newWeblate(flags *flag.FlagSet) provider {
+	w := &weblate{}
+	envFlag(flags, &w.api, "api", "WEBLATE_URL", "base URL of the Weblate API, e.g. https://weblate.example.com/api")
+	flags.StringVar(&w.project, "project", "", "Weblate project slug")
+	flags.StringVar(&w.component, "component", "", "Weblate component slug")
+	envFlag(flags, &w.token, "token", "WEBLATE_TOKEN", "Weblate API token")
+	return w
+}
+
+// weblateList collects all the results of a paginated list endpoint.
+func weblateList[T any](client *apiClient, path string) ([]T, error) {
+	var items []T
+	for path != "" {
+		var page struct {
+			Next    string `json:"next"`
+			Results []T    `json:"results"`
+		}
+		if err := client.do(http.MethodGet, path, nil, &page); err != nil {
+			return nil, err
+		}
+		items = append(items, page.Results...)
+		path = page.Next
+	}
+	return items, nil
+}
+
+func (w *weblate) fetch() (map[string]Translation, error) {
+	if w.api == "" || w.project == "" || w.component == "" || w.token == "" {
+		return nil, errors.New("the API URL, project, component and token are required")
+	}
+	w.client = &apiClient{
+		base:   w.api,
+		header: http.Header{"Authorization": {"Token " + w.token}},
+	}
+
+	type weblateTranslation struct {
+		LanguageCode string `json:"language_code"`
+		IsSource     bool   `json:"is_source"`
+	}
+	component := url.PathEscape(w.project) + "/" + url.PathEscape(w.component)
+	componentTranslations, err := weblateList[weblateTranslation](w.client, fmt.Sprintf("/components/%v/translations/", component))
+	if err != nil {
+		return nil, err
+	}
+
+	type weblateUnit struct {
+		ID      int      `json:"id"`
+		Context string   `json:"context"`
+		Target  []string `json:"target"`
+	}
+	translations := make(map[string]Translation)
+	w.unitIDs = make(map[string]map[string]int)
+	for _, ct := range componentTranslations {
+		lang := ct.LanguageCode
+		if ct.IsSource {
+			lang = "en"
+		}
+		path := fmt.Sprintf("/translations/%v/%v/units/", component, url.PathEscape(ct.LanguageCode))
+		units, err := weblateList[weblateUnit](w.client, path)
+		if err != nil {
+			return nil, err
+		}
+		translation := Translation{}
+		w.unitIDs[lang] = make(map[string]int)
+		for _, unit := range units {
+			// Plural units have several targets, only the singular ones are checked.
+			if unit.Context == "" || len(unit.Target) != 1 || strings.TrimSpace(unit.Target[0]) == "" {
+				continue
+			}
+			translation[unit.Context] = unit.Target[0]
+			w.unitIDs[lang][unit.Context] = unit.ID
+		}
+		translations[lang] = translation
+	}
+	return translations, nil
+}
+
+// report flags the units with findings as needing editing. Weblate computes its own checks
+// and doesn't allow adding new ones through the API, so this is the closest flag translators see.
+// Findings in the source strings are skipped, as those are fixed in the repository.
+func (w *weblate) report(findings []Finding) error {
+	flagged := make(map[int]bool)
+	for _, finding := range findings {
+		id, ok := w.unitIDs[finding.Lang][finding.Key]
+		if !ok || finding.Lang == "en" || flagged[id] {
+			continue
+		}
+		flagged[id] = true
+		err := w.client.do(http.MethodPatch, fmt.Sprintf("/units/%v/", id),
+			map[string]any{"state": weblateStateNeedsEditing}, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWeblate(t *testing.T) {
+	patched := make(map[string]any)
+	mux := http.NewServeMux()
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	mux.HandleFunc("/api/components/web/strings/translations/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"results": [{"language_code": "en", "is_source": true}, {"language_code": "sv"}]}`))
+	})
+	mux.HandleFunc("/api/translations/web/strings/en/units/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"results": [{"id": 1, "context": "greeting", "target": ["Hello $name$"]}]}`))
+	})
+	mux.HandleFunc("/api/translations/web/strings/sv/units/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			fmt.Fprintf(w, `{"next": "%v/api/translations/web/strings/sv/units/?page=2",
+				"results": [{"id": 2, "context": "greeting", "target": ["Hej $namn$"]}]}`, ts.URL)
+			return
+		}
+		w.Write([]byte(`{"results": [{"id": 3, "context": "items", "target": ["$n$ sak", "$n$ saker"]}]}`))
+	})
+	mux.HandleFunc("/api/units/", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		patched[r.URL.Path] = body["state"]
+	})
+
+	wl := &weblate{api: ts.URL + "/api", project: "web", component: "strings", token: "secret"}
+	translations, err := wl.fetch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if translations["en"]["greeting"] != "Hello $name$" || translations["sv"]["greeting"] != "Hej $namn$" {
+		t.Errorf("unexpected translations: %v", translations)
+	}
+	if _, ok := translations["sv"]["items"]; ok {
+		t.Errorf("plural units must be skipped: %v", translations)
+	}
+	if err := wl.report(runChecks(builtinChecks, translations)); err != nil {
+		t.Fatal(err)
+	}
+	if len(patched) != 1 || patched["/api/units/2/"] != float64(weblateStateNeedsEditing) {
+		t.Errorf("unexpected patched units: %v", patched)
+	}
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Finding is a single problem reported by a check.
+type Finding struct {
+	// Lang is the language of the translation the problem was found in.
+	Lang string `json:"lang"`
+	// Key is the translation key, if the problem is tied to a single string.
+	Key string `json:"key,omitempty"`
+	// Check is the name of the check that reported the problem.
+	Check string `json:"check"`
+	// Message describes the problem.
+	Message string `json:"message"`
+	// Context is the description of the key for the translators, if there is one.
+	Context string `json:"context,omitempty"`
+	// Owner are the space separated owners of the key in the ownership file, if there are any.
+	Owner string `json:"owner,omitempty"`
+}
+
+// jsonFinding is a finding in the JSON outputs, with the RFC 6901 JSON Pointer of the member
+// of its translation file it's about, like /menu.save, for tools to locate and patch it.
+type jsonFinding struct {
+	Finding
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Pointer  string `json:"pointer,omitempty"`
+}
+
+// elementKeyRx matches the keys of the elements of arrays, like key[1].
+var elementKeyRx = regexp.MustCompile(`^(.*)\[(\d+)\]$`)
+
+// keyPointer returns the JSON Pointer of the member of key in a translation file,
+// or the one of the element of an array when arrays says they are read as elements.
+func keyPointer(key, arrays string) string {
+	if m := elementKeyRx.FindStringSubmatch(key); m != nil && arrays == arraysElements {
+		return "/" + pointerToken(m[1]) + "/" + m[2]
+	}
+	return "/" + pointerToken(key)
+}
+
+// jsonFindings returns findings with their rule and its severity in rules, and the pointers of the
+// ones tied to a key, within the translation files whose arrays are read as arrays says.
+func jsonFindings(findings []Finding, rules ruleSeverities, arrays string) []jsonFinding {
+	result := make([]jsonFinding, len(findings))
+	for i, finding := range findings {
+		result[i].Finding = finding
+		result[i].Rule = rules.id(finding.Check)
+		result[i].Severity = rules.of(finding.Check)
+		if finding.Key != "" {
+			result[i].Pointer = keyPointer(finding.Key, arrays)
+		}
+	}
+	return result
+}
+
+// findingsByLang groups findings by their language.
+func findingsByLang(findings []Finding) map[string][]Finding {
+	result := make(map[string][]Finding)
+	for _, finding := range findings {
+		result[finding.Lang] = append(result[finding.Lang], finding)
+	}
+	return result
+}
+
+// reportText writes a human readable report of findings to w,
+// one section per language with findings, with the identifiers of their rules in rules.
+// The findings without a language, like the directories which couldn't be read, are reported under [files].
+func reportText(w io.Writer, findings []Finding, rules ruleSeverities) {
+	reportTextLimited(w, findings, rules, reportLimits{})
+}
+
+// reportLimits cap the number of findings written by reportTextLimited, 0 being unlimited.
+// Identical findings reported once count as one.
+type reportLimits struct {
+	total   int
+	perLang int
+}
+
+// reportTextLimited is reportText writing at most limits findings,
+// with a note of how many more there are when truncated.
+// The languages and their findings are sorted, so that the same findings are always reported the same.
+func reportTextLimited(w io.Writer, findings []Finding, rules ruleSeverities, limits reportLimits) {
+	byLang := findingsByLang(findings)
+	langs := make([]string, 0, len(byLang))
+	for lang := range byLang {
+		langs = append(langs, lang)
+	}
+	// The findings without a language sort first.
+	slices.Sort(langs)
+	written := 0
+	remaining := len(findings)
+	for _, lang := range langs {
+		if limits.total > 0 && written == limits.total {
+			break
+		}
+		langFindings := slices.Clone(byLang[lang])
+		slices.SortStableFunc(langFindings, compareFindings)
+		if lang == "" {
+			lang = reportLocale.sprintf("files")
+		}
+		fmt.Fprintf(w, "[%v]\n", lang)
+		langRemaining := len(langFindings)
+		for i, group := range groupFindings(langFindings) {
+			if limits.perLang > 0 && i == limits.perLang || limits.total > 0 && written == limits.total {
+				fmt.Fprintf(w, "    %v\n", reportLocale.sprintf("…and %v more", langRemaining))
+				break
+			}
+			written++
+			remaining -= len(group.findings)
+			langRemaining -= len(group.findings)
+			message := reportLocale.message(group.Message)
+			if len(group.findings) == 1 {
+				fmt.Fprintf(w, "    %v (%v)\n", message, rules.id(group.Check))
+				if group.Context != "" {
+					fmt.Fprintf(w, "        %v\n", reportLocale.sprintf("context: %v", group.Context))
+				}
+				continue
+			}
+			fmt.Fprintf(w, "    %v (%v, %v)\n", message, rules.id(group.Check), reportLocale.sprintf("%v times", len(group.findings)))
+			if keys := group.keys(); len(keys) > 0 {
+				fmt.Fprintf(w, "        %v\n", reportLocale.sprintf("keys: %v", strings.Join(keys, ", ")))
+			}
+		}
+	}
+	if limits.total > 0 && remaining > 0 && written == limits.total {
+		fmt.Fprintln(w, reportLocale.sprintf("…and %v more findings in total, only %v are shown", remaining, limits.total))
+	}
+}
+
+// reportCompact writes findings one per line, as file:line:col: rule check: message, the format
+// editors read compiler errors in. The findings of a key are located at its member in the
+// translation file of their language in paths, and the other ones at the start of the file,
+// or the translation root directory rootDir. At most limits findings are written.
+func reportCompact(w io.Writer, findings []Finding, rules ruleSeverities, rootDir string, paths map[string]string, limits reportLimits) {
+	findings = slices.Clone(findings)
+	slices.SortStableFunc(findings, compareFindings)
+	files := make(map[string][]byte)
+	members := make(map[string]map[string]member)
+	perLang := make(map[string]int)
+	written := 0
+	for _, finding := range findings {
+		if limits.total > 0 && written == limits.total {
+			fmt.Fprintf(w, "%v: %v\n", rootDir, reportLocale.sprintf("…and %v more findings", len(findings)-written))
+			return
+		}
+		if limits.perLang > 0 && perLang[finding.Lang] == limits.perLang {
+			continue
+		}
+		perLang[finding.Lang]++
+		written++
+		message := strings.ReplaceAll(reportLocale.message(finding.Message), "\n", `\n`)
+		path, ok := paths[finding.Lang]
+		if !ok {
+			fmt.Fprintf(w, "%v: %v %v: %v\n", rootDir, rules.id(finding.Check), finding.Check, message)
+			continue
+		}
+		if _, ok := files[path]; !ok {
+			// The files which can't be read or parsed, like the PO ones, are located at their start.
+			files[path], _ = os.ReadFile(path)
+			members[path], _ = locateMembers(files[path])
+		}
+		line, col := 0, 0
+		if m, ok := members[path][finding.Key]; ok && finding.Key != "" {
+			line, col = lineCol(files[path], m.key.start)
+		}
+		fmt.Fprintf(w, "%v:%v:%v: %v %v: %v\n", path, line+1, col+1, rules.id(finding.Check), finding.Check, message)
+	}
+}
+
+// findingGroup is a set of identical findings, by check and message, reported once.
+type findingGroup struct {
+	Finding
+	findings []Finding
+}
+
+// keys returns the keys of the findings of the group.
+func (g findingGroup) keys() (keys []string) {
+	for _, finding := range g.findings {
+		if finding.Key != "" {
+			keys = append(keys, finding.Key)
+		}
+	}
+	return keys
+}
+
+// groupFindings groups the findings with the same check and message, like the ones
+// of a string reused under many keys, in the order of their first finding.
+func groupFindings(findings []Finding) []findingGroup {
+	var groups []findingGroup
+	index := make(map[[2]string]int)
+	for _, finding := range findings {
+		id := [2]string{finding.Check, finding.Message}
+		i, ok := index[id]
+		if !ok {
+			i = len(groups)
+			index[id] = i
+			groups = append(groups, findingGroup{Finding: finding})
+		}
+		groups[i].findings = append(groups[i].findings, finding)
+	}
+	return groups
+}
+
+// reportSummary writes the closing summary of a run to w: how many languages and english keys
+// were checked in how long, and how many findings each check and each language has.
+func reportSummary(w io.Writer, translations map[string]Translation, findings []Finding, rules ruleSeverities, elapsed time.Duration) {
+	warnings := 0
+	for _, finding := range findings {
+		if rules.isWarning(finding) {
+			warnings++
+		}
+	}
+	fmt.Fprint(w, reportLocale.sprintf("checked %v languages, %v keys in %v: %v findings",
+		len(translations), len(translations["en"]), elapsed.Round(time.Millisecond), len(findings)))
+	if warnings > 0 {
+		fmt.Fprint(w, reportLocale.sprintf(", %v of them warnings", warnings))
+	}
+	fmt.Fprintln(w)
+	if len(findings) == 0 {
+		return
+	}
+	byCheck := make(map[string]int)
+	byLang := make(map[string]int)
+	for _, finding := range findings {
+		byCheck[finding.Check]++
+		lang := finding.Lang
+		if lang == "" {
+			lang = reportLocale.sprintf("files")
+		}
+		byLang[lang]++
+	}
+	fmt.Fprintf(w, "    %v\n", reportLocale.sprintf("by check: %v", formatCounts(byCheck)))
+	fmt.Fprintf(w, "    %v\n", reportLocale.sprintf("by language: %v", formatCounts(byLang)))
+}
+
+// formatCounts formats counts as "name count" pairs, the most frequent first.
+func formatCounts(counts map[string]int) string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%v %v", name, counts[name])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReportSummary(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {"a": "Save", "b": "Open"},
+		"sv": {"a": "Spara", "b": "Öppna"},
+		"de": {"a": "Speichern"},
+	}
+	findings := []Finding{
+		{Lang: "de", Check: "variables", Message: "missing"},
+		{Lang: "de", Check: "html", Message: "unclosed"},
+		{Lang: "sv", Check: "html", Message: "unclosed"},
+		{Check: "files", Message: "permission denied"},
+	}
+	want := `checked 3 languages, 2 keys in 1.235s: 4 findings
+    by check: html 2, files 1, variables 1
+    by language: de 2, files 1, sv 1
+`
+	var buf bytes.Buffer
+	reportSummary(&buf, translations, findings, ruleSeverities{}, 1234567*time.Microsecond)
+	if buf.String() != want {
+		t.Errorf("want\n%v\ngot\n%v", want, buf.String())
+	}
+
+	buf.Reset()
+	reportSummary(&buf, translations, nil, ruleSeverities{}, time.Second)
+	if want := "checked 3 languages, 2 keys in 1s: 0 findings\n"; buf.String() != want {
+		t.Errorf("want %q, got %q", want, buf.String())
+	}
+}
+
+func TestReportTextDuplicates(t *testing.T) {
+	findings := []Finding{
+		{Lang: "sv", Key: "save", Check: "html", Message: "unclosed <b>: <b>Spara"},
+		{Lang: "sv", Key: "open", Check: "variables", Message: "mismatch in variables: $name$ ⇒ $namn$"},
+		{Lang: "sv", Key: "menu.save", Check: "html", Message: "unclosed <b>: <b>Spara"},
+		{Lang: "sv", Key: "toolbar.save", Check: "html", Message: "unclosed <b>: <b>Spara"},
+	}
+	want := `[sv]
+    unclosed <b>: <b>Spara (HTML001, 3 times)
+        keys: menu.save, save, toolbar.save
+    mismatch in variables: $name$ ⇒ $namn$ (VAR001)
+`
+	var buf bytes.Buffer
+	reportText(&buf, findings, ruleSeverities{})
+	if buf.String() != want {
+		t.Errorf("want\n%v\ngot\n%v", want, buf.String())
+	}
+}
+
+func TestReportTextLimited(t *testing.T) {
+	var findings []Finding
+	for _, key := range []string{"a", "b", "c", "d"} {
+		findings = append(findings, Finding{Lang: "sv", Key: key, Check: "html", Message: "unclosed <b>: " + key})
+	}
+
+	tests := []struct {
+		limits reportLimits
+		want   string
+	}{
+		{reportLimits{}, "[sv]\n    unclosed <b>: a (HTML001)\n    unclosed <b>: b (HTML001)\n    unclosed <b>: c (HTML001)\n    unclosed <b>: d (HTML001)\n"},
+		{reportLimits{perLang: 2}, "[sv]\n    unclosed <b>: a (HTML001)\n    unclosed <b>: b (HTML001)\n    …and 2 more\n"},
+		{reportLimits{total: 3}, "[sv]\n    unclosed <b>: a (HTML001)\n    unclosed <b>: b (HTML001)\n    unclosed <b>: c (HTML001)\n    …and 1 more\n" +
+			"…and 1 more findings in total, only 3 are shown\n"},
+		{reportLimits{total: 4}, "[sv]\n    unclosed <b>: a (HTML001)\n    unclosed <b>: b (HTML001)\n    unclosed <b>: c (HTML001)\n    unclosed <b>: d (HTML001)\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		reportTextLimited(&buf, findings, ruleSeverities{}, test.limits)
+		if buf.String() != test.want {
+			t.Errorf("%+v: want\n%v\ngot\n%v", test.limits, test.want, buf.String())
+		}
+	}
+}
+
+func TestReportTextOrder(t *testing.T) {
+	findings := []Finding{
+		{Lang: "sv", Key: "b", Check: "html", Message: "unclosed <b>: b"},
+		{Lang: "de", Key: "a", Check: "variables", Message: "mismatch in variables: a"},
+		{Lang: "sv", Key: "a", Check: "variables", Message: "mismatch in variables: a"},
+		{Check: "files", Message: "permission denied"},
+		{Lang: "sv", Key: "a", Check: "html", Message: "unclosed <b>: a"},
+	}
+	want := `[files]
+    permission denied (FILE001)
+[de]
+    mismatch in variables: a (VAR001)
+[sv]
+    unclosed <b>: a (HTML001)
+    mismatch in variables: a (VAR001)
+    unclosed <b>: b (HTML001)
+`
+	for i := 0; i < 10; i++ {
+		var buf bytes.Buffer
+		reportText(&buf, findings, ruleSeverities{})
+		if buf.String() != want {
+			t.Fatalf("want\n%v\ngot\n%v", want, buf.String())
+		}
+	}
+}
+
+func TestKeyPointer(t *testing.T) {
+	for _, test := range []struct {
+		arrays, key, want string
+	}{
+		{arraysUnsupported, "menu.file.save", "/menu.file.save"},
+		{arraysUnsupported, "a/b~c", "/a~1b~0c"},
+		{arraysUnsupported, "steps[1]", "/steps[1]"},
+		{arraysElements, "steps[1]", "/steps/1"},
+	} {
+		if got := keyPointer(test.key, test.arrays); got != test.want {
+			t.Errorf("%v %q: want %q, got %q", test.arrays, test.key, test.want, got)
+		}
+	}
+}
+
+func TestReportCompact(t *testing.T) {
+	dir := t.TempDir()
+	de := filepath.Join(dir, "de.json")
+	os.WriteFile(de, []byte("{\n    \"a\": \"Hallo\",\n    \"greeting\": \"Hallo $namn$\"\n}\n"), 0644)
+	findings := []Finding{
+		{Lang: "de", Key: "greeting", Check: "variables", Message: "mismatch in variables: Hello $name$ ⇒ Hallo $namn$"},
+		{Lang: "de", Check: "languages", Message: "unexpected language"},
+		{Check: "files", Message: "broken link"},
+		{Lang: "de", Key: "a", Check: "html", Message: "first\nsecond"},
+	}
+	var buf bytes.Buffer
+	reportCompact(&buf, findings, ruleSeverities{}, dir, map[string]string{"de": de}, reportLimits{})
+	want := dir + ": FILE001 files: broken link\n" +
+		de + ":1:1: CAT005 languages: unexpected language\n" +
+		de + ":2:5: HTML001 html: first\\nsecond\n" +
+		de + ":3:5: VAR001 variables: mismatch in variables: Hello $name$ ⇒ Hallo $namn$\n"
+	if buf.String() != want {
+		t.Errorf("want:\n%v\ngot:\n%v", want, buf.String())
+	}
+
+	buf.Reset()
+	reportCompact(&buf, findings, ruleSeverities{}, dir, map[string]string{"de": de}, reportLimits{total: 2})
+	want = dir + ": FILE001 files: broken link\n" +
+		de + ":1:1: CAT005 languages: unexpected language\n" +
+		dir + ": …and 2 more findings\n"
+	if buf.String() != want {
+		t.Errorf("limited: want:\n%v\ngot:\n%v", want, buf.String())
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// highlightStart and highlightEnd are the terminal escape sequences placeholders are highlighted with.
+const (
+	highlightStart = "\x1b[1;33m"
+	highlightEnd   = "\x1b[0m"
+)
+
+// reviewer steps through the findings of a translation folder interactively.
+type reviewer struct {
+	in           *bufio.Scanner
+	out          io.Writer
+	rootDir      string
+	checks       []check
+	baselinePath string
+	files        FilesConfig
+	rules        ruleSeverities
+	// edit opens the file at path on the given 1-based line and returns once it's been edited.
+	edit func(path string, line int) error
+
+	paths        map[string]string
+	translations map[string]Translation
+	baseline     []Finding
+	findings     []Finding
+}
+
+// load (re)loads the translations and collects the findings not in the baseline.
+func (r *reviewer) load() {
+	var walkFindings, loadFindings []Finding
+	r.paths, walkFindings = findTranslationFiles(r.rootDir, r.files)
+	r.translations, loadFindings = loadTranslationFiles(r.paths, r.files.Arrays)
+	loadFindings = append(walkFindings, loadFindings...)
+	r.findings = newFindings(r.baseline, append(loadFindings, runChecks(r.checks, r.translations)...))
+	slices.SortFunc(r.findings, compareFindings)
+}
+
+// run reviews the findings until there are none left or the reviewer quits.
+func (r *reviewer) run() error {
+	r.baseline = loadBaseline(r.baselinePath)
+	r.load()
+	for i := 0; i < len(r.findings); {
+		r.show(i)
+		fmt.Fprint(r.out, "[a]ccept, [e]dit, [n]ext, [p]revious, [q]uit? ")
+		if !r.in.Scan() {
+			fmt.Fprintln(r.out)
+			return r.in.Err()
+		}
+		switch strings.TrimSpace(r.in.Text()) {
+		case "a":
+			r.baseline = append(r.baseline, r.findings[i])
+			if err := writeBaseline(r.baselinePath, r.baseline); err != nil {
+				return err
+			}
+			r.findings = slices.Delete(r.findings, i, i+1)
+		case "e":
+			finding := r.findings[i]
+			if err := r.edit(r.paths[finding.Lang], r.keyLine(finding)); err != nil {
+				fmt.Fprintf(r.out, "edit: %v\n", err)
+			}
+			r.load()
+			i = min(i, len(r.findings))
+		case "n", "":
+			i++
+		case "p":
+			i = max(i-1, 0)
+		case "q":
+			return nil
+		}
+	}
+	fmt.Fprintln(r.out, "no more findings")
+	return nil
+}
+
+// show prints the i-th finding together with the english source and the translation.
+func (r *reviewer) show(i int) {
+	finding := r.findings[i]
+	fmt.Fprintf(r.out, "\n(%v/%v) [%v] %v %v %v\n", i+1, len(r.findings), finding.Lang, r.rules.id(finding.Check), finding.Check, finding.Key)
+	if finding.Key != "" {
+		fmt.Fprintf(r.out, "    en: %v\n", highlightVariables(r.translations["en"][finding.Key]))
+		fmt.Fprintf(r.out, "    %v: %v\n", finding.Lang, highlightVariables(r.translations[finding.Lang][finding.Key]))
+	}
+	fmt.Fprintf(r.out, "    %v\n", finding.Message)
+}
+
+// keyLine returns the 1-based line of the key of finding in its translation file,
+// or the first line if it can't be located.
+func (r *reviewer) keyLine(finding Finding) int {
+	bs, err := os.ReadFile(r.paths[finding.Lang])
+	if err != nil {
+		return 1
+	}
+	members, err := locateMembers(bs)
+	m, ok := members[finding.Key]
+	if err != nil || !ok {
+		return 1
+	}
+	line, _ := lineCol(bs, m.key.start)
+	return line + 1
+}
+
+// highlightVariables wraps the variables of s in terminal highlighting.
+func highlightVariables(s string) string {
+	return variableRx.ReplaceAllString(s, highlightStart+"${0}"+highlightEnd)
+}
+
+// editFile opens path on line in $EDITOR, or vi if it isn't set.
+func editFile(path string, line int) error {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+	cmd := exec.Command(editor[0], append(editor[1:], fmt.Sprintf("+%v", line), path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// review steps through the findings interactively, accepting them into the baseline
+// or opening them in an editor.
+func review(args []string) {
+	flags := flag.NewFlagSet("review", flag.ExitOnError)
+	baselineFlag := flags.String("baseline", "",
+		fmt.Sprintf("file the accepted findings are written to (default %v)", defaultBaselineFile))
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v review [flags] <translation-root-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	rootDir := flags.Arg(0)
+	if err := checkRootDir(rootDir); err != nil {
+		log.Fatal(err)
+	}
+
+	config := loadConfig(*configPath)
+	r := &reviewer{
+		in:           bufio.NewScanner(os.Stdin),
+		out:          os.Stdout,
+		rootDir:      rootDir,
+		checks:       loadChecks(config),
+		baselinePath: baselinePath(*baselineFlag, config),
+		files:        config.Files,
+		rules:        config.Rules,
+		edit:         editFile,
+	}
+	if err := r.run(); err != nil {
+		log.Fatalf("review: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReview(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{"a": "$x$ items", "b": "<b>Bold</b>"}`), 0644)
+	svPath := filepath.Join(dir, "sv.json")
+	os.WriteFile(svPath, []byte("{\n    \"a\": \"$y$ saker\",\n    \"b\": \"<b>Fet\"\n}\n"), 0644)
+	baselinePath := filepath.Join(dir, "baseline.json")
+
+	var out strings.Builder
+	var edited []string
+	r := &reviewer{
+		in:           bufio.NewScanner(strings.NewReader("a\ne\n")),
+		out:          &out,
+		rootDir:      dir,
+		checks:       builtinChecks,
+		baselinePath: baselinePath,
+		edit: func(path string, line int) error {
+			edited = append(edited, path)
+			if line != 3 {
+				t.Errorf("edit: want line 3, got %v", line)
+			}
+			return os.WriteFile(path, []byte(`{"a": "$y$ saker", "b": "<b>Fet</b>"}`), 0644)
+		},
+	}
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(edited) != 1 || edited[0] != svPath {
+		t.Errorf("unexpected edited files: %v", edited)
+	}
+	if !strings.Contains(out.String(), "    en: "+highlightStart+"$x$"+highlightEnd+" items\n") {
+		t.Errorf("missing highlighted source in:\n%v", out.String())
+	}
+	if !strings.HasSuffix(out.String(), "no more findings\n") {
+		t.Errorf("review didn't finish:\n%v", out.String())
+	}
+
+	baseline := loadBaseline(baselinePath)
+	if len(baseline) != 1 || baseline[0].Check != "variables" || baseline[0].Key != "a" {
+		t.Errorf("unexpected baseline: %v", baseline)
+	}
+	// The accepted finding isn't reviewed again.
+	out.Reset()
+	r.in = bufio.NewScanner(strings.NewReader(""))
+	if err := r.run(); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "no more findings\n" {
+		t.Errorf("unexpected output:\n%v", out.String())
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// rtlLanguages are the base languages written right to left, which also cover regional codes like ar-EG.
+var rtlLanguages = []string{"ar", "fa", "he", "ur"}
+
+// isRTL reports whether lang is written right to left.
+func isRTL(lang string) bool {
+	return slices.Contains(rtlLanguages, baseLanguage(lang))
+}
+
+// ltrMarkupRx matches the attributes which hard-code a left to right layout, as in htmlAttributes.
+var ltrMarkupRx = regexp.MustCompile(`(?i)\bdir="ltr"|direction:\s*ltr|text-align:\s*left|float:\s*left|\b(?:float|pull|text)-left\b`)
+
+// ltrArrowRx matches arrows pointing forward in a left to right text only.
+var ltrArrowRx = regexp.MustCompile(`->|=>|→`)
+
+// sidesRx matches the words which are mirrored between left to right and right to left layouts.
+var sidesRx = regexp.MustCompile(`(?i)\b(?:left|right|ltr|rtl)\b|-left\b|-right\b`)
+
+// unmirrored replaces the sides and directions of attrs with placeholders,
+// so that mirrored layouts compare equal.
+func unmirrored(attrs []string) []string {
+	result := make([]string, len(attrs))
+	for i, attr := range attrs {
+		result[i] = sidesRx.ReplaceAllString(attr, "⇔")
+	}
+	slices.Sort(result)
+	return result
+}
+
+// checkRTL reports the right to left translations with markup hard-coding a left to right layout,
+// and with arrows only pointing forward in left to right texts.
+func checkRTL(translations map[string]Translation) (result []Finding) {
+	for lang, translation := range translations {
+		if !isRTL(lang) {
+			continue
+		}
+		for key, translated := range translation {
+			for _, attr := range htmlAttributes(translated) {
+				if ltrMarkupRx.MatchString(attr) {
+					result = append(result, Finding{
+						Lang:    lang,
+						Key:     key,
+						Check:   "rtl",
+						Message: fmt.Sprintf("left to right markup in a right to left text: %v: %v", attr, translated),
+					})
+				}
+			}
+			if arrow := ltrArrowRx.FindString(translated); arrow != "" {
+				result = append(result, Finding{
+					Lang:    lang,
+					Key:     key,
+					Check:   "rtl",
+					Message: fmt.Sprintf("left to right arrow %v in a right to left text: %v", arrow, translated),
+				})
+			}
+		}
+	}
+	return result
+}
+
+// arabicScriptLanguages are the right to left languages with their own punctuation, like ، and ؟.
+var arabicScriptLanguages = []string{"ar", "fa", "ur"}
+
+// latinPunctuationRx matches Latin punctuation following an Arabic letter, instead of ، ؛ or ؟.
+var latinPunctuationRx = regexp.MustCompile(`\p{Arabic}\s*[,;?]`)
+
+// reversedBracketsRx matches brackets typed mirrored around a variable or {placeholder},
+// which the bidirectional algorithm already mirrors when rendering.
+var reversedBracketsRx = regexp.MustCompile(`\)\s*(?:\$[^$]+\$|\{[^{}]+\})\s*\(`)
+
+// strayPunctuationRx matches Latin punctuation at the boundaries of a text, next to left to right
+// content at the end, which is displayed on the wrong side in a right to left context.
+var strayPunctuationRx = regexp.MustCompile(`^[.,:;!?]|(?:[A-Za-z0-9]|\$[^$]+\$|\{[^{}]+\})[.:;!?]+$`)
+
+// checkRTLPunctuation reports the right to left translations with Latin punctuation instead of
+// the Arabic one, with mirrored brackets around variables, or with punctuation at their boundaries
+// which is displayed on the wrong side.
+func checkRTLPunctuation(translations map[string]Translation) (result []Finding) {
+	for lang, translation := range translations {
+		if !isRTL(lang) {
+			continue
+		}
+		for key, translated := range translation {
+			var problem string
+			switch {
+			case slices.Contains(arabicScriptLanguages, baseLanguage(lang)) && latinPunctuationRx.MatchString(translated):
+				problem = "latin punctuation instead of ، ؛ or ؟"
+			case reversedBracketsRx.MatchString(translated):
+				problem = "mirrored brackets around a variable"
+			case strayPunctuationRx.MatchString(translated):
+				problem = "punctuation at the boundary, displayed on the wrong side"
+			default:
+				continue
+			}
+			result = append(result, Finding{
+				Lang:    lang,
+				Key:     key,
+				Check:   "rtl-punctuation",
+				Message: fmt.Sprintf("%v: %v", problem, translated),
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckRTL(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {
+			"a": `<p style="text-align: left">Welcome</p>`,
+			"b": `<span dir="ltr">$email$</span>`,
+			"c": "Settings -> Account",
+		},
+		"ar": {
+			"a": `<p style="text-align: left">مرحبا</p>`,
+			"b": `<span dir="ltr">$email$</span>`,
+			"c": "الإعدادات -> الحساب",
+		},
+		"he": {
+			"a": `<p style="text-align: right">ברוכים הבאים</p>`,
+			"b": `<span dir="rtl">$email$</span>`,
+			"c": "הגדרות ← חשבון",
+		},
+		"fa-IR": {
+			"c": "تنظیمات -> حساب",
+		},
+		"sv": {
+			"c": "Inställningar -> Konto",
+		},
+	}
+	got := map[string]int{}
+	for _, finding := range checkRTL(translations) {
+		got[finding.Lang+":"+finding.Key]++
+	}
+	if want := map[string]int{"ar:a": 1, "ar:b": 1, "ar:c": 1, "fa-IR:c": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	// The mirrored layout isn't reported as changed markup.
+	for _, finding := range checkTranslationHTMLAttributes(translations) {
+		t.Errorf("unexpected finding: %v", finding)
+	}
+}
+
+func TestCheckRTLPunctuation(t *testing.T) {
+	translations := map[string]Translation{
+		"ar": {
+			"comma":    "مرحبا, عالم",
+			"question": "هل أنت متأكد?",
+			"good":     "هل أنت متأكد؟",
+			"brackets": "الملفات )$count$(",
+			"stray":    "البريد الإلكتروني $email$.",
+		},
+		"he": {
+			"comma":    "שלום, עולם",
+			"brackets": "קבצים ($count$)",
+			"stray":    ":שם",
+		},
+		"ar-EG": {
+			"comma": "مرحبا, عالم",
+		},
+	}
+	got := map[string]bool{}
+	for _, finding := range checkRTLPunctuation(translations) {
+		got[finding.Lang+":"+finding.Key] = true
+	}
+	want := map[string]bool{"ar:comma": true, "ar:question": true, "ar:brackets": true, "ar:stray": true, "he:stray": true,
+		"ar-EG:comma": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ruleIDs are the stable identifiers of the rules, the checks, by the name of the check.
+// They are grouped by what they check, and never reused, so that suppressions and documentation
+// can refer to them even if a check is renamed. The custom rules are carried by the severities of their
+// configuration instead, see ruleSeverities.
+var ruleIDs = map[string]string{
+	"variables":           "VAR001",
+	"variable-spacing":    "VAR002",
+	"attribute-variables": "VAR003",
+
+	"html":                    "HTML001",
+	"html-tags":               "HTML002",
+	"html-attributes":         "HTML003",
+	"html-comments":           "HTML004",
+	"html-elements":           "HTML005",
+	"html-alt":                "HTML006",
+	"html-entities":           "HTML007",
+	"html-content-model":      "HTML008",
+	"aria":                    "HTML009",
+	"translatable-attributes": "HTML010",
+
+	"rtl":             "RTL001",
+	"rtl-punctuation": "RTL002",
+	"cjk":             "CJK001",
+
+	"dates":    "FMT001",
+	"currency": "FMT002",
+	"numbers":  "FMT003",
+
+	"identical":      "TXT001",
+	"similarity":     "TXT002",
+	"language":       "TXT003",
+	"profanity":      "TXT004",
+	"secrets":        "TXT005",
+	"markers":        "TXT006",
+	"dummy-text":     "TXT007",
+	"personal-data":  "TXT008",
+	"internal-hosts": "TXT009",
+
+	"context":        "CAT001",
+	"stale":          "CAT002",
+	"fuzzy":          "CAT003",
+	"schema":         "CAT004",
+	"languages":      "CAT005",
+	"language-codes": "CAT006",
+	"release":        "CAT007",
+
+	"files": "FILE001",
+	"load":  "FILE002",
+	"json":  "FILE003",
+	"value": "FILE004",
+
+	"missing-key": "USE001",
+	"unused-key":  "USE002",
+	"merge":       "MRG001",
+	"plugin":      "PLG001",
+}
+
+// ruleID returns the identifier of the built-in rule of check, or the name of the check
+// for the ones without, like the checks of plugins.
+func ruleID(check string) string {
+	return ruleSeverities{}.id(check)
+}
+
+// ruleCheck returns the name of the check of the built-in rule, given by its identifier, in any case, or by its name.
+func ruleCheck(rule string) string {
+	return ruleSeverities{}.check(rule)
+}
+
+// The severities a rule can be configured with. Errors fail the run, warnings are only reported,
+// and the rules which are off aren't checked.
+const (
+	severityOff     = "off"
+	severityWarning = "warning"
+	severityError   = "error"
+)
+
+// ruleIDRx matches the rule identifiers, to tell a mistyped one from the name of a plugin check.
+var ruleIDRx = regexp.MustCompile(`^[A-Za-z]+[0-9]{3}$`)
+
+// warningChecks are the checks whose findings are only warnings by default: they are reported,
+// but don't fail the run. They are the heuristic ones, which texts can trip on purpose, like XXX
+// in "Enter code XXX-XXX", so that upgrading doesn't fail a run which passed; each can be made an error.
+var warningChecks = []string{
+	"variable-spacing",
+	"html-alt", "aria",
+	"rtl", "rtl-punctuation", "cjk",
+	"dates", "currency",
+	"identical", "profanity", "internal-hosts", "markers", "dummy-text", "personal-data",
+	"value",
+}
+
+// ruleSeverities are the configured severities of rules, along with the custom rules of the configuration,
+// whose identifiers and severities are looked up with the built-in ones. The zero value has the default
+// severities of the built-in rules.
+type ruleSeverities struct {
+	// severities maps the names of the checks to their configured severity.
+	severities map[string]string
+	// ids maps the names of the checks of the custom rules to their identifier.
+	ids map[string]string
+	// defaults maps the names of the checks of the custom rules to their severity, if they have one.
+	defaults map[string]string
+}
+
+// newRuleSeverities returns the severities of severities, by check name, without custom rules.
+func newRuleSeverities(severities map[string]string) ruleSeverities {
+	return ruleSeverities{severities: severities}
+}
+
+// UnmarshalJSON reads the severities of rules, by identifier or name, over the ones already read,
+// like the ones of a project over the ones of the file. They are resolved by resolveRules.
+func (rules *ruleSeverities) UnmarshalJSON(bs []byte) error {
+	var severities map[string]string
+	if err := json.Unmarshal(bs, &severities); err != nil {
+		return err
+	}
+	for rule, severity := range severities {
+		rules.set(rule, severity)
+	}
+	return nil
+}
+
+// clone returns a copy of rules which can be changed without changing rules.
+func (rules ruleSeverities) clone() ruleSeverities {
+	return ruleSeverities{maps.Clone(rules.severities), maps.Clone(rules.ids), maps.Clone(rules.defaults)}
+}
+
+// set configures the severity of check.
+func (rules *ruleSeverities) set(check, severity string) {
+	if rules.severities == nil {
+		rules.severities = make(map[string]string)
+	}
+	rules.severities[check] = severity
+}
+
+// configured returns the configured severity of check, if it has one.
+func (rules ruleSeverities) configured(check string) (string, bool) {
+	severity, ok := rules.severities[check]
+	return severity, ok
+}
+
+// of returns the severity of check: the configured one, or the default one.
+func (rules ruleSeverities) of(check string) string {
+	if severity, ok := rules.severities[check]; ok {
+		return severity
+	}
+	if severity, ok := rules.defaults[check]; ok {
+		return severity
+	}
+	if slices.Contains(warningChecks, check) {
+		return severityWarning
+	}
+	return severityError
+}
+
+// id returns the identifier of the rule of check, a built-in or a custom one, or the name of the check
+// for the ones without, like the checks of plugins.
+func (rules ruleSeverities) id(check string) string {
+	if id, ok := ruleIDs[check]; ok {
+		return id
+	}
+	if id, ok := rules.ids[check]; ok {
+		return id
+	}
+	return check
+}
+
+// check returns the name of the check of rule, a built-in or a custom one, given by its identifier,
+// in any case, or by its name.
+func (rules ruleSeverities) check(rule string) string {
+	for _, ids := range []map[string]string{ruleIDs, rules.ids} {
+		for check, id := range ids {
+			if strings.EqualFold(id, rule) {
+				return check
+			}
+		}
+	}
+	return rule
+}
+
+// known reports whether check is the check of a built-in or a custom rule.
+func (rules ruleSeverities) known(check string) bool {
+	_, builtin := ruleIDs[check]
+	_, custom := rules.ids[check]
+	return builtin || custom
+}
+
+// isWarning reports whether finding is only a warning.
+func (rules ruleSeverities) isWarning(finding Finding) bool {
+	return rules.of(finding.Check) == severityWarning
+}
+
+// validate returns an error if rule looks like an identifier but none of the known ones.
+// Names can't be validated, since the checks of plugins are named after their file.
+func (rules ruleSeverities) validate(rule string) error {
+	if ruleIDRx.MatchString(rule) && !rules.known(rules.check(rule)) {
+		return fmt.Errorf("unknown rule %v", rule)
+	}
+	return nil
+}
+
+// resolveRules validates the severities of rules, given by identifier or name, and returns them by check name,
+// along with the custom rules, whose identifiers they may use.
+func resolveRules(rules map[string]string, custom []RuleConfig) (ruleSeverities, error) {
+	resolved := ruleSeverities{}
+	for _, rule := range custom {
+		if check := resolved.check(rule.ID); check != rule.ID {
+			return ruleSeverities{}, fmt.Errorf("custom rule %v is already the identifier of %v", rule.ID, check)
+		}
+		if id, ok := resolved.ids[rule.Name]; ok {
+			return ruleSeverities{}, fmt.Errorf("custom rule %v is named %v, like %v", rule.ID, rule.Name, id)
+		}
+		if resolved.ids == nil {
+			resolved.ids, resolved.defaults = make(map[string]string), make(map[string]string)
+		}
+		resolved.ids[rule.Name] = rule.ID
+		if rule.Severity != "" {
+			resolved.defaults[rule.Name] = rule.Severity
+		}
+	}
+	for _, rule := range sortedKeys(rules) {
+		severity := rules[rule]
+		switch severity {
+		case severityOff, severityWarning, severityError:
+		default:
+			return ruleSeverities{}, fmt.Errorf("rule %v must be %q, %q or %q, not %q", rule, severityOff, severityWarning, severityError, severity)
+		}
+		if err := resolved.validate(rule); err != nil {
+			return ruleSeverities{}, err
+		}
+		resolved.set(resolved.check(rule), severity)
+	}
+	return resolved, nil
+}
+
+// enabledChecks returns the checks which aren't turned off by rules.
+func enabledChecks(checks []check, rules ruleSeverities) []check {
+	return slices.DeleteFunc(checks, func(c check) bool { return rules.severities[c.name] == severityOff })
+}
+
+// enabledFindings returns the findings of the rules which aren't turned off by rules,
+// like the ones found while loading the files.
+func enabledFindings(findings []Finding, rules ruleSeverities) []Finding {
+	return slices.DeleteFunc(findings, func(f Finding) bool { return rules.severities[f.Check] == severityOff })
+}
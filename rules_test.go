@@ -0,0 +1,73 @@
+package main
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestRuleIDs(t *testing.T) {
+	// The identifiers must be unique, and every explained rule must have one.
+	checks := make(map[string]string)
+	for check, id := range ruleIDs {
+		if other, ok := checks[id]; ok {
+			t.Errorf("%v is the identifier of both %v and %v", id, other, check)
+		}
+		checks[id] = check
+	}
+	for rule := range ruleDocs {
+		if _, ok := ruleIDs[rule]; !ok {
+			t.Errorf("%v has no identifier", rule)
+		}
+	}
+
+	for _, test := range []struct{ rule, want string }{
+		{"VAR001", "variables"},
+		{"html007", "html-entities"},
+		{"variables", "variables"},
+		{"my-plugin", "my-plugin"},
+	} {
+		if got := ruleCheck(test.rule); got != test.want {
+			t.Errorf("%v: want %v, got %v", test.rule, test.want, got)
+		}
+	}
+	if got := ruleID("my-plugin"); got != "my-plugin" {
+		t.Errorf("want the name of checks without an identifier, got %v", got)
+	}
+}
+
+func TestResolveRules(t *testing.T) {
+	rules, err := resolveRules(map[string]string{"HTML003": "off", "variable-spacing": "warning", "TXT004": "error"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"html-attributes": "off", "variable-spacing": "warning", "profanity": "error"}
+	if !maps.Equal(rules.severities, want) {
+		t.Errorf("want %v, got %v", want, rules)
+	}
+	for _, rules := range []map[string]string{{"HTML003": "disabled"}, {"HTML999": "off"}} {
+		if _, err := resolveRules(rules, nil); err == nil {
+			t.Errorf("%v: want an error", rules)
+		}
+	}
+
+	for _, test := range []struct {
+		check string
+		want  bool
+	}{
+		{"variables", false},
+		{"variable-spacing", true},
+		{"profanity", false},
+		{"rtl-punctuation", true},
+		{"identical", true},
+		{"markers", true},
+	} {
+		if got := rules.isWarning(Finding{Check: test.check}); got != test.want {
+			t.Errorf("%v: want warning %v, got %v", test.check, test.want, got)
+		}
+	}
+
+	checks := enabledChecks([]check{{"html", checkTranslationHTML}, {"html-attributes", checkTranslationHTMLAttributes}}, rules)
+	if len(checks) != 1 || checks[0].name != "html" {
+		t.Errorf("want only html enabled, got %v", checks)
+	}
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// jsonSchema is the subset of JSON Schema the translation files can be validated against:
+// the types, object properties and string constraints. The other keywords are ignored.
+type jsonSchema struct {
+	Type                 schemaTypes            `json:"type"`
+	Enum                 []any                  `json:"enum"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	PatternProperties    map[string]*jsonSchema `json:"patternProperties"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties"`
+	PropertyNames        *jsonSchema            `json:"propertyNames"`
+	Required             []string               `json:"required"`
+	Items                *jsonSchema            `json:"items"`
+	Pattern              string                 `json:"pattern"`
+	MinLength            *int                   `json:"minLength"`
+	MaxLength            *int                   `json:"maxLength"`
+
+	// never is set by the false schema, which nothing is valid against.
+	never bool
+	// patternRx and patternPropertiesRx are the compiled Pattern and PatternProperties.
+	patternRx           *regexp.Regexp
+	patternPropertiesRx map[string]*regexp.Regexp
+}
+
+// UnmarshalJSON reads a schema object, or one of the true and false schemas.
+func (s *jsonSchema) UnmarshalJSON(bs []byte) error {
+	switch string(bytes.TrimSpace(bs)) {
+	case "true":
+		*s = jsonSchema{}
+		return nil
+	case "false":
+		*s = jsonSchema{never: true}
+		return nil
+	}
+	type schema jsonSchema
+	return json.Unmarshal(bs, (*schema)(s))
+}
+
+// schemaTypes are the types a value may have, given as a single type or a list of them.
+type schemaTypes []string
+
+// UnmarshalJSON reads a single type or a list of types.
+func (t *schemaTypes) UnmarshalJSON(bs []byte) error {
+	var single string
+	if err := json.Unmarshal(bs, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	return json.Unmarshal(bs, (*[]string)(t))
+}
+
+// loadSchema loads and compiles the JSON Schema at path.
+func loadSchema(path string) (*jsonSchema, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var schema jsonSchema
+	if err := json.Unmarshal(bs, &schema); err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	if err := schema.compile(); err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return &schema, nil
+}
+
+// compile compiles the regular expressions of s and its subschemas.
+func (s *jsonSchema) compile() error {
+	if s == nil {
+		return nil
+	}
+	if s.Pattern != "" {
+		rx, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return err
+		}
+		s.patternRx = rx
+	}
+	s.patternPropertiesRx = make(map[string]*regexp.Regexp, len(s.PatternProperties))
+	for pattern, sub := range s.PatternProperties {
+		rx, err := regexp.Compile(pattern)
+		if err != nil {
+			return err
+		}
+		s.patternPropertiesRx[pattern] = rx
+		if err := sub.compile(); err != nil {
+			return err
+		}
+	}
+	for _, sub := range s.Properties {
+		if err := sub.compile(); err != nil {
+			return err
+		}
+	}
+	for _, sub := range []*jsonSchema{s.AdditionalProperties, s.PropertyNames, s.Items} {
+		if err := sub.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schemaType returns the JSON Schema type of a value decoded with json.Decoder.UseNumber.
+func schemaType(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := value.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// schemaViolation is a value violating a schema.
+type schemaViolation struct {
+	// pointer is the JSON Pointer of the value in the document,
+	// and key the top-level property it's in, the translation key.
+	pointer string
+	key     string
+	message string
+}
+
+func (v schemaViolation) String() string {
+	pointer := v.pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	return pointer + ": " + v.message
+}
+
+// validate returns the violations of s by value, found at the JSON Pointer pointer of the document.
+func (s *jsonSchema) validate(value any, pointer string) (violations []schemaViolation) {
+	if s == nil {
+		return nil
+	}
+	fail := func(format string, args ...any) {
+		violations = append(violations, schemaViolation{pointer: pointer, message: fmt.Sprintf(format, args...)})
+	}
+	if s.never {
+		fail("not allowed")
+		return violations
+	}
+	typ := schemaType(value)
+	if len(s.Type) > 0 && !slices.Contains(s.Type, typ) && !(typ == "integer" && slices.Contains(s.Type, "number")) {
+		fail("must be of type %v, not %v", strings.Join(s.Type, " or "), typ)
+		return violations
+	}
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(allowed any) bool { return fmt.Sprint(allowed) == fmt.Sprint(value) }) {
+		fail("must be one of %v", s.Enum)
+	}
+
+	switch value := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(value)
+		if s.MinLength != nil && length < *s.MinLength {
+			fail("must be at least %v characters long", *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			fail("must be at most %v characters long", *s.MaxLength)
+		}
+		if s.patternRx != nil && !s.patternRx.MatchString(value) {
+			fail("must match %v", s.Pattern)
+		}
+	case []any:
+		for i, item := range value {
+			violations = append(violations, s.Items.validate(item, fmt.Sprintf("%v/%v", pointer, i))...)
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := value[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			first := len(violations)
+			itemPointer := pointer + "/" + pointerToken(name)
+			if s.PropertyNames != nil {
+				for _, violation := range s.PropertyNames.validate(name, itemPointer) {
+					violation.message = "property name " + violation.message
+					violations = append(violations, violation)
+				}
+			}
+			matched := false
+			if sub, ok := s.Properties[name]; ok {
+				matched = true
+				violations = append(violations, sub.validate(value[name], itemPointer)...)
+			}
+			for pattern, rx := range s.patternPropertiesRx {
+				if rx.MatchString(name) {
+					matched = true
+					violations = append(violations, s.PatternProperties[pattern].validate(value[name], itemPointer)...)
+				}
+			}
+			if !matched && s.AdditionalProperties != nil {
+				violations = append(violations, s.AdditionalProperties.validate(value[name], itemPointer)...)
+			}
+			if pointer == "" {
+				for i := first; i < len(violations); i++ {
+					violations[i].key = name
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// pointerToken escapes a property name to be a JSON Pointer reference token.
+func pointerToken(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
+
+// checkSchema returns a check reporting the violations of schema by the JSON translation files at paths,
+// for the languages being checked.
+func checkSchema(schema *jsonSchema, paths map[string]string) checkFunc {
+	return func(translations map[string]Translation) (result []Finding) {
+		for lang, path := range paths {
+			if _, ok := translations[lang]; !ok || isPOPath(path) {
+				continue
+			}
+			bs, err := os.ReadFile(path)
+			if err != nil {
+				// The files which can't be read are reported while loading them.
+				continue
+			}
+			dec := json.NewDecoder(bytes.NewReader(bs))
+			dec.UseNumber()
+			var value any
+			if err := dec.Decode(&value); err != nil {
+				continue
+			}
+			for _, violation := range schema.validate(value, "") {
+				result = append(result, Finding{Lang: lang, Key: violation.key, Check: "schema", Message: fmt.Sprintf("%v: %v", path, violation)})
+			}
+		}
+		return result
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheckSchema(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "schema.json"), []byte(`{
+		"type": "object",
+		"required": ["_meta"],
+		"properties": {
+			"_meta": {
+				"type": "object",
+				"required": ["translator"],
+				"properties": {"translator": {"type": "string"}},
+				"additionalProperties": false
+			}
+		},
+		"propertyNames": {"pattern": "^[a-z_]+(\\.[a-z_]+)*$"},
+		"additionalProperties": {"type": "string", "maxLength": 10}
+	}`), 0644)
+	schema, err := loadSchema(filepath.Join(dir, "schema.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	en := filepath.Join(dir, "en.json")
+	sv := filepath.Join(dir, "sv.json")
+	os.WriteFile(en, []byte(`{"_meta": {"translator": "Anna"}, "menu.save": "Save", "menu.open": "Open"}`), 0644)
+	os.WriteFile(sv, []byte(`{"_meta": {"team": "Nordic"}, "menu.save": "Spara allt nu genast", "Menu/Open": 1}`), 0644)
+	paths := map[string]string{"en": en, "sv": sv}
+	translations := map[string]Translation{"en": {}, "sv": {}}
+
+	want := []Finding{
+		{Lang: "sv", Key: "Menu/Open", Check: "schema", Message: sv + ": /Menu~1Open: property name must match ^[a-z_]+(\\.[a-z_]+)*$"},
+		{Lang: "sv", Key: "Menu/Open", Check: "schema", Message: sv + ": /Menu~1Open: must be of type string, not integer"},
+		{Lang: "sv", Key: "_meta", Check: "schema", Message: sv + `: /_meta: missing required property "translator"`},
+		{Lang: "sv", Key: "_meta", Check: "schema", Message: sv + ": /_meta/team: not allowed"},
+		{Lang: "sv", Key: "menu.save", Check: "schema", Message: sv + ": /menu.save: must be at most 10 characters long"},
+	}
+	if findings := checkSchema(schema, paths)(translations); !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+
+	delete(translations, "sv")
+	if findings := checkSchema(schema, paths)(translations); len(findings) != 0 {
+		t.Errorf("unchecked language: unexpected findings: %v", findings)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// secretPatterns are the values pasted in while debugging which must not be in a catalog, by the check
+// reporting them. The personal data and the internal hosts are checks of their own, only warnings by default,
+// since support addresses, phone numbers and example hosts are in some texts on purpose. The matches of
+// the masked ones are only partially shown in the reports, which CI logs keep.
+var secretPatterns = []struct {
+	check  string
+	kind   string
+	rx     *regexp.Regexp
+	masked bool
+}{
+	{"secrets", "AWS access key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), true},
+	{"secrets", "GitHub token", regexp.MustCompile(`\bgh[opsu]_[0-9A-Za-z]{36}\b|\bgithub_pat_[0-9A-Za-z_]{22,}`), true},
+	{"secrets", "Slack token", regexp.MustCompile(`\bxox[abprs]-[0-9A-Za-z-]{10,}`), true},
+	{"secrets", "Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`), true},
+	{"secrets", "Stripe key", regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{16,}`), true},
+	{"secrets", "JSON Web Token", regexp.MustCompile(`\beyJ[0-9A-Za-z_-]{8,}\.eyJ[0-9A-Za-z_-]{8,}\.[0-9A-Za-z_-]+`), true},
+	{"secrets", "private key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`), false},
+	{"personal-data", "email address", regexp.MustCompile(`\b[0-9A-Za-z._%+-]+@[0-9A-Za-z.-]+\.[A-Za-z]{2,}\b`), true},
+	// Only the international phone numbers are told apart from the other numbers.
+	{"personal-data", "phone number", regexp.MustCompile(`\+\d{1,3}[ .-]?(?:\(\d{1,4}\)[ .-]?)?\d{2,4}(?:[ .-]?\d{2,4}){2,4}\b`), true},
+	{"internal-hosts", "internal host", regexp.MustCompile(`\b(?:localhost|[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*\.(?:internal|local|lan|corp|intranet))(?::\d+)?\b`), false},
+	{"internal-hosts", "private IP address", regexp.MustCompile(`\b(?:10\.\d{1,3}|192\.168|172\.(?:1[6-9]|2\d|3[01]))\.\d{1,3}\.\d{1,3}\b`), false},
+}
+
+// maskSecret returns the first characters of a secret, enough to find it without leaking it.
+func maskSecret(secret string) string {
+	runes := []rune(secret)
+	if len(runes) <= 8 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:4]) + "…"
+}
+
+// checkSecrets reports the texts with what looks like credentials.
+func checkSecrets(translations map[string]Translation) []Finding {
+	return findSecrets(translations, "secrets")
+}
+
+// checkPersonalData reports the texts with what looks like email addresses or phone numbers.
+func checkPersonalData(translations map[string]Translation) []Finding {
+	return findSecrets(translations, "personal-data")
+}
+
+// checkInternalHosts reports the texts with what looks like internal hosts or private IP addresses.
+func checkInternalHosts(translations map[string]Translation) []Finding {
+	return findSecrets(translations, "internal-hosts")
+}
+
+// findSecrets reports the texts matching the secretPatterns of check.
+// The ones of the translations copied from the english text are reported in english only.
+func findSecrets(translations map[string]Translation, check string) (result []Finding) {
+	for lang, translation := range translations {
+		for key, translated := range translation {
+			for _, pattern := range secretPatterns {
+				if pattern.check != check {
+					continue
+				}
+				for _, match := range pattern.rx.FindAllString(translated, -1) {
+					if lang != "en" && strings.Contains(translations["en"][key], match) {
+						continue
+					}
+					if pattern.masked {
+						match = maskSecret(match)
+					}
+					result = append(result, Finding{
+						Lang:    lang,
+						Key:     key,
+						Check:   check,
+						Message: fmt.Sprintf("%v in the text: %v", pattern.kind, match),
+					})
+				}
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// maxUploadSize limits the size of the request bodies accepted by the server.
+const maxUploadSize = 256 << 20
+
+// readHeaderTimeout limits the time the clients have to send the headers of a request,
+// so that the slow ones can't hold the connections open.
+const readHeaderTimeout = 10 * time.Second
+
+// server validates catalogs and single strings over HTTP.
+type server struct {
+	// reference is the english translation single strings are checked against.
+	reference Translation
+	checks    []check
+	// files tells the translation files of the uploaded archives.
+	files FilesConfig
+	// rules are the configured severities of the rules of the checks.
+	rules ruleSeverities
+	// metrics describes the loaded catalog, in the Prometheus text format.
+	metrics []byte
+}
+
+// stringRequest is the body of a single string validation request.
+type stringRequest struct {
+	Lang  string `json:"lang"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// findingsResponse is the body of all the successful validation responses.
+type findingsResponse struct {
+	Findings []jsonFinding `json:"findings"`
+}
+
+// serve runs the HTTP server until it fails.
+func serve(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	listen := flags.String("listen", ":8080", "address to listen on")
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v serve [flags] <translation-root-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	rootDir := flags.Arg(0)
+	if err := checkRootDir(rootDir); err != nil {
+		log.Fatal(err)
+	}
+
+	config := loadConfig(*configPath)
+	checks := loadChecks(config)
+	translations, loadFindings := loadTranslations(rootDir, config.Files)
+	for _, finding := range loadFindings {
+		log.Printf("serve: %v", finding.Message)
+	}
+	s := &server{
+		reference: translations["en"],
+		checks:    checks,
+		files:     config.Files,
+		rules:     config.Rules,
+		metrics:   catalogMetrics(checks, translations, config.Rules, config.Fallbacks),
+	}
+
+	log.Printf("listening on %v", *listen)
+	// h2c serves HTTP/2 without TLS, which gRPC clients need.
+	server := &http.Server{
+		Addr:              *listen,
+		Handler:           h2c.NewHandler(s.handler(), &http2.Server{}),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	log.Fatal(server.ListenAndServe())
+}
+
+// handler returns the routes of the server.
+// gRPC requests are recognized by their content type and served on the same address.
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/check/catalog", s.handleCatalog)
+	mux.HandleFunc("/check/string", s.handleString)
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	grpcServer := newGRPCServer(s)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// handleCatalog checks a whole catalog, uploaded either as a zip archive of translation files,
+// or as a JSON object of language -> translation. The files of the archive which can't be loaded,
+// and the values which aren't strings, are reported among the findings.
+func (s *server) handleCatalog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxUploadSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var translations map[string]Translation
+	var loadFindings []Finding
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/zip") {
+		translations, loadFindings, err = parseZipTranslations(body, s.files)
+	} else {
+		translations, loadFindings, err = parseJSONCatalog(body, s.files.Arrays)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	findings := append(enabledFindings(loadFindings, s.rules), runChecks(s.checks, translations)...)
+	writeFindings(w, findings, s.rules, s.files.Arrays)
+}
+
+// handleString checks a single translated string against the reference.
+func (s *server) handleString(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req stringRequest
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxUploadSize)).Decode(&req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	findings, err := s.checkStringRequest(req)
+	if errors.Is(err, errUnknownKey) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeFindings(w, findings, s.rules, s.files.Arrays)
+}
+
+var (
+	errMissingLangKey = errors.New("lang and key are required")
+	errUnknownKey     = errors.New("unknown key")
+)
+
+// checkStringRequest validates req and checks its string against the reference.
+func (s *server) checkStringRequest(req stringRequest) ([]Finding, error) {
+	if req.Lang == "" || req.Key == "" {
+		return nil, errMissingLangKey
+	}
+	enString, ok := s.reference[req.Key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", errUnknownKey, req.Key)
+	}
+	return checkString(s.checks, req.Lang, req.Key, req.Value, enString), nil
+}
+
+// handleMetrics exposes the metrics of the loaded catalog to Prometheus.
+func (s *server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(s.metrics)
+}
+
+// checkString runs the checks on a single translated string and its english original,
+// returning only the findings about the translated string.
+func checkString(checks []check, lang, key, value, enString string) (findings []Finding) {
+	translations := map[string]Translation{
+		"en": {key: enString},
+		lang: {key: value},
+	}
+	for _, finding := range runChecks(checks, translations) {
+		if finding.Lang == lang {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// parseZipTranslations loads the translation files of files found in a zip archive, like loadArchiveData,
+// returning the findings of the files which can't be loaded. An error is returned if the archive can't be read.
+func parseZipTranslations(body []byte, files FilesConfig) (map[string]Translation, []Finding, error) {
+	match, wants, err := translationFileFilter(files)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bytes.HasPrefix(body, []byte("PK\x03\x04")) {
+		return nil, nil, zip.ErrFormat
+	}
+	contents, err := readArchive("archive", body, wants)
+	if err != nil {
+		return nil, nil, err
+	}
+	translations, findings := loadFileContents("archive", contents, match, files.Arrays)
+	return translations, findings, nil
+}
+
+// parseJSONCatalog reads a JSON object of language -> translation, each read like a translation file,
+// returning the findings of the translations which aren't objects and of the values left out.
+// An error is returned if the body isn't a JSON object.
+func parseJSONCatalog(body []byte, arrays string) (map[string]Translation, []Finding, error) {
+	var catalog map[string]json.RawMessage
+	if err := json.Unmarshal(body, &catalog); err != nil {
+		return nil, nil, err
+	}
+	translations := make(map[string]Translation, len(catalog))
+	var findings []Finding
+	for _, lang := range sortedKeys(catalog) {
+		translation, raw, err := decodeTranslation(catalog[lang], arrays)
+		if err != nil {
+			findings = append(findings, Finding{Lang: lang, Check: "load", Message: fmt.Sprintf("%v: %v", lang, err)})
+			continue
+		}
+		translations[lang] = translation
+		findings = append(findings, skippedFindings(lang, raw)...)
+	}
+	return translations, findings, nil
+}
+
+// writeFindings writes findings, of the severities of rules and for the arrays read as arrays says,
+// as the JSON response.
+func writeFindings(w http.ResponseWriter, findings []Finding, rules ruleSeverities, arrays string) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(findingsResponse{Findings: jsonFindings(findings, rules, arrays)})
+}
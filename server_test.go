@@ -0,0 +1,112 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func postFindings(t *testing.T, handler http.Handler, path, contentType string, body []byte) []jsonFinding {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%v: status %v: %v", path, rec.Code, rec.Body.String())
+	}
+	var resp findingsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("%v: %v", path, err)
+	}
+	return resp.Findings
+}
+
+func TestServerString(t *testing.T) {
+	s := &server{
+		reference: Translation{"greeting": "Hello $name$"},
+		checks:    builtinChecks,
+	}
+	var tests = []struct {
+		value string
+		want  []string
+	}{
+		{"Hej $name$", []string{}},
+		{"Hej $namn$", []string{"variables"}},
+		{"<b>Hej $name$", []string{"html", "html-tags"}},
+	}
+	for _, test := range tests {
+		body, _ := json.Marshal(stringRequest{Lang: "sv", Key: "greeting", Value: test.value})
+		findings := postFindings(t, s.handler(), "/check/string", "application/json", body)
+		got := []string{}
+		for _, finding := range findings {
+			got = append(got, finding.Check)
+		}
+		if strings.Join(got, ",") != strings.Join(test.want, ",") {
+			t.Errorf("%q: want: %q, got: %q", test.value, test.want, got)
+		}
+	}
+
+	body, _ := json.Marshal(stringRequest{Lang: "sv", Key: "missing", Value: "x"})
+	rec := httptest.NewRecorder()
+	s.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/check/string", bytes.NewReader(body)))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown key: want status %v, got %v", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestServerCatalog(t *testing.T) {
+	s := &server{checks: builtinChecks}
+
+	body := []byte(`{"en": {"a": "$x$ items"}, "sv": {"a": "$y$ saker"}}`)
+	findings := postFindings(t, s.handler(), "/check/catalog", "application/json", body)
+	if len(findings) != 1 || findings[0].Lang != "sv" || findings[0].Key != "a" || findings[0].Pointer != "/a" {
+		t.Errorf("json: unexpected findings: %v", findings)
+	}
+
+	// The values which aren't strings are reported, like in the translation files.
+	body = []byte(`{"en": {"a": "items", "n": null}, "sv": {"a": "saker", "n": 1}, "de": []}`)
+	findings = postFindings(t, s.handler(), "/check/catalog", "application/json", body)
+	if len(findings) != 3 || findings[0].Check != "load" || findings[0].Lang != "de" ||
+		findings[1].Check != "value" || findings[1].Lang != "en" || findings[2].Check != "value" || findings[2].Lang != "sv" {
+		t.Errorf("values: unexpected findings: %v", findings)
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"locales/en.json": `{"a": "<b>bold</b>"}`,
+		"locales/sv.json": `{"a": "<b>fet"}`,
+		"README.md":       "not a translation",
+	} {
+		w, _ := archive.Create(name)
+		w.Write([]byte(content))
+	}
+	archive.Close()
+	findings = postFindings(t, s.handler(), "/check/catalog", "application/zip", buf.Bytes())
+	if len(findings) != 1 || findings[0].Lang != "sv" || findings[0].Check != "html" {
+		t.Errorf("zip: unexpected findings: %v", findings)
+	}
+
+	// The files are the ones of the configured pattern.
+	s.files = FilesConfig{Pattern: "translation_*.json"}
+	buf.Reset()
+	archive = zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"translation_en.json": `{"a": "<b>bold</b>"}`,
+		"translation_sv.json": `{"a": "<b>fet</b>", "n": 1}`,
+		"sv.json":             `{"a": "<b>fet"}`,
+	} {
+		w, _ := archive.Create(name)
+		w.Write([]byte(content))
+	}
+	archive.Close()
+	findings = postFindings(t, s.handler(), "/check/catalog", "application/zip", buf.Bytes())
+	if len(findings) != 1 || findings[0].Lang != "sv" || findings[0].Check != "value" || findings[0].Severity != severityWarning {
+		t.Errorf("pattern: unexpected findings: %v", findings)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// editDistance returns the Levenshtein distance between a and b, in characters.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := range ra {
+		current[0] = i + 1
+		for j := range rb {
+			cost := 1
+			if ra[i] == rb[j] {
+				cost = 0
+			}
+			current[j+1] = min(previous[j+1]+1, current[j]+1, previous[j]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// similarity returns how similar a and b are, from 0 for completely different to 1 for equal,
+// as the share of the characters of the longest one which don't need to be edited.
+func similarity(a, b string) float64 {
+	length := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	if length == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(a, b))/float64(length)
+}
+
+// checkSimilarity returns a check reporting the translations which are only trivially different
+// from the reference english text: at least threshold similar to it, but not equal.
+// Those are usually an accidental edit of the english text rather than a translation.
+func checkSimilarity(threshold float64) checkFunc {
+	return func(translations map[string]Translation) (result []Finding) {
+		for enKey, enString := range translations["en"] {
+			for lang, translation := range translations {
+				translated := translation[enKey]
+				if lang == "en" || translated == "" || translated == enString {
+					continue
+				}
+				if s := similarity(enString, translated); s >= threshold {
+					result = append(result, Finding{
+						Lang:    lang,
+						Key:     enKey,
+						Check:   "similarity",
+						Message: fmt.Sprintf("%.0f%% similar to english: %v ⇒ %v", 100*s, enString, translated),
+					})
+				}
+			}
+		}
+		return result
+	}
+}
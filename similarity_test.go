@@ -0,0 +1,39 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"kitten", "sitting", 3},
+		{"Spara", "", 5},
+		{"Größe", "Grösse", 2},
+	} {
+		if got := editDistance(test.a, test.b); got != test.want {
+			t.Errorf("%q %q: want %v, got %v", test.a, test.b, test.want, got)
+		}
+	}
+}
+
+func TestCheckSimilarity(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {"edit": "Your document has been signed", "short": "Status", "same": "Scrive"},
+		"sv": {"edit": "Your document has been signed.", "short": "Statut", "same": "Scrive"},
+		"de": {"edit": "Ihr Dokument wurde unterzeichnet"},
+	}
+	want := []Finding{
+		{Lang: "sv", Key: "edit", Check: "similarity", Message: "97% similar to english: Your document has been signed ⇒ Your document has been signed."},
+	}
+	findings := checkSimilarity(0.9)(translations)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+}
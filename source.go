@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// isURLPath reports whether p is an HTTP(S) URL, read in place of a translation root directory.
+func isURLPath(p string) bool {
+	return strings.HasPrefix(p, "https://") || strings.HasPrefix(p, "http://")
+}
+
+// isRemoteRoot reports whether the translation root root is a URL or a bucket, which have no context and lock files.
+func isRemoteRoot(root string) bool {
+	return isURLPath(root) || isBucketPath(root)
+}
+
+// isRootFile reports whether the translation root root is a workbook, an archive, a URL or a bucket
+// rather than a directory.
+func isRootFile(root string) bool {
+	return isWorkbookPath(root) || isArchivePath(root) || isRemoteRoot(root)
+}
+
+// checkRoot returns an error unless root can be read as a translation root:
+// a readable directory, an existing workbook or archive, or a valid URL or bucket.
+func checkRoot(root string) error {
+	switch {
+	case isURLPath(root):
+		_, err := url.Parse(root)
+		return err
+	case isBucketPath(root):
+		_, _, err := parseBucketPath(root)
+		return err
+	case isWorkbookPath(root), isArchivePath(root):
+		_, err := os.Stat(root)
+		return err
+	}
+	return checkRootDir(root)
+}
+
+// loadRoot loads the translations of the translation root root, like loadTranslations does for a directory.
+func loadRoot(root string, files FilesConfig) (map[string]Translation, []Finding) {
+	switch {
+	case isURLPath(root):
+		return loadURL(root, files)
+	case isBucketPath(root):
+		return loadBucket(root, files)
+	case isArchivePath(root):
+		return loadArchive(root, files)
+	case isWorkbookPath(root):
+		translations, findings := loadWorkbook(root)
+		for lang := range translations {
+			if !files.wantsLanguage(lang) {
+				delete(translations, lang)
+			}
+		}
+		return translations, findings
+	}
+	return loadTranslations(root, files)
+}
+
+// fetchURL returns the body of the response to a GET of rawURL.
+func fetchURL(rawURL string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return fetch(httpClient, req)
+}
+
+// fetch sends req with client and returns the body of the response, which may be maxArchiveContents big,
+// like the files of an archive.
+func fetch(client *http.Client, req *http.Request) ([]byte, error) {
+	return fetchLimited(client, req, maxArchiveContents)
+}
+
+// fetchLimited is fetch with a body of at most limit bytes, failing if the response is bigger.
+func fetchLimited(client *http.Client, req *http.Request, limit int64) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("%v %v: %v: %s", req.Method, req.URL, resp.Status, bytes.TrimSpace(msg))
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%v %v: the response is bigger than %v bytes", req.Method, req.URL, limit)
+	}
+	return body, nil
+}
+
+// loadURL loads the translations published at rawURL: either an archive of translation files, read
+// like loadArchive, or a JSON manifest mapping the languages to the URLs of their translation
+// files, relative to the one of the manifest, like {"en": "en.json", "sv": "https://cdn/sv.json"}.
+// The files which can't be fetched or loaded are left out and reported as findings instead.
+func loadURL(rawURL string, files FilesConfig) (map[string]Translation, []Finding) {
+	bs, err := fetchURL(rawURL)
+	if err != nil {
+		return nil, []Finding{{Check: "files", Message: err.Error()}}
+	}
+	if isArchiveData(bs) {
+		return loadArchiveData(rawURL, bs, files)
+	}
+	var manifest map[string]string
+	if err := json.Unmarshal(bs, &manifest); err != nil {
+		return nil, []Finding{{Check: "files", Message: fmt.Sprintf("%v: neither an archive nor a manifest of translation files: %v", rawURL, err)}}
+	}
+	base, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, []Finding{{Check: "files", Message: err.Error()}}
+	}
+
+	translations := make(map[string]Translation)
+	var findings []Finding
+	loadFailed := func(lang string, err error) {
+		findings = append(findings, Finding{Lang: lang, Check: "load", Message: err.Error()})
+	}
+	for _, lang := range sortedKeys(manifest) {
+		if !files.wantsLanguage(lang) {
+			continue
+		}
+		fileURL, err := base.Parse(manifest[lang])
+		if err != nil {
+			loadFailed(lang, fmt.Errorf("%v: %w", rawURL, err))
+			continue
+		}
+		bs, err := fetchURL(fileURL.String())
+		if err != nil {
+			loadFailed(lang, err)
+			continue
+		}
+		translation, source, raw, err := parseTranslationFile(fileURL.Path, bs, files.Arrays)
+		if err != nil {
+			loadFailed(lang, fmt.Errorf("%v: %w", fileURL, err))
+			continue
+		}
+		translations[lang] = translation
+		findings = append(findings, skippedFindings(lang, raw)...)
+		if _, ok := manifest["en"]; !ok && source != nil {
+			addPOSource(translations, source)
+		}
+	}
+	return translations, findings
+}
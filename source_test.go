@@ -0,0 +1,61 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadURL(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "catalogs.zip")
+	writeArchive(t, archive, map[string]string{"en.json": `{"save": "Save"}`, "sv.json": `{"save": "Spara"}`})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cdn/manifest.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"en": "en.json", "sv": "/other/sv.json", "de": "de.json", "fi": "missing.json"}`))
+	})
+	mux.HandleFunc("/cdn/en.json", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(`{"save": "Save"}`)) })
+	mux.HandleFunc("/other/sv.json", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(`{"save": "Spara"}`)) })
+	mux.HandleFunc("/cdn/de.json", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(`{"save": `)) })
+	mux.HandleFunc("/catalogs.zip", func(w http.ResponseWriter, r *http.Request) { http.ServeFile(w, r, archive) })
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	want := map[string]Translation{"en": {"save": "Save"}, "sv": {"save": "Spara"}}
+	translations, findings := loadURL(server.URL+"/cdn/manifest.json", FilesConfig{})
+	if !reflect.DeepEqual(translations, want) {
+		t.Errorf("manifest: want %v, got %v", want, translations)
+	}
+	if len(findings) != 2 || findings[0].Lang != "de" || findings[1].Lang != "fi" {
+		t.Errorf("manifest: unexpected findings: %v", findings)
+	}
+
+	translations, findings = loadURL(server.URL+"/catalogs.zip", FilesConfig{})
+	if !reflect.DeepEqual(translations, want) || len(findings) > 0 {
+		t.Errorf("archive: want %v, got %v, %v", want, translations, findings)
+	}
+
+	if _, findings := loadURL(server.URL+"/missing", FilesConfig{}); len(findings) != 1 || findings[0].Check != "files" {
+		t.Errorf("missing: unexpected findings: %v", findings)
+	}
+	if err := checkRoot(filepath.Join(os.TempDir(), "missing.zip")); err == nil {
+		t.Error("want an error for a missing archive")
+	}
+}
+
+func TestFetchLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("12345")) }))
+	defer server.Close()
+	for _, test := range []struct {
+		limit int64
+		ok    bool
+	}{{5, true}, {4, false}} {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		body, err := fetchLimited(httpClient, req, test.limit)
+		if test.ok && (string(body) != "12345" || err != nil) || !test.ok && err == nil {
+			t.Errorf("%v: unexpected body %q, %v", test.limit, body, err)
+		}
+	}
+}
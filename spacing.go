@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// paddedVariableRx matches variables with spaces inside their delimiters, like $ name$.
+var paddedVariableRx = regexp.MustCompile(`\$(\s+[^$\s]+\s*|[^$\s]+\s+)\$`)
+
+// variableSurroundings returns whether the single occurrence of variable in s is preceded and followed
+// by a letter or digit, glued to the surrounding word. ok is false unless variable occurs exactly once.
+func variableSurroundings(s, variable string) (gluedBefore, gluedAfter, ok bool) {
+	if strings.Count(s, variable) != 1 {
+		return false, false, false
+	}
+	i := strings.Index(s, variable)
+	before, _ := utf8.DecodeLastRuneInString(s[:i])
+	after, _ := utf8.DecodeRuneInString(s[i+len(variable):])
+	isWord := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
+	return isWord(before), isWord(after), true
+}
+
+// checkVariableSpacing reports the translations where a variable lost the space separating it
+// from the surrounding words in the english source, as in Hello $name$! translated to Hej$name$!,
+// and the variables with spaces inside their delimiters. The languages written without spaces
+// between words, like Chinese and Japanese, are only checked for the latter.
+func checkVariableSpacing(translations map[string]Translation) (result []Finding) {
+	for enKey, enString := range translations["en"] {
+		enVariables := slices.Compact(sortedVariables(enString))
+		for lang, translation := range translations {
+			translated := translation[enKey]
+			if lang == "en" || translated == "" {
+				continue
+			}
+			var problems []string
+			for _, padded := range paddedVariableRx.FindAllString(translated, -1) {
+				problems = append(problems, fmt.Sprintf("spaces inside %v", padded))
+			}
+			if !slices.Contains(fullWidthLanguages, lang) {
+				for _, variable := range enVariables {
+					enBefore, enAfter, enOK := variableSurroundings(enString, variable)
+					before, after, ok := variableSurroundings(translated, variable)
+					if !enOK || !ok {
+						continue
+					}
+					if before && !enBefore {
+						problems = append(problems, fmt.Sprintf("no space before %v", variable))
+					}
+					if after && !enAfter {
+						problems = append(problems, fmt.Sprintf("no space after %v", variable))
+					}
+				}
+			}
+			for _, problem := range problems {
+				result = append(result, Finding{
+					Lang:    lang,
+					Key:     enKey,
+					Check:   "variable-spacing",
+					Message: fmt.Sprintf("%v: %v ⇒ %v", problem, enString, translated),
+				})
+			}
+		}
+	}
+	return result
+}
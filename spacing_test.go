@@ -0,0 +1,37 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestVariableSpacing(t *testing.T) {
+	translations := map[string]Translation{
+		"en": {
+			"greeting": "Hello $name$!",
+			"count":    "$count$ files",
+			"suffix":   "$count$x faster",
+			"twice":    "$name$ and $name$",
+		},
+		"sv": {
+			"greeting": "Hej$name$!",
+			"count":    "$count$filer",
+			"suffix":   "$count$x snabbare",
+			"twice":    "$name$och$name$",
+		},
+		"de": {"greeting": "Hallo $ name$!"},
+		"ja": {"greeting": "こんにちは$name$さん", "count": "$ count $ファイル"},
+	}
+	want := []Finding{
+		{Lang: "de", Key: "greeting", Check: "variable-spacing", Message: "spaces inside $ name$: Hello $name$! ⇒ Hallo $ name$!"},
+		{Lang: "ja", Key: "count", Check: "variable-spacing", Message: "spaces inside $ count $: $count$ files ⇒ $ count $ファイル"},
+		{Lang: "sv", Key: "count", Check: "variable-spacing", Message: "no space after $count$: $count$ files ⇒ $count$filer"},
+		{Lang: "sv", Key: "greeting", Check: "variable-spacing", Message: "no space before $name$: Hello $name$! ⇒ Hej$name$!"},
+	}
+	findings := checkVariableSpacing(translations)
+	slices.SortFunc(findings, compareFindings)
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("want %v, got %v", want, findings)
+	}
+}
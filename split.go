@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// namespaceOf returns the namespace of key: its prefix up to the first separator, like menu for menu.save.
+// The keys without a prefix, or one which can't be a directory name, are in the fallback namespace.
+func namespaceOf(key, separator, fallback string) string {
+	namespace, _, ok := strings.Cut(key, separator)
+	if !ok || namespace == "" || namespace == "." || namespace == ".." || strings.ContainsAny(namespace, `/\`) {
+		return fallback
+	}
+	return namespace
+}
+
+// splitTranslation partitions translation by the namespaces of its keys, which are kept whole.
+func splitTranslation(translation Translation, separator, fallback string) map[string]Translation {
+	parts := make(map[string]Translation)
+	for key, value := range translation {
+		namespace := namespaceOf(key, separator, fallback)
+		if parts[namespace] == nil {
+			parts[namespace] = Translation{}
+		}
+		parts[namespace][key] = value
+	}
+	return parts
+}
+
+// split writes the translation files of a translation root directory to a directory per namespace,
+// one <namespace>/<lang>.json for every language, and reads them back to make sure nothing was lost.
+func split(args []string) {
+	flags := flag.NewFlagSet("split", flag.ExitOnError)
+	outDir := flags.String("out", ".", "directory the <namespace>/<lang>.json files are written to")
+	separator := flags.String("separator", ".", "separator of the namespace prefix of the keys")
+	fallback := flags.String("default", "common", "namespace of the keys without a prefix")
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v split [flags] <translation-root-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	rootDir := flags.Arg(0)
+	if err := checkRootDir(rootDir); err != nil {
+		log.Fatal(err)
+	}
+
+	config := loadConfig(*configPath)
+	translations, findings := loadTranslations(rootDir, config.Files)
+	if len(findings) > 0 {
+		// Splitting some of the languages only would leave the namespaces inconsistent.
+		reportText(os.Stderr, findings, config.Rules)
+		os.Exit(1)
+	}
+
+	langs := make([]string, 0, len(translations))
+	for lang := range translations {
+		langs = append(langs, lang)
+	}
+	slices.Sort(langs)
+	for _, lang := range langs {
+		translation := translations[lang]
+		parts := splitTranslation(translation, *separator, *fallback)
+		joined := Translation{}
+		for namespace, part := range parts {
+			dir := filepath.Join(*outDir, namespace)
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				log.Fatalf("split: %v", err)
+			}
+			path := filepath.Join(dir, lang+".json")
+			if err := writeTranslation(path, part, rawValues{}); err != nil {
+				log.Fatalf("split: %v: %v", path, err)
+			}
+			written, _, err := loadTranslation(path, config.Files.Arrays)
+			if err != nil {
+				log.Fatalf("split: %v", err)
+			}
+			maps.Copy(joined, written)
+		}
+		if !maps.Equal(joined, translation) {
+			log.Fatalf("split: %v: the split files don't hold the same translations", lang)
+		}
+		fmt.Printf("%v: %v strings in %v namespaces\n", lang, len(translation), len(parts))
+	}
+}
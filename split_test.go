@@ -0,0 +1,25 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitTranslation(t *testing.T) {
+	translation := Translation{
+		"menu.save":      "Spara",
+		"menu.file.open": "Öppna",
+		"dialog.close":   "Stäng",
+		"title":          "Titel",
+		"../evil.key":    "Ond",
+		".hidden":        "Dold",
+	}
+	want := map[string]Translation{
+		"menu":   {"menu.save": "Spara", "menu.file.open": "Öppna"},
+		"dialog": {"dialog.close": "Stäng"},
+		"common": {"title": "Titel", "../evil.key": "Ond", ".hidden": "Dold"},
+	}
+	if parts := splitTranslation(translation, ".", "common"); !reflect.DeepEqual(parts, want) {
+		t.Errorf("want %v, got %v", want, parts)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// jsonProblem is a problem found validating a translation file, at a byte offset.
+type jsonProblem struct {
+	offset  int
+	message string
+}
+
+// validateTranslation strictly validates the contents of a <lang>.json: it must be a single object
+// of supported values, the arrays of strings being read as arrays says, without duplicate keys nor
+// anything after the object. The second result reports whether the contents can still be parsed, leaving out the unsupported values.
+func validateTranslation(bs []byte, arrays string) (problems []jsonProblem, ok bool) {
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	syntaxError := func(err error) ([]jsonProblem, bool) {
+		offset := jsonErrorOffset(err)
+		if errors.Is(err, io.EOF) {
+			offset, err = len(bs), io.ErrUnexpectedEOF
+		} else if offset > 0 && offset < len(bs) {
+			// The offset is just past the offending byte.
+			offset--
+		}
+		return append(problems, jsonProblem{offset, err.Error()}), false
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return syntaxError(err)
+	}
+	if delim, isDelim := tok.(json.Delim); !isDelim || delim != '{' {
+		return append(problems, jsonProblem{0, "the top-level value is not an object"}), false
+	}
+
+	ok = true
+	seen := make(map[string]bool)
+	for dec.More() {
+		offset := skipSeparators(bs, int(dec.InputOffset()))
+		tok, err := dec.Token()
+		if err != nil {
+			return syntaxError(err)
+		}
+		key, _ := tok.(string)
+		if seen[key] {
+			problems = append(problems, jsonProblem{offset, fmt.Sprintf("duplicate key %q", key)})
+		}
+		seen[key] = true
+
+		offset = skipSeparators(bs, int(dec.InputOffset()))
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return syntaxError(err)
+		}
+		if message := decodeValue(Translation{}, key, raw, arrays); message != "" {
+			problems = append(problems, jsonProblem{offset, message})
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return syntaxError(err)
+	}
+	offset := skipSeparators(bs, int(dec.InputOffset()))
+	if _, err := dec.Token(); err != io.EOF {
+		problems = append(problems, jsonProblem{offset, "unexpected data after the top-level object"})
+		ok = false
+	}
+	return problems, ok
+}
+
+// loadStrictTranslations strictly validates and loads the files of a map of language -> path.
+// The problems are reported as findings with their line and column, and the files which
+// can't be parsed are left out instead of stopping the whole run.
+func loadStrictTranslations(paths map[string]string, arrays string) (map[string]Translation, []Finding) {
+	var findings []Finding
+	poPaths := make(map[string]string)
+	parsed := make(map[string]Translation)
+	for lang, path := range paths {
+		if isPOPath(path) {
+			poPaths[lang] = path
+			continue
+		}
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			findings = append(findings, Finding{Lang: lang, Check: "load", Message: err.Error()})
+			continue
+		}
+		problems, ok := validateTranslation(bs, arrays)
+		for _, problem := range problems {
+			line, col := lineCol(bs, problem.offset)
+			findings = append(findings, Finding{
+				Lang:    lang,
+				Check:   "json",
+				Message: fmt.Sprintf("%v:%v:%v: %v", path, line+1, col+1, problem.message),
+			})
+		}
+		if ok {
+			parsed[lang], _, _ = decodeTranslation(bs, arrays)
+		}
+	}
+
+	translations, poFindings := loadTranslationFiles(poPaths, arrays)
+	for lang, translation := range parsed {
+		translations[lang] = translation
+	}
+	return translations, append(findings, poFindings...)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidateTranslation(t *testing.T) {
+	tests := []struct {
+		input    string
+		messages []string
+		ok       bool
+	}{
+		{`{"a": "A", "b": "B"}`, nil, true},
+		{`{"a": "A", "a": "B"}`, []string{`1:12: duplicate key "a"`}, true},
+		{"{\n    \"a\": [\"A\"],\n    \"b\": 1\n}", []string{`2:10: the value of "a" is an array, not a string`, `3:10: the value of "b" is a number, not a string`}, true},
+		{`{"a": "A"} {"b": "B"}`, []string{"1:12: unexpected data after the top-level object"}, false},
+		{"{\n    \"a\": \"A\",\n}", []string{"2:13: invalid character ',' looking for beginning of value"}, false},
+		{`{"a": "A"`, []string{"1:10: unexpected end of JSON input"}, false},
+		{`["a"]`, []string{"1:1: the top-level value is not an object"}, false},
+	}
+	for _, test := range tests {
+		bs := []byte(test.input)
+		problems, ok := validateTranslation(bs, arraysUnsupported)
+		var messages []string
+		for _, problem := range problems {
+			line, col := lineCol(bs, problem.offset)
+			messages = append(messages, fmt.Sprintf("%v:%v: %v", line+1, col+1, problem.message))
+		}
+		if !reflect.DeepEqual(messages, test.messages) || ok != test.ok {
+			t.Errorf("%q: want %q %v, got %q %v", test.input, test.messages, test.ok, messages, ok)
+		}
+	}
+}
+
+func TestLoadStrictTranslations(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{"a": "A"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "sv.json"), []byte(`{"a": "A",}`), 0644)
+
+	paths, _ := findTranslationFiles(dir, FilesConfig{})
+	translations, findings := loadStrictTranslations(paths, arraysUnsupported)
+	if _, ok := translations["sv"]; ok || translations["en"]["a"] != "A" {
+		t.Errorf("unexpected translations: %v", translations)
+	}
+	if len(findings) != 1 || findings[0].Lang != "sv" || findings[0].Check != "json" {
+		t.Errorf("unexpected findings: %v", findings)
+	}
+}
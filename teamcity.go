@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// teamcityEscaper escapes the values of the attributes of the TeamCity service messages.
+// See https://www.jetbrains.com/help/teamcity/service-messages.html
+var teamcityEscaper = strings.NewReplacer("|", "||", "'", "|'", "\n", "|n", "\r", "|r", "[", "|[", "]", "|]")
+
+// teamcityMessage writes a TeamCity service message with the attributes attrs, as name, value pairs.
+func teamcityMessage(w io.Writer, message string, attrs ...string) {
+	fmt.Fprintf(w, "##teamcity[%v", message)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		fmt.Fprintf(w, " %v='%v'", attrs[i], teamcityEscaper.Replace(attrs[i+1]))
+	}
+	fmt.Fprintln(w, "]")
+}
+
+// reportTeamCity writes findings as TeamCity service messages: a test per language of langs, and of the
+// languages with findings, failed with the report of their findings if they fail budgets, and an
+// inspection per finding, on the line of its key in the translation file of its language in paths.
+// The findings without a language are the ones of the files test. At most limits findings are reported
+// in the details of every test.
+func reportTeamCity(w io.Writer, findings []Finding, rules ruleSeverities, langs []string, paths map[string]string, budgets map[string]Budget, limits reportLimits) {
+	byLang := findingsByLang(findings)
+	langs = slices.Clone(langs)
+	for lang := range byLang {
+		langs = append(langs, lang)
+	}
+	slices.Sort(langs)
+	langs = slices.Compact(langs)
+
+	const suite = "check-translations"
+	teamcityMessage(w, "testSuiteStarted", "name", suite)
+	for _, lang := range langs {
+		name := lang
+		if lang == "" {
+			name = reportLocale.sprintf("files")
+		}
+		teamcityMessage(w, "testStarted", "name", name)
+		if langFindings := byLang[lang]; len(langFindings) > 0 {
+			var details bytes.Buffer
+			reportTextLimited(&details, langFindings, rules, limits)
+			if failsBudgets(langFindings, rules, budgets) {
+				teamcityMessage(w, "testFailed", "name", name,
+					"message", reportLocale.sprintf("%v findings", len(langFindings)), "details", details.String())
+			} else {
+				teamcityMessage(w, "testStdOut", "name", name, "out", details.String())
+			}
+		}
+		teamcityMessage(w, "testFinished", "name", name)
+	}
+	teamcityMessage(w, "testSuiteFinished", "name", suite)
+
+	findings = slices.Clone(findings)
+	slices.SortStableFunc(findings, compareFindings)
+	located, outside := locateFindings(findings, paths)
+	types := make(map[string]bool)
+	inspect := func(finding Finding, file string, line int) {
+		id := rules.id(finding.Check)
+		if !types[id] {
+			types[id] = true
+			teamcityMessage(w, "inspectionType", "id", id, "name", finding.Check, "category", "Translations", "description", finding.Check)
+		}
+		severity := "ERROR"
+		if rules.isWarning(finding) {
+			severity = "WARNING"
+		}
+		message := reportLocale.message(finding.Message)
+		if finding.Key != "" {
+			message = finding.Lang + " " + finding.Key + ": " + message
+		}
+		attrs := []string{"typeId", id, "message", message}
+		if file != "" {
+			attrs = append(attrs, "file", file, "line", fmt.Sprint(line))
+		}
+		teamcityMessage(w, "inspection", append(attrs, "SEVERITY", severity)...)
+	}
+	for _, finding := range outside {
+		inspect(finding, "", 0)
+	}
+	for _, finding := range located {
+		inspect(finding.Finding, finding.path, finding.start)
+	}
+}
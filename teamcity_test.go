@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReportTeamCity(t *testing.T) {
+	dir := t.TempDir()
+	svPath := filepath.Join(dir, "sv.json")
+	os.WriteFile(svPath, []byte("{\n  \"greeting\": \"Hej $namn$\",\n  \"rate\": \"100%\"\n}\n"), 0644)
+	findings := []Finding{
+		{Lang: "sv", Key: "greeting", Check: "variables", Message: "mismatch in variables: Hello $name$ ⇒ Hej [namn]"},
+		{Lang: "fi", Key: "rate", Check: "profanity", Message: "it's"},
+	}
+	var buf bytes.Buffer
+	reportTeamCity(&buf, findings, ruleSeverities{}, []string{"de", "sv"}, map[string]string{"sv": svPath}, nil, reportLimits{})
+	want := "##teamcity[testSuiteStarted name='check-translations']\n" +
+		"##teamcity[testStarted name='de']\n" +
+		"##teamcity[testFinished name='de']\n" +
+		"##teamcity[testStarted name='fi']\n" +
+		"##teamcity[testStdOut name='fi' out='|[fi|]|n    it|'s (TXT004)|n']\n" +
+		"##teamcity[testFinished name='fi']\n" +
+		"##teamcity[testStarted name='sv']\n" +
+		"##teamcity[testFailed name='sv' message='1 findings' details='|[sv|]|n    mismatch in variables: Hello $name$ ⇒ Hej |[namn|] (VAR001)|n']\n" +
+		"##teamcity[testFinished name='sv']\n" +
+		"##teamcity[testSuiteFinished name='check-translations']\n" +
+		"##teamcity[inspectionType id='TXT004' name='profanity' category='Translations' description='profanity']\n" +
+		"##teamcity[inspection typeId='TXT004' message='fi rate: it|'s' SEVERITY='WARNING']\n" +
+		"##teamcity[inspectionType id='VAR001' name='variables' category='Translations' description='variables']\n" +
+		"##teamcity[inspection typeId='VAR001' message='sv greeting: mismatch in variables: Hello $name$ ⇒ Hej |[namn|]' file='" + svPath + "' line='2' SEVERITY='ERROR']\n"
+	if buf.String() != want {
+		t.Errorf("want:\n%v\ngot:\n%v", want, buf.String())
+	}
+
+	// A language within its budget passes.
+	buf.Reset()
+	reportTeamCity(&buf, findings[:1], ruleSeverities{}, nil, nil, map[string]Budget{"sv": {Errors: 1}}, reportLimits{})
+	if bytes.Contains(buf.Bytes(), []byte("testFailed")) {
+		t.Errorf("within budget: got:\n%v", buf.String())
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// defaultUsagePatterns match JavaScript t("key") calls and Go template {{ t "key" }} calls.
+// Both can have a second argument with the default english text, t("key", "Text").
+var defaultUsagePatterns = []string{
+	`\bt\(\s*["'` + "`" + `]([^"'` + "`" + `]+)["'` + "`" + `](?:\s*,\s*["'` + "`" + `]([^"'` + "`" + `]*)["'` + "`" + `])?`,
+	`\{\{-?\s*[tT]\s+"([^"]+)"(?:\s+"([^"]*)")?`,
+}
+
+// skippedDirs are never scanned for key references.
+var skippedDirs = []string{".git", "node_modules", "vendor"}
+
+// keyReference is a reference to a translation key found in the application source.
+type keyReference struct {
+	key string
+	// text is the default english text given with the reference, if any.
+	text string
+	file string
+	line int
+}
+
+// scanUsage finds all the references matching patterns in the files under dirs.
+// Files for which skip returns true, and binary files, are not scanned.
+func scanUsage(dirs []string, patterns []*regexp.Regexp, skip func(path string) bool) ([]keyReference, error) {
+	var refs []keyReference
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != dir && slices.Contains(skippedDirs, d.Name()) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() || skip(path) {
+				return nil
+			}
+			bs, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if bytes.IndexByte(bs[:min(len(bs), 8000)], 0) >= 0 {
+				return nil
+			}
+			scanner := bufio.NewScanner(bytes.NewReader(bs))
+			scanner.Buffer(nil, len(bs)+1)
+			for line := 1; scanner.Scan(); line++ {
+				for _, rx := range patterns {
+					for _, m := range rx.FindAllStringSubmatch(scanner.Text(), -1) {
+						if len(m) < 2 || m[1] == "" {
+							continue
+						}
+						ref := keyReference{key: m[1], file: path, line: line}
+						if len(m) > 2 {
+							ref.text = m[2]
+						}
+						refs = append(refs, ref)
+					}
+				}
+			}
+			return scanner.Err()
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return refs, nil
+}
+
+// scanSources scans the source directories for key references, skipping the translation files
+// at paths. The directories and patterns given on the command line take precedence over the
+// configured ones.
+func scanSources(command string, config Config, dirs, patterns []string, paths map[string]string) []keyReference {
+	if len(dirs) == 0 {
+		dirs = config.Usage.Dirs
+	}
+	if len(patterns) == 0 {
+		patterns = config.Usage.Patterns
+	}
+	if len(dirs) == 0 {
+		log.Fatalf("%v: no source directories given", command)
+	}
+	if len(patterns) == 0 {
+		patterns = defaultUsagePatterns
+	}
+	var rxs []*regexp.Regexp
+	for _, pattern := range patterns {
+		rx, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Fatalf("%v: %v", command, err)
+		}
+		rxs = append(rxs, rx)
+	}
+
+	// The translation files themselves are full of keys, without using any of them.
+	translationFiles := make(map[string]bool)
+	for _, path := range paths {
+		abs, _ := filepath.Abs(path)
+		translationFiles[abs] = true
+	}
+	refs, err := scanUsage(dirs, rxs, func(path string) bool {
+		abs, _ := filepath.Abs(path)
+		return translationFiles[abs]
+	})
+	if err != nil {
+		log.Fatalf("%v: %v", command, err)
+	}
+	return refs
+}
+
+// unusedKeys reports the keys of the english reference which are never referenced.
+func unusedKeys(en Translation, refs []keyReference) (result []Finding) {
+	used := make(map[string]bool)
+	for _, ref := range refs {
+		used[ref.key] = true
+	}
+	for key := range en {
+		if !used[key] {
+			result = append(result, Finding{
+				Lang:    "en",
+				Key:     key,
+				Check:   "unused-key",
+				Message: fmt.Sprintf("key is never referenced in the source: %v", key),
+			})
+		}
+	}
+	slices.SortFunc(result, func(a, b Finding) int { return strings.Compare(a.Key, b.Key) })
+	return result
+}
+
+// missingKeys reports the referenced keys which are missing from the english reference,
+// once per key with all the places it is referenced at.
+func missingKeys(en Translation, refs []keyReference) (result []Finding) {
+	places := make(map[string][]string)
+	var keys []string
+	for _, ref := range refs {
+		if _, ok := en[ref.key]; ok {
+			continue
+		}
+		if places[ref.key] == nil {
+			keys = append(keys, ref.key)
+		}
+		places[ref.key] = append(places[ref.key], fmt.Sprintf("%v:%v", ref.file, ref.line))
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		result = append(result, Finding{
+			Lang:    "en",
+			Key:     key,
+			Check:   "missing-key",
+			Message: fmt.Sprintf("key is missing from the catalog: %v, referenced at %v", key, strings.Join(places[key], ", ")),
+		})
+	}
+	return result
+}
+
+// scanUsageCommand scans the application source for the translation keys,
+// and reports the unused ones and the referenced ones missing from the catalog.
+func scanUsageCommand(args []string) {
+	flags := flag.NewFlagSet("scan-usage", flag.ExitOnError)
+	var dirs listFlag
+	var patterns []string
+	flags.Var(&dirs, "dir", "comma separated source directories to scan, can be repeated (default from the configuration file)")
+	// The patterns aren't split, since regular expressions have commas.
+	flags.Func("pattern",
+		"regular expression matching key references, with the key as the first group, can be repeated (default t(\"key\") and {{ t \"key\" }} calls)",
+		func(pattern string) error { patterns = append(patterns, pattern); return nil })
+	reportUnused := flags.Bool("unused", true, "report the keys of en.json which are never referenced")
+	reportMissing := flags.Bool("missing", true, "report the referenced keys which are missing from en.json")
+	configPath := flags.String("config", "",
+		fmt.Sprintf("configuration file (default %v, if it exists)", defaultConfigFile))
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage:\n    %v scan-usage [flags] <translation-root-dir>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	rootDir := flags.Arg(0)
+	if err := checkRootDir(rootDir); err != nil {
+		log.Fatal(err)
+	}
+
+	config := loadConfig(*configPath)
+	paths, findings := findTranslationFiles(rootDir, config.Files)
+	refs := scanSources("scan-usage", config, dirs, patterns, paths)
+	translations, loadFindings := loadTranslationFiles(paths, config.Files.Arrays)
+	findings = append(findings, loadFindings...)
+	if *reportUnused {
+		findings = append(findings, unusedKeys(translations["en"], refs)...)
+	}
+	if *reportMissing {
+		findings = append(findings, missingKeys(translations["en"], refs)...)
+	}
+	reportText(os.Stderr, findings, config.Rules)
+	if len(findings) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestScanUsage(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"src/app.js":                "label = t(\"menu.file\", \"File\");\nother = t( 'menu.edit' ) + t(`menu.view`);\n",
+		"src/view.tmpl":             "{{ translate \"menu.help\" }}{{ t \"menu.about\" \"About\" }}",
+		"src/node_modules/lib/x.js": "t(\"menu.skipped\")",
+		"src/image.png":             "\x00t(\"menu.binary\")",
+		"src/locales/en.json":       `{"menu.file": "File"}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+
+	patterns := []*regexp.Regexp{regexp.MustCompile(`translate "([^"]+)"`)}
+	for _, pattern := range defaultUsagePatterns {
+		patterns = append(patterns, regexp.MustCompile(pattern))
+	}
+	refs, err := scanUsage([]string{filepath.Join(dir, "src")}, patterns, func(path string) bool {
+		return isTranslationFile(path)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []keyReference{
+		{"menu.file", "File", filepath.Join(dir, "src/app.js"), 1},
+		{"menu.edit", "", filepath.Join(dir, "src/app.js"), 2},
+		{"menu.view", "", filepath.Join(dir, "src/app.js"), 2},
+		{"menu.help", "", filepath.Join(dir, "src/view.tmpl"), 1},
+		{"menu.about", "About", filepath.Join(dir, "src/view.tmpl"), 1},
+	}
+	if len(refs) != len(want) {
+		t.Fatalf("want %v, got %v", want, refs)
+	}
+	for i := range want {
+		if refs[i] != want[i] {
+			t.Errorf("want %v, got %v", want[i], refs[i])
+		}
+	}
+
+	en := Translation{"menu.file": "File", "menu.edit": "Edit", "menu.unused": "Unused", "menu.old": "Old"}
+	unused := unusedKeys(en, refs)
+	if len(unused) != 2 || unused[0].Key != "menu.old" || unused[1].Key != "menu.unused" {
+		t.Errorf("unexpected unused keys: %v", unused)
+	}
+}
+
+func TestMissingKeys(t *testing.T) {
+	en := Translation{"menu.file": "File"}
+	refs := []keyReference{
+		{"menu.file", "", "app.js", 1},
+		{"menu.flie", "", "app.js", 2},
+		{"menu.edit", "", "app.js", 3},
+		{"menu.flie", "", "view.js", 7},
+	}
+	missing := missingKeys(en, refs)
+	if len(missing) != 2 {
+		t.Fatalf("want 2 missing keys, got %v", missing)
+	}
+	if missing[0].Key != "menu.edit" || missing[1].Key != "menu.flie" {
+		t.Errorf("unexpected missing keys: %v", missing)
+	}
+	if want := "key is missing from the catalog: menu.flie, referenced at app.js:2, view.js:7"; missing[1].Message != want {
+		t.Errorf("want %q, got %q", want, missing[1].Message)
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// The ways arrays of strings in the translation files can be read.
+const (
+	// arraysUnsupported reports them like any other value which isn't a string.
+	arraysUnsupported = ""
+	// arraysJoin joins the elements into a single string, one line each.
+	arraysJoin = "join"
+	// arraysElements reads every element as a string of its own, with the key key[index].
+	arraysElements = "elements"
+)
+
+// jsonKind describes the kind of a JSON value, e.g. "an object".
+func jsonKind(value json.RawMessage) string {
+	switch value[0] {
+	case '{':
+		return "an object"
+	case '[':
+		return "an array"
+	case 't', 'f':
+		return "a boolean"
+	case '"':
+		return "a string"
+	case 'n':
+		return "null"
+	}
+	return "a number"
+}
+
+// decodeValue decodes the value of key into translation, as a string or an array of strings
+// if arrays allows them. An error message is returned if the value isn't supported, like null,
+// which would otherwise be read as the empty string.
+func decodeValue(translation Translation, key string, value json.RawMessage, arrays string) string {
+	var s string
+	if value[0] == '"' && json.Unmarshal(value, &s) == nil {
+		translation[key] = s
+		return ""
+	}
+	var elements []string
+	if value[0] == '[' && arrays != arraysUnsupported && json.Unmarshal(value, &elements) == nil {
+		if arrays == arraysJoin {
+			translation[key] = strings.Join(elements, "\n")
+		} else {
+			for i, element := range elements {
+				translation[fmt.Sprintf("%v[%v]", key, i)] = element
+			}
+		}
+		return ""
+	}
+	return fmt.Sprintf("the value of %q is %v, not a string", key, jsonKind(value))
+}
+
+// rawValues are the raw JSON values of the members of a translation file which aren't read as a single
+// string, by key: the values which aren't supported, and the arrays read as configured.
+// They are kept for the file to be rewritten without losing them, see encodeTranslation.
+type rawValues struct {
+	// arrays is how the arrays of strings were read, see the arrays constants.
+	arrays string
+	values map[string]json.RawMessage
+}
+
+// skippedValue is a value of a translation file which is left out, with the message saying why.
+type skippedValue struct {
+	key     string
+	message string
+}
+
+// skipped returns the values of raw which aren't supported, sorted by key.
+func (raw rawValues) skipped() []skippedValue {
+	var skipped []skippedValue
+	for key, value := range raw.values {
+		if message := decodeValue(Translation{}, key, value, raw.arrays); message != "" {
+			skipped = append(skipped, skippedValue{key, message})
+		}
+	}
+	slices.SortFunc(skipped, func(a, b skippedValue) int { return strings.Compare(a.key, b.key) })
+	return skipped
+}
+
+// decodeTranslation parses the contents of a <lang>.json defensively, see readTranslation.
+func decodeTranslation(bs []byte, arrays string) (Translation, rawValues, error) {
+	return readTranslation(bytes.NewReader(bs), arrays)
+}
+
+// readTranslation decodes a <lang>.json from r defensively, reading the arrays of strings as arrays says:
+// the values which aren't supported are left out of the translation, and returned with the arrays among
+// the raw values instead.
+// An error is only returned if the contents aren't a JSON object.
+// The file isn't held in memory while it's decoded, but the whole translation is built before any check
+// runs, since the checks compare it with the other languages: the memory taken grows with the catalog.
+func readTranslation(r io.Reader, arrays string) (Translation, rawValues, error) {
+	dec := json.NewDecoder(r)
+	if token, err := dec.Token(); err != nil {
+		return nil, rawValues{}, err
+	} else if token != json.Delim('{') {
+		return nil, rawValues{}, fmt.Errorf("the contents are %v, not an object", jsonTokenKind(token))
+	}
+
+	translation := Translation{}
+	raw := rawValues{arrays: arrays, values: map[string]json.RawMessage{}}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, rawValues{}, err
+		}
+		key := token.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, rawValues{}, err
+		}
+		if value[0] != '"' {
+			raw.values[key] = value
+		}
+		decodeValue(translation, key, value, arrays)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, rawValues{}, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, rawValues{}, fmt.Errorf("invalid data after the top-level object at offset %v", dec.InputOffset())
+	}
+	return translation, raw, nil
+}
+
+// encodeTranslation encodes translation as a <lang>.json with sorted keys and without escaping HTML,
+// along with the raw values read from the file: the arrays are written back from their strings in
+// translation, and the values which aren't supported as they were read.
+func encodeTranslation(translation Translation, raw rawValues) ([]byte, error) {
+	members := make(map[string]any, len(translation)+len(raw.values))
+	for key, s := range translation {
+		members[key] = s
+	}
+	for key, value := range raw.values {
+		var elements []string
+		if raw.arrays == arraysUnsupported || value[0] != '[' || json.Unmarshal(value, &elements) != nil {
+			if _, ok := members[key]; !ok {
+				members[key] = value
+			}
+			continue
+		}
+		if raw.arrays == arraysJoin {
+			// The lines can only be told from the elements when the string changed.
+			if s, ok := translation[key]; ok && s != strings.Join(elements, "\n") {
+				members[key] = strings.Split(s, "\n")
+			} else if ok {
+				members[key] = value
+			}
+			continue
+		}
+		for i := range elements {
+			elementKey := fmt.Sprintf("%v[%v]", key, i)
+			if s, ok := translation[elementKey]; ok {
+				elements[i] = s
+			}
+			delete(members, elementKey)
+		}
+		members[key] = elements
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(members); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonTokenKind describes the kind of the value starting with token, e.g. "an array".
+func jsonTokenKind(token json.Token) string {
+	switch token := token.(type) {
+	case json.Delim:
+		if token == '[' {
+			return "an array"
+		}
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case nil:
+		return "null"
+	}
+	return "a number"
+}
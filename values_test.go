@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeTranslation(t *testing.T) {
+	bs := []byte(`{"a": "A", "b": {"c": "C"}, "d": ["D1", "D2"], "e": 1, "f": [1], "g": null}`)
+	messagesOf := func(raw rawValues) (messages []string) {
+		for _, skipped := range raw.skipped() {
+			messages = append(messages, skipped.message)
+		}
+		return messages
+	}
+
+	tests := []struct {
+		arrays      string
+		translation Translation
+		problems    []string
+	}{
+		{arraysUnsupported, Translation{"a": "A"}, []string{
+			`the value of "b" is an object, not a string`,
+			`the value of "d" is an array, not a string`,
+			`the value of "e" is a number, not a string`,
+			`the value of "f" is an array, not a string`,
+			`the value of "g" is null, not a string`,
+		}},
+		{arraysJoin, Translation{"a": "A", "d": "D1\nD2"}, []string{
+			`the value of "b" is an object, not a string`,
+			`the value of "e" is a number, not a string`,
+			`the value of "f" is an array, not a string`,
+			`the value of "g" is null, not a string`,
+		}},
+		{arraysElements, Translation{"a": "A", "d[0]": "D1", "d[1]": "D2"}, []string{
+			`the value of "b" is an object, not a string`,
+			`the value of "e" is a number, not a string`,
+			`the value of "f" is an array, not a string`,
+			`the value of "g" is null, not a string`,
+		}},
+	}
+	for _, test := range tests {
+		translation, raw, err := decodeTranslation(bs, test.arrays)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(translation, test.translation) || !reflect.DeepEqual(messagesOf(raw), test.problems) {
+			t.Errorf("%q: want %v %q, got %v %q",
+				test.arrays, test.translation, test.problems, translation, messagesOf(raw))
+		}
+	}
+}
+
+func TestReadTranslation(t *testing.T) {
+	bs := []byte("{\n    \"a\": \"A\",\n    \"b\": 12,\n    \"c\": {\"d\": 1}\n}\n")
+	translation, raw, err := readTranslation(bytes.NewReader(bs), arraysUnsupported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(translation, Translation{"a": "A"}) {
+		t.Errorf("unexpected translation: %v", translation)
+	}
+	if want := (rawValues{values: map[string]json.RawMessage{"b": json.RawMessage("12"), "c": json.RawMessage(`{"d": 1}`)}}); !reflect.DeepEqual(raw, want) {
+		t.Errorf("want raw values %s, got %s", want, raw)
+	}
+
+	for _, invalid := range []string{``, `{"a": `, `["a"]`, `"a"`, `{"a": "A"} {}`, `{"a": "A"} x`, `{"a" "A"}`} {
+		if _, _, err := readTranslation(strings.NewReader(invalid), arraysUnsupported); err == nil {
+			t.Errorf("%q: want an error", invalid)
+		}
+	}
+}
+
+func TestEncodeTranslation(t *testing.T) {
+	bs := []byte(`{"a": "A", "b": {"c": "C"}, "d": ["D1", "D2"], "e": 1, "g": null}`)
+
+	tests := []struct {
+		arrays  string
+		changed Translation
+		want    string
+	}{
+		{arraysUnsupported, Translation{"a": "<b>A2</b>", "f": "F"},
+			`{"a":"<b>A2</b>","b":{"c":"C"},"d":["D1","D2"],"e":1,"f":"F","g":null}`},
+		{arraysJoin, Translation{"f": "F"}, `{"a":"A","b":{"c":"C"},"d":["D1","D2"],"e":1,"f":"F","g":null}`},
+		{arraysJoin, Translation{"d": "D1\nD2\nD3"}, `{"a":"A","b":{"c":"C"},"d":["D1","D2","D3"],"e":1,"g":null}`},
+		{arraysElements, Translation{"d[1]": "D3"}, `{"a":"A","b":{"c":"C"},"d":["D1","D3"],"e":1,"g":null}`},
+	}
+	for _, test := range tests {
+		translation, raw, err := decodeTranslation(bs, test.arrays)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for key, s := range test.changed {
+			translation[key] = s
+		}
+		encoded, err := encodeTranslation(translation, raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, encoded); err != nil {
+			t.Fatal(err)
+		}
+		if got := compacted.String(); got != test.want {
+			t.Errorf("%q: want %v, got %v", test.arrays, test.want, got)
+		}
+	}
+}
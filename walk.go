@@ -0,0 +1,225 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// errDirectoryCycle is returned when a directory is reached again inside itself, through a link or a mount.
+var errDirectoryCycle = errors.New("directory cycle, skipping it")
+
+// translationWalker collects the translation files of a directory tree.
+type translationWalker struct {
+	FilesConfig
+	match fileMatcher
+	// paths maps language -> path.
+	paths map[string]string
+	// findings reports the entries which couldn't be read.
+	findings []Finding
+	// ancestors are the directories being walked, to detect cycles.
+	ancestors []fs.FileInfo
+	// ignores are the rules of the ignore files of the directories being walked.
+	ignores []ignoreRule
+}
+
+// failed reports that path couldn't be read. The finding has the language of path if it's
+// a translation file, and no language otherwise.
+func (w *translationWalker) failed(path string, err error) {
+	finding := Finding{Check: "files", Message: err.Error()}
+	finding.Lang, _ = w.match(path)
+	w.findings = append(w.findings, finding)
+}
+
+// walk collects the translation files under dir, in lexical order.
+// The entries of dir are at the given depth, 1 for the ones of the root directory.
+func (w *translationWalker) walk(dir string, depth int) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		w.failed(dir, err)
+		return
+	}
+	for _, ancestor := range w.ancestors {
+		if os.SameFile(ancestor, info) {
+			w.failed(dir, fmt.Errorf("%v: %w", dir, errDirectoryCycle))
+			return
+		}
+	}
+	w.ancestors = append(w.ancestors, info)
+	defer func() { w.ancestors = w.ancestors[:len(w.ancestors)-1] }()
+	if !w.NoIgnore {
+		rules, err := loadIgnoreRules(dir)
+		if err != nil {
+			w.failed(dir, err)
+		}
+		n := len(w.ignores)
+		w.ignores = append(w.ignores, rules...)
+		defer func() { w.ignores = w.ignores[:n] }()
+	}
+
+	// The entries read before an error are still walked.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		w.failed(dir, err)
+	}
+	descend := w.MaxDepth == 0 || depth < w.MaxDepth
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		// As in git, the links are ignored like files, even the ones to directories.
+		if isIgnored(w.ignores, path, entry.IsDir()) {
+			continue
+		}
+		lang, isTranslation := w.match(entry.Name())
+		isTranslation = isTranslation && w.wantsLanguage(lang)
+		if entry.Type()&fs.ModeSymlink != 0 {
+			target, err := os.Stat(path)
+			switch {
+			case err != nil && isTranslation:
+				w.failed(path, fmt.Errorf("broken link: %w", err))
+			case err != nil:
+				// Broken links to other files don't matter.
+			case target.IsDir() && w.FollowSymlinks && descend:
+				w.walk(path, depth+1)
+			case !target.IsDir() && isTranslation:
+				w.paths[lang] = path
+			}
+			continue
+		}
+		switch {
+		case entry.IsDir() && descend:
+			w.walk(path, depth+1)
+		case !entry.IsDir() && isTranslation:
+			w.paths[lang] = path
+		}
+	}
+}
+
+// isTranslationName reports whether a file name is the one of a <lang>.json or <lang>.po file.
+func isTranslationName(name string) bool {
+	return isTranslationFile(name) || isPOFile(name)
+}
+
+// fileMatcher returns the language of a translation file name, and whether it is one.
+type fileMatcher func(name string) (lang string, ok bool)
+
+// defaultFileMatcher matches the <lang>.json and <lang>.po files.
+func defaultFileMatcher(name string) (string, bool) {
+	if !isTranslationName(filepath.Base(name)) {
+		return "", false
+	}
+	return translationLang(name), true
+}
+
+// newFileMatcher returns the matcher of the translation file names for pattern, which is either
+// a regular expression whose "lang" group, or first group, is the language, like `^messages\.(\w+)\.json$`,
+// or a glob whose first * or run of ? is the language, like translation_*.json.
+// The empty pattern matches the <lang>.json and <lang>.po files.
+func newFileMatcher(pattern string) (fileMatcher, error) {
+	if pattern == "" {
+		return defaultFileMatcher, nil
+	}
+	expr := pattern
+	if !strings.Contains(pattern, "(") {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+		}
+		expr = globRegexp(pattern)
+	}
+	rx, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+	}
+	group := rx.SubexpIndex("lang")
+	if group < 0 {
+		group = 1
+	}
+	if rx.NumSubexp() < group {
+		return nil, fmt.Errorf("pattern %q: no group capturing the language", pattern)
+	}
+	return func(name string) (string, bool) {
+		m := rx.FindStringSubmatch(filepath.Base(name))
+		if m == nil || m[group] == "" {
+			return "", false
+		}
+		return m[group], true
+	}, nil
+}
+
+// globRegexp converts a glob to an anchored regular expression capturing its first * or run of ?.
+func globRegexp(glob string) string {
+	var expr strings.Builder
+	expr.WriteString("^")
+	captured := false
+	for i := 0; i < len(glob); i++ {
+		switch glob[i] {
+		case '*', '?':
+			n := 1
+			for glob[i] == '?' && i+n < len(glob) && glob[i+n] == '?' {
+				n++
+			}
+			part := "[^/]*"
+			if glob[i] == '?' {
+				part = fmt.Sprintf("[^/]{%v}", n)
+			}
+			i += n - 1
+			if !captured {
+				part = "(" + part + ")"
+				captured = true
+			}
+			expr.WriteString(part)
+		case '[':
+			end := strings.IndexByte(glob[i:], ']')
+			expr.WriteString(glob[i : i+end+1])
+			i += end
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+			}
+			expr.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		default:
+			expr.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	expr.WriteString("$")
+	return expr.String()
+}
+
+// translationFilePath returns the path of a new translation file of lang in rootDir, named after pattern
+// like newFileMatcher: the first * or run of ? of a glob is replaced with lang, and for a regular
+// expression, the language of an existing file of paths, by language, is. An error is returned if the
+// name can't be told.
+func translationFilePath(rootDir, pattern, lang string, paths map[string]string) (string, error) {
+	match, err := newFileMatcher(pattern)
+	if err != nil {
+		return "", err
+	}
+	matches := func(name string) bool {
+		l, ok := match(name)
+		return ok && l == lang
+	}
+	if pattern == "" {
+		return filepath.Join(rootDir, lang+".json"), nil
+	}
+	if i := strings.IndexAny(pattern, "*?"); i >= 0 && !strings.ContainsAny(pattern, "([\\") {
+		n := 1
+		for pattern[i] == '?' && i+n < len(pattern) && pattern[i+n] == '?' {
+			n++
+		}
+		if name := pattern[:i] + lang + pattern[i+n:]; !strings.ContainsAny(name, "*?") && matches(name) {
+			return filepath.Join(rootDir, name), nil
+		}
+	}
+	for _, other := range sortedKeys(paths) {
+		dir, base := filepath.Split(paths[other])
+		if i := strings.Index(base, other); i >= 0 {
+			if name := base[:i] + lang + base[i+len(other):]; matches(name) {
+				return filepath.Join(dir, name), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("the name of the %v file can't be told from the pattern %q", lang, pattern)
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindTranslationFiles(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "nested"), 0755)
+	os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{}`), 0644)
+	os.WriteFile(filepath.Join(dir, "nested", "sv.po"), []byte(``), 0644)
+	os.WriteFile(filepath.Join(dir, "notes.json"), []byte(`{}`), 0644)
+	os.Symlink(filepath.Join(dir, "missing.json"), filepath.Join(dir, "de.json"))
+
+	paths, findings := findTranslationFiles(dir, FilesConfig{})
+	want := map[string]string{
+		"en": filepath.Join(dir, "en.json"),
+		"sv": filepath.Join(dir, "nested", "sv.po"),
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("want %v, got %v", want, paths)
+	}
+	if len(findings) != 1 || findings[0].Lang != "de" || !strings.HasPrefix(findings[0].Message, "broken link:") {
+		t.Errorf("unexpected findings: %v", findings)
+	}
+
+	_, findings = findTranslationFiles(filepath.Join(dir, "missing"), FilesConfig{})
+	if len(findings) != 1 || findings[0].Lang != "" {
+		t.Errorf("missing directory: unexpected findings: %v", findings)
+	}
+}
+
+func TestFollowSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	shared := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{}`), 0644)
+	os.WriteFile(filepath.Join(shared, "sv.json"), []byte(`{}`), 0644)
+	os.Symlink(shared, filepath.Join(dir, "shared"))
+	os.Symlink(dir, filepath.Join(dir, "loop"))
+
+	paths, findings := findTranslationFiles(dir, FilesConfig{})
+	if len(paths) != 1 || len(findings) != 0 {
+		t.Errorf("not following: unexpected %v %v", paths, findings)
+	}
+
+	paths, findings = findTranslationFiles(dir, FilesConfig{FollowSymlinks: true})
+	want := map[string]string{
+		"en": filepath.Join(dir, "en.json"),
+		"sv": filepath.Join(dir, "shared", "sv.json"),
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("want %v, got %v", want, paths)
+	}
+	if len(findings) != 1 || !strings.Contains(findings[0].Message, errDirectoryCycle.Error()) {
+		t.Errorf("unexpected findings: %v", findings)
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "a", "b"), 0755)
+	os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{}`), 0644)
+	os.WriteFile(filepath.Join(dir, "a", "sv.json"), []byte(`{}`), 0644)
+	os.WriteFile(filepath.Join(dir, "a", "b", "de.json"), []byte(`{}`), 0644)
+
+	for _, test := range []struct {
+		maxDepth int
+		langs    int
+	}{{0, 3}, {1, 1}, {2, 2}, {3, 3}} {
+		paths, _ := findTranslationFiles(dir, FilesConfig{MaxDepth: test.maxDepth})
+		if len(paths) != test.langs {
+			t.Errorf("max depth %v: want %v languages, got %v", test.maxDepth, test.langs, paths)
+		}
+	}
+}
+
+func TestNewFileMatcher(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		lang    string
+		ok      bool
+	}{
+		{"", "sv.json", "sv", true},
+		{"", "sv.po", "sv", true},
+		{"", "notes.json", "", false},
+		{"translation_*.json", "translation_de.json", "de", true},
+		{"translation_*.json", "translation_de.po", "", false},
+		{"translation_??.json", "translation_pt.json", "pt", true},
+		{`^messages\.(?P<lang>[a-z]{2})\.json$`, "messages.de.json", "de", true},
+		{`^messages\.(?P<lang>[a-z]{2})\.json$`, "messages.json", "", false},
+		{`^(web|app)-(?P<lang>\w+)\.json$`, "web-sv.json", "sv", true},
+		{`^([a-z]+)\.yaml$`, "fr.yaml", "fr", true},
+	}
+	for _, test := range tests {
+		match, err := newFileMatcher(test.pattern)
+		if err != nil {
+			t.Errorf("%q: %v", test.pattern, err)
+			continue
+		}
+		if lang, ok := match(test.name); lang != test.lang || ok != test.ok {
+			t.Errorf("%q %q: want %q %v, got %q %v", test.pattern, test.name, test.lang, test.ok, lang, ok)
+		}
+	}
+
+	for _, pattern := range []string{"translation_[.json", `^messages\.\w+\.json$`, `^(\w+\.json`} {
+		if _, err := newFileMatcher(pattern); err == nil {
+			t.Errorf("%q: want an error", pattern)
+		}
+	}
+}
+
+func TestTranslationFilePath(t *testing.T) {
+	paths := map[string]string{"sv": filepath.Join("locales", "nested", "messages.sv.json")}
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"", filepath.Join("locales", "en.json")},
+		{"translation_*.json", filepath.Join("locales", "translation_en.json")},
+		{"translation_??.json", filepath.Join("locales", "translation_en.json")},
+		{`^messages\.(?P<lang>[a-z]{2})\.json$`, filepath.Join("locales", "nested", "messages.en.json")},
+	}
+	for _, test := range tests {
+		if got, err := translationFilePath("locales", test.pattern, "en", paths); got != test.want || err != nil {
+			t.Errorf("%q: want %v, got %v, %v", test.pattern, test.want, got, err)
+		}
+	}
+	if _, err := translationFilePath("locales", `^messages\.(?P<lang>[a-z]{2})\.json$`, "en", nil); err == nil {
+		t.Error("want an error without a file to name it after")
+	}
+}
+
+func TestFindTranslationFilesPattern(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "translation_en.json"), []byte(`{}`), 0644)
+	os.WriteFile(filepath.Join(dir, "translation_sv.json"), []byte(`{}`), 0644)
+	os.WriteFile(filepath.Join(dir, "de.json"), []byte(`{}`), 0644)
+
+	paths, findings := findTranslationFiles(dir, FilesConfig{Pattern: "translation_*.json"})
+	want := map[string]string{
+		"en": filepath.Join(dir, "translation_en.json"),
+		"sv": filepath.Join(dir, "translation_sv.json"),
+	}
+	if !reflect.DeepEqual(paths, want) || len(findings) != 0 {
+		t.Errorf("want %v, got %v %v", want, paths, findings)
+	}
+
+	paths, findings = findTranslationFiles(dir, FilesConfig{Pattern: "translation_[.json"})
+	if len(paths) != 0 || len(findings) != 1 || findings[0].Check != "files" {
+		t.Errorf("invalid pattern: unexpected %v %v", paths, findings)
+	}
+}
+
+func TestFindTranslationFilesIncludeLanguages(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"en.json", "de.json", "sv.json", "fi.json"} {
+		os.WriteFile(filepath.Join(dir, name), []byte(`{}`), 0644)
+	}
+
+	paths, findings := findTranslationFiles(dir, FilesConfig{IncludeLanguages: []string{"sv", "fi"}})
+	want := map[string]string{
+		"en": filepath.Join(dir, "en.json"),
+		"sv": filepath.Join(dir, "sv.json"),
+		"fi": filepath.Join(dir, "fi.json"),
+	}
+	if !reflect.DeepEqual(paths, want) || len(findings) != 0 {
+		t.Errorf("want %v, got %v %v", want, paths, findings)
+	}
+}
+
+func TestFindTranslationFilesExcludeLanguages(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"en.json", "de.json", "is.json", "mt.json"} {
+		os.WriteFile(filepath.Join(dir, name), []byte(`{}`), 0644)
+	}
+
+	paths, findings := findTranslationFiles(dir, FilesConfig{ExcludeLanguages: []string{"en", "is", "mt"}})
+	want := map[string]string{
+		"en": filepath.Join(dir, "en.json"),
+		"de": filepath.Join(dir, "de.json"),
+	}
+	if !reflect.DeepEqual(paths, want) || len(findings) != 0 {
+		t.Errorf("want %v, got %v %v", want, paths, findings)
+	}
+}
+
+func TestFindTranslationFilesIgnored(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "build"), 0755)
+	os.MkdirAll(filepath.Join(dir, "web", "node_modules"), 0755)
+	os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("build/\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "web", ".check-translationsignore"), []byte("node_modules\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{}`), 0644)
+	os.WriteFile(filepath.Join(dir, "build", "sv.json"), []byte(`{}`), 0644)
+	os.WriteFile(filepath.Join(dir, "web", "de.json"), []byte(`{}`), 0644)
+	os.WriteFile(filepath.Join(dir, "web", "node_modules", "ab.json"), []byte(`{}`), 0644)
+
+	paths, _ := findTranslationFiles(dir, FilesConfig{})
+	want := map[string]string{
+		"en": filepath.Join(dir, "en.json"),
+		"de": filepath.Join(dir, "web", "de.json"),
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("want %v, got %v", want, paths)
+	}
+
+	paths, _ = findTranslationFiles(dir, FilesConfig{NoIgnore: true})
+	if len(paths) != 4 {
+		t.Errorf("want all the files without ignoring any, got %v", paths)
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// isWorkbookPath reports whether p is an XLSX workbook, read in place of a translation root directory.
+func isWorkbookPath(p string) bool {
+	return strings.EqualFold(filepath.Ext(p), ".xlsx")
+}
+
+// The parts of the SpreadsheetML files of a workbook which are read.
+type (
+	xlsxWorkbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	xlsxRelationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	xlsxSharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+	xlsxWorksheet struct {
+		Rows []struct {
+			Cells []xlsxCell `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	xlsxCell struct {
+		Ref    string   `xml:"r,attr"`
+		Type   string   `xml:"t,attr"`
+		Value  string   `xml:"v"`
+		Inline xlsxText `xml:"is"`
+	}
+	// xlsxText is a string, either plain or made of rich text runs.
+	xlsxText struct {
+		T    string `xml:"t"`
+		Runs []struct {
+			T string `xml:"t"`
+		} `xml:"r"`
+	}
+)
+
+func (t xlsxText) String() string {
+	s := t.T
+	for _, run := range t.Runs {
+		s += run.T
+	}
+	return s
+}
+
+// xlsxSheet is a sheet of a workbook, with the values of its cells by row and column.
+type xlsxSheet struct {
+	name string
+	rows [][]string
+}
+
+// readXLSX reads the sheets of the workbook at p, in order.
+func readXLSX(p string) ([]xlsxSheet, error) {
+	r, err := zip.OpenReader(p)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	decode := func(name string, v any) error {
+		f, err := r.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return xml.NewDecoder(f).Decode(v)
+	}
+
+	var workbook xlsxWorkbook
+	if err := decode("xl/workbook.xml", &workbook); err != nil {
+		return nil, fmt.Errorf("%v: %w", p, err)
+	}
+	var rels xlsxRelationships
+	if err := decode("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, fmt.Errorf("%v: %w", p, err)
+	}
+	targets := make(map[string]string)
+	for _, rel := range rels.Relationships {
+		// The targets are relative to xl/, unless absolute in the package.
+		if strings.HasPrefix(rel.Target, "/") {
+			targets[rel.ID] = strings.TrimPrefix(rel.Target, "/")
+		} else {
+			targets[rel.ID] = path.Join("xl", rel.Target)
+		}
+	}
+	// Workbooks without strings have no shared strings part.
+	var shared xlsxSharedStrings
+	if err := decode("xl/sharedStrings.xml", &shared); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%v: %w", p, err)
+	}
+
+	var sheets []xlsxSheet
+	for _, s := range workbook.Sheets {
+		var worksheet xlsxWorksheet
+		if err := decode(targets[s.RID], &worksheet); err != nil {
+			return nil, fmt.Errorf("%v: sheet %v: %w", p, s.Name, err)
+		}
+		sheet := xlsxSheet{name: s.Name}
+		for _, row := range worksheet.Rows {
+			var values []string
+			for _, cell := range row.Cells {
+				col := len(values)
+				if cell.Ref != "" {
+					col = xlsxColumn(cell.Ref)
+				}
+				for len(values) <= col {
+					values = append(values, "")
+				}
+				switch cell.Type {
+				case "s":
+					i, err := strconv.Atoi(cell.Value)
+					if err != nil || i < 0 || i >= len(shared.Items) {
+						return nil, fmt.Errorf("%v: sheet %v: cell %v: no shared string %q", p, s.Name, cell.Ref, cell.Value)
+					}
+					values[col] = shared.Items[i].String()
+				case "inlineStr":
+					values[col] = cell.Inline.String()
+				default:
+					values[col] = cell.Value
+				}
+			}
+			sheet.rows = append(sheet.rows, values)
+		}
+		sheets = append(sheets, sheet)
+	}
+	return sheets, nil
+}
+
+// xlsxColumn returns the index of the column of a cell reference, like 1 for B12.
+func xlsxColumn(ref string) int {
+	col := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+	}
+	return col - 1
+}
+
+// loadWorkbook loads the translations of the XLSX workbook at p. Every sheet is a namespace,
+// whose name prefixes its keys, like menu.save for the key save of the menu sheet. The first row
+// names the languages of the columns after the one of the keys, and the empty cells are untranslated.
+// The problems of the workbook are reported as findings.
+func loadWorkbook(p string) (map[string]Translation, []Finding) {
+	sheets, err := readXLSX(p)
+	if err != nil {
+		return nil, []Finding{{Check: "load", Message: err.Error()}}
+	}
+	translations := make(map[string]Translation)
+	var findings []Finding
+	seen := make(map[string]bool)
+	for _, sheet := range sheets {
+		if len(sheet.rows) == 0 {
+			continue
+		}
+		langs := sheet.rows[0]
+		for _, row := range sheet.rows[1:] {
+			if len(row) == 0 || strings.TrimSpace(row[0]) == "" {
+				continue
+			}
+			key := sheet.name + "." + strings.TrimSpace(row[0])
+			if seen[key] {
+				findings = append(findings, Finding{Check: "load", Message: fmt.Sprintf("%v: sheet %v: duplicate key %q", p, sheet.name, key)})
+				continue
+			}
+			seen[key] = true
+			for col, value := range row[1:] {
+				lang := ""
+				if col+1 < len(langs) {
+					lang = strings.TrimSpace(langs[col+1])
+				}
+				if lang == "" || value == "" {
+					continue
+				}
+				if translations[lang] == nil {
+					translations[lang] = Translation{}
+				}
+				translations[lang][key] = value
+			}
+		}
+	}
+	if _, ok := translations["en"]; !ok {
+		findings = append(findings, Finding{Check: "load", Message: fmt.Sprintf("%v: no en column", p)})
+	}
+	return translations, findings
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeXLSX writes a workbook made of the SpreadsheetML parts files, by name.
+func writeXLSX(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		part, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadWorkbook(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "delivery.xlsx")
+	writeXLSX(t, path, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="menu" sheetId="1" r:id="rId1"/><sheet name="emails" sheetId="2" r:id="rId2"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Target="/xl/worksheets/sheet2.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst><si><t>key</t></si><si><t>en</t></si><si><t>sv</t></si><si><t>save</t></si><si><r><t>Sa</t></r><r><t>ve</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c></row>
+<row r="2"><c r="A2" t="s"><v>3</v></c><c r="B2" t="s"><v>4</v></c><c r="C2" t="inlineStr"><is><t>Spara</t></is></c></row>
+<row r="4"><c r="A4" t="inlineStr"><is><t>open</t></is></c><c r="B4" t="inlineStr"><is><t>Open</t></is></c></row>
+<row r="5"><c r="A5" t="s"><v>3</v></c><c r="C5" t="inlineStr"><is><t>Spara igen</t></is></c></row>
+</sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet><sheetData>
+<row><c t="inlineStr"><is><t>key</t></is></c><c t="inlineStr"><is><t>sv</t></is></c><c t="inlineStr"><is><t>en</t></is></c></row>
+<row><c t="inlineStr"><is><t>count</t></is></c><c t="inlineStr"><is><t>$n$ brev</t></is></c><c><v>42</v></c></row>
+</sheetData></worksheet>`,
+	})
+
+	translations, findings := loadWorkbook(path)
+	want := map[string]Translation{
+		"en": {"menu.save": "Save", "menu.open": "Open", "emails.count": "42"},
+		"sv": {"menu.save": "Spara", "emails.count": "$n$ brev"},
+	}
+	if !reflect.DeepEqual(translations, want) {
+		t.Errorf("want %v, got %v", want, translations)
+	}
+	wantFindings := []Finding{{Check: "load", Message: path + `: sheet menu: duplicate key "menu.save"`}}
+	if !reflect.DeepEqual(findings, wantFindings) {
+		t.Errorf("want %v, got %v", wantFindings, findings)
+	}
+
+	if _, findings := loadWorkbook(filepath.Join(t.TempDir(), "missing.xlsx")); len(findings) != 1 {
+		t.Errorf("want a finding for a missing workbook, got %v", findings)
+	}
+}